@@ -0,0 +1,91 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"errors"
+)
+
+// FSAuthPerm is the permission granted on a path of a file system by
+// Authorize.
+type FSAuthPerm string
+
+const (
+	// FSAuthRead grants read-only access.
+	FSAuthRead = FSAuthPerm("r")
+	// FSAuthReadWrite grants read-write access.
+	FSAuthReadWrite = FSAuthPerm("rw")
+	// FSAuthReadWriteSnap grants read-write access including the creation
+	// and removal of snapshots.
+	FSAuthReadWriteSnap = FSAuthPerm("rws")
+	// FSAuthReadWriteLayout grants read-write access including changing
+	// layouts and quotas.
+	FSAuthReadWriteLayout = FSAuthPerm("rwp")
+	// FSAuthReadWriteSnapLayout grants read-write access including the
+	// creation and removal of snapshots and changing layouts and quotas.
+	FSAuthReadWriteSnapLayout = FSAuthPerm("rwps")
+)
+
+// FSAuthCap grants a permission on a path of a file system.
+type FSAuthCap struct {
+	// Path within the file system, for example "/" or "/volumes/grp/sub".
+	Path string
+	// Perm is the permission granted on the path.
+	Perm FSAuthPerm
+	// RootSquash, if set, prevents clients with uid 0 from writing to the
+	// path.
+	RootSquash bool
+}
+
+// FSAuthKey is the cephx key and caps of a client, as returned by Authorize.
+type FSAuthKey struct {
+	Entity string            `json:"entity"`
+	Key    string            `json:"key"`
+	Caps   map[string]string `json:"caps"`
+}
+
+var (
+	errNoAuthCaps = errors.New("at least one cap is required")
+	errNoAuthKey  = errors.New("no key returned for entity")
+)
+
+func parseFSAuthKey(res response) (*FSAuthKey, error) {
+	var keys []FSAuthKey
+	if err := res.Unmarshal(&keys).End(); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errNoAuthKey
+	}
+	return &keys[0], nil
+}
+
+// Authorize creates the cephx client entity, or updates its caps if the
+// entity exists, so that it is granted the given permissions on the paths
+// of the file system. The key and the resulting caps of the entity are
+// returned. The entity must be given in the form "client.<id>". Updating
+// the caps of an existing entity requires Ceph Reef or later.
+//
+// Similar To:
+//
+//	ceph fs authorize <fsname> <entity> <path> <perm> [root_squash] [<path> <perm> [root_squash]...]
+func (fsa *FSAdmin) Authorize(fsname, entity string, caps []FSAuthCap) (*FSAuthKey, error) {
+	if len(caps) == 0 {
+		return nil, errNoAuthCaps
+	}
+	args := make([]string, 0, 3*len(caps))
+	for _, c := range caps {
+		args = append(args, c.Path, string(c.Perm))
+		if c.RootSquash {
+			args = append(args, "root_squash")
+		}
+	}
+	m := map[string]interface{}{
+		"prefix":     "fs authorize",
+		"filesystem": fsname,
+		"entity":     entity,
+		"caps":       args,
+		"format":     "json",
+	}
+	return parseFSAuthKey(fsa.marshalMonCommand(m))
+}
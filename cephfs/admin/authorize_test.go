@@ -0,0 +1,69 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFSAuthKey(t *testing.T) {
+	R := newResponse
+	t.Run("error", func(t *testing.T) {
+		_, err := parseFSAuthKey(R(nil, "", errors.New("denied")))
+		assert.Error(t, err)
+	})
+	t.Run("ok", func(t *testing.T) {
+		k, err := parseFSAuthKey(R([]byte(`[{
+			"entity": "client.share1",
+			"key": "AQBKwWBmAAAAABAAbcd==",
+			"caps": {
+				"mds": "allow rw fsname=cephfs path=/share1",
+				"mon": "allow r fsname=cephfs",
+				"osd": "allow rw tag cephfs data=cephfs"
+			}
+		}]`), "added key for client.share1", nil))
+		assert.NoError(t, err)
+		if assert.NotNil(t, k) {
+			assert.Equal(t, "client.share1", k.Entity)
+			assert.Equal(t, "AQBKwWBmAAAAABAAbcd==", k.Key)
+			assert.Contains(t, k.Caps["mds"], "path=/share1")
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := parseFSAuthKey(R([]byte(`[]`), "", nil))
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	fsa := getFSAdmin(t)
+	entity := "client.goceph-authorize"
+	defer func() {
+		m := map[string]string{"prefix": "auth rm", "entity": entity}
+		assert.NoError(t, fsa.marshalMonCommand(m).End())
+	}()
+
+	k, err := fsa.Authorize("cephfs", entity, []FSAuthCap{
+		{Path: "/", Perm: FSAuthRead},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity, k.Entity)
+	assert.NotEmpty(t, k.Key)
+	assert.Contains(t, k.Caps["mds"], "allow r")
+	assert.Contains(t, k.Caps["mds"], "fsname=cephfs")
+
+	t.Run("noCaps", func(t *testing.T) {
+		_, err := fsa.Authorize("cephfs", entity, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("noSuchFS", func(t *testing.T) {
+		_, err := fsa.Authorize("noSuchFS", "client.goceph-authorize2",
+			[]FSAuthCap{{Path: "/", Perm: FSAuthReadWrite}})
+		assert.Error(t, err)
+	})
+}
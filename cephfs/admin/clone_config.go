@@ -0,0 +1,87 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxConcurrentClonesOption = "mgr/volumes/max_concurrent_clones"
+	snapshotCloneDelayOption  = "mgr/volumes/snapshot_clone_delay"
+)
+
+func (fsa *FSAdmin) setVolumesOption(name, value string) error {
+	m := map[string]string{
+		"prefix": "config set",
+		"who":    "mgr",
+		"name":   name,
+		"value":  value,
+	}
+	return fsa.marshalMonCommand(m).NoData().End()
+}
+
+func (fsa *FSAdmin) getVolumesOption(name string) (int, error) {
+	m := map[string]string{
+		"prefix": "config get",
+		"who":    "mgr",
+		"key":    name,
+	}
+	return parseIntOption(fsa.marshalMonCommand(m))
+}
+
+func parseIntOption(res response) (int, error) {
+	if err := res.NoStatus().End(); err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(res.Body())))
+}
+
+// SetMaxConcurrentClones sets the number of subvolume clones the volumes
+// module copies in parallel. Further clones are pending until a running
+// clone completes.
+//
+// Similar To:
+//
+//	ceph config set mgr mgr/volumes/max_concurrent_clones <n>
+func (fsa *FSAdmin) SetMaxConcurrentClones(n int) error {
+	return fsa.setVolumesOption(maxConcurrentClonesOption, strconv.Itoa(n))
+}
+
+// GetMaxConcurrentClones returns the number of subvolume clones the volumes
+// module copies in parallel.
+//
+// Similar To:
+//
+//	ceph config get mgr mgr/volumes/max_concurrent_clones
+func (fsa *FSAdmin) GetMaxConcurrentClones() (int, error) {
+	return fsa.getVolumesOption(maxConcurrentClonesOption)
+}
+
+// SetSnapshotCloneDelay sets the time the volumes module waits before it
+// starts copying a new subvolume clone. The delay is rounded down to whole
+// seconds.
+//
+// Similar To:
+//
+//	ceph config set mgr mgr/volumes/snapshot_clone_delay <seconds>
+func (fsa *FSAdmin) SetSnapshotCloneDelay(d time.Duration) error {
+	return fsa.setVolumesOption(
+		snapshotCloneDelayOption, strconv.Itoa(int(d/time.Second)))
+}
+
+// GetSnapshotCloneDelay returns the time the volumes module waits before it
+// starts copying a new subvolume clone.
+//
+// Similar To:
+//
+//	ceph config get mgr mgr/volumes/snapshot_clone_delay
+func (fsa *FSAdmin) GetSnapshotCloneDelay() (time.Duration, error) {
+	secs, err := fsa.getVolumesOption(snapshotCloneDelayOption)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(secs) * time.Second, nil
+}
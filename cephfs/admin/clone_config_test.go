@@ -0,0 +1,53 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIntOption(t *testing.T) {
+	R := newResponse
+	n, err := parseIntOption(R([]byte("4\n"), "", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	_, err = parseIntOption(R([]byte("four\n"), "", nil))
+	assert.Error(t, err)
+	_, err = parseIntOption(R(nil, "", errors.New("nope")))
+	assert.Error(t, err)
+}
+
+func TestCloneConfig(t *testing.T) {
+	fsa := getFSAdmin(t)
+
+	t.Run("maxConcurrentClones", func(t *testing.T) {
+		orig, err := fsa.GetMaxConcurrentClones()
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, fsa.SetMaxConcurrentClones(orig))
+		}()
+
+		require.NoError(t, fsa.SetMaxConcurrentClones(orig+2))
+		n, err := fsa.GetMaxConcurrentClones()
+		assert.NoError(t, err)
+		assert.Equal(t, orig+2, n)
+	})
+
+	t.Run("snapshotCloneDelay", func(t *testing.T) {
+		orig, err := fsa.GetSnapshotCloneDelay()
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, fsa.SetSnapshotCloneDelay(orig))
+		}()
+
+		require.NoError(t, fsa.SetSnapshotCloneDelay(3*time.Second))
+		d, err := fsa.GetSnapshotCloneDelay()
+		assert.NoError(t, err)
+		assert.Equal(t, 3*time.Second, d)
+	})
+}
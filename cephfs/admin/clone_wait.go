@@ -0,0 +1,137 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CloneCanceled is the state of a clone that was canceled using CancelClone.
+const CloneCanceled = CloneState("canceled")
+
+// defaultClonePollInterval is the interval WaitForClone polls the status of
+// a clone at if no interval is given.
+const defaultClonePollInterval = time.Second
+
+var (
+	// ErrCloneFailed is returned by WaitForClone if the clone failed.
+	ErrCloneFailed = errors.New("subvolume clone failed")
+	// ErrCloneCanceled is returned by WaitForClone if the clone was canceled.
+	ErrCloneCanceled = errors.New("subvolume clone canceled")
+)
+
+// unitShift maps the unit suffixes used by the mgr to format byte counts to
+// the power of 1024 they represent.
+var unitShift = map[byte]uint{
+	'k': 10, 'K': 10, 'M': 20, 'G': 30, 'T': 40, 'P': 50, 'E': 60,
+}
+
+// Percent returns the percentage of the source that has been cloned.
+func (r CloneProgressReport) Percent() (float64, error) {
+	s := strings.TrimSpace(r.PercentageCloned)
+	return strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+}
+
+// Bytes returns the number of bytes cloned and the total number of bytes to
+// clone. The mgr reports the byte counts rounded to a few significant digits
+// only, so the returned values are approximations.
+func (r CloneProgressReport) Bytes() (uint64, uint64, error) {
+	return parseProgressPair(r.AmountCloned, parseByteCount)
+}
+
+// Files returns the number of files cloned and the total number of files to
+// clone.
+func (r CloneProgressReport) Files() (uint64, uint64, error) {
+	return parseProgressPair(r.FilesCloned, func(s string) (uint64, error) {
+		return strconv.ParseUint(s, 10, 64)
+	})
+}
+
+func parseProgressPair(
+	s string, parse func(string) (uint64, error)) (uint64, uint64, error) {
+
+	done, total, found := strings.Cut(s, "/")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid clone progress: %q", s)
+	}
+	d, err := parse(strings.TrimSpace(done))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid clone progress: %q: %w", s, err)
+	}
+	t, err := parse(strings.TrimSpace(total))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid clone progress: %q: %w", s, err)
+	}
+	return d, t, nil
+}
+
+// parseByteCount parses byte counts like "376M" or "3.0G" as formatted by
+// the mgr.
+func parseByteCount(s string) (uint64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty byte count")
+	}
+	var shift uint
+	if sh, ok := unitShift[s[len(s)-1]]; ok {
+		shift = sh
+		s = strings.TrimSpace(s[:len(s)-1])
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("negative byte count: %v", v)
+	}
+	return uint64(v * float64(uint64(1)<<shift)), nil
+}
+
+// WaitForClone polls the status of a subvolume clone every interval until
+// the clone is complete, and returns the final status. If interval is zero
+// a default interval is used. If progress is not nil, it is called with
+// every status polled while the clone is pending or in progress.
+//
+// ErrCloneFailed is returned along with the status if the clone failed, and
+// ErrCloneCanceled if it was canceled. If ctx is canceled before the clone
+// completes, the error of the context is returned.
+func (fsa *FSAdmin) WaitForClone(ctx context.Context,
+	volume, group, clone string, interval time.Duration,
+	progress func(*CloneStatus)) (*CloneStatus, error) {
+
+	if interval <= 0 {
+		interval = defaultClonePollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status, err := fsa.CloneStatus(volume, group, clone)
+		if err != nil {
+			return nil, err
+		}
+		switch status.State {
+		case CloneComplete:
+			return status, nil
+		case CloneFailed:
+			if f := status.failure; f != nil {
+				return status, fmt.Errorf("%w: %s (errno %s)",
+					ErrCloneFailed, f.ErrStr, f.Errno)
+			}
+			return status, ErrCloneFailed
+		case CloneCanceled:
+			return status, ErrCloneCanceled
+		}
+		if progress != nil {
+			progress(status)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,114 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneProgressReportParse(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		r := CloneProgressReport{
+			PercentageCloned: "12.5%",
+			AmountCloned:     "376M/3.0G",
+			FilesCloned:      "4/6",
+		}
+		p, err := r.Percent()
+		assert.NoError(t, err)
+		assert.Equal(t, 12.5, p)
+		done, total, err := r.Bytes()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 376<<20, done)
+		assert.EqualValues(t, 3<<30, total)
+		done, total, err = r.Files()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 4, done)
+		assert.EqualValues(t, 6, total)
+	})
+	t.Run("smallSizes", func(t *testing.T) {
+		r := CloneProgressReport{AmountCloned: "  0 /1.5k"}
+		done, total, err := r.Bytes()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0, done)
+		assert.EqualValues(t, 1536, total)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		r := CloneProgressReport{
+			PercentageCloned: "lots",
+			AmountCloned:     "376M",
+			FilesCloned:      "4/x",
+		}
+		_, err := r.Percent()
+		assert.Error(t, err)
+		_, _, err = r.Bytes()
+		assert.Error(t, err)
+		_, _, err = r.Files()
+		assert.Error(t, err)
+		_, _, err = CloneProgressReport{AmountCloned: "1Q/2M"}.Bytes()
+		assert.Error(t, err)
+	})
+}
+
+func TestWaitForClone(t *testing.T) {
+	fsa := getFSAdmin(t)
+	volume := "cephfs"
+	group := NoGroup
+	subname := "waitForCloneSrc"
+	snapname := "waitForCloneSnap"
+	clonename := "waitForCloneDst"
+
+	err := fsa.CreateSubVolume(volume, group, subname, nil)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolume(volume, group, subname))
+	}()
+	err = fsa.CreateSubVolumeSnapshot(volume, group, subname, snapname)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolumeSnapshot(volume, group, subname, snapname))
+	}()
+
+	err = fsa.CloneSubVolumeSnapshot(volume, group, subname, snapname, clonename, nil)
+	var x NotProtectedError
+	if errors.As(err, &x) {
+		err = fsa.ProtectSubVolumeSnapshot(volume, group, subname, snapname)
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, fsa.UnprotectSubVolumeSnapshot(volume, group, subname, snapname))
+		}()
+		err = fsa.CloneSubVolumeSnapshot(volume, group, subname, snapname, clonename, nil)
+	}
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolume(volume, group, clonename))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	status, err := fsa.WaitForClone(
+		ctx, volume, group, clonename, 50*time.Millisecond, nil)
+	require.NoError(t, err)
+	assert.Equal(t, CloneComplete, status.State)
+	assert.Equal(t, subname, status.Source.SubVolume)
+
+	t.Run("canceledContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// the clone is complete, so the context is never checked
+		status, err := fsa.WaitForClone(ctx, volume, group, clonename, 0, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, status)
+	})
+
+	t.Run("notExist", func(t *testing.T) {
+		_, err := fsa.WaitForClone(
+			context.Background(), volume, group, "noSuchClone", 0, nil)
+		assert.Error(t, err)
+	})
+}
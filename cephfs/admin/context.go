@@ -0,0 +1,92 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/ceph/go-ceph/rados"
+)
+
+// ctxCommander wraps a RadosCommander so that commands return once the
+// context is done or the timeout has passed.
+type ctxCommander struct {
+	conn    RadosCommander
+	ctx     context.Context
+	timeout time.Duration
+}
+
+type commandResult struct {
+	buf    []byte
+	status string
+	err    error
+}
+
+func (c *ctxCommander) run(f func() ([]byte, string, error)) ([]byte, string, error) {
+	if c.conn == nil {
+		return nil, "", rados.ErrNotConnected
+	}
+	ctx := c.ctx
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	ch := make(chan commandResult, 1)
+	go func() {
+		buf, status, err := f()
+		ch <- commandResult{buf, status, err}
+	}()
+	select {
+	case r := <-ch:
+		return r.buf, r.status, r.err
+	case <-ctx.Done():
+		return nil, "", ctx.Err()
+	}
+}
+
+// MgrCommand implements the MgrCommander interface.
+func (c *ctxCommander) MgrCommand(buf [][]byte) ([]byte, string, error) {
+	return c.run(func() ([]byte, string, error) {
+		return c.conn.MgrCommand(buf)
+	})
+}
+
+// MonCommand implements the MonCommander interface.
+func (c *ctxCommander) MonCommand(buf []byte) ([]byte, string, error) {
+	return c.run(func() ([]byte, string, error) {
+		return c.conn.MonCommand(buf)
+	})
+}
+
+// NewFromConnWithTimeout creates an FSAdmin management object like
+// NewFromConn, but every command it sends returns with
+// context.DeadlineExceeded if no reply has been received within timeout.
+//
+// A command that times out is not aborted. Ceph may still execute it, and
+// the call to the underlying connection keeps running in the background
+// until the reply arrives.
+func NewFromConnWithTimeout(conn RadosCommander, timeout time.Duration) *FSAdmin {
+	return &FSAdmin{conn: &ctxCommander{
+		conn:    conn,
+		ctx:     context.Background(),
+		timeout: timeout,
+	}}
+}
+
+// WithContext returns a copy of the FSAdmin whose commands return the error
+// of ctx once ctx is done. A timeout set using NewFromConnWithTimeout still
+// applies to each command. As with timeouts, commands are not aborted when
+// ctx is done.
+func (fsa *FSAdmin) WithContext(ctx context.Context) *FSAdmin {
+	c := &ctxCommander{conn: fsa.conn, ctx: ctx}
+	if cc, ok := fsa.conn.(*ctxCommander); ok {
+		c.conn = cc.conn
+		c.timeout = cc.timeout
+	}
+	return &FSAdmin{conn: c}
+}
@@ -0,0 +1,75 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ceph/go-ceph/rados"
+)
+
+// slowConn is a RadosCommander that replies after a delay.
+type slowConn struct {
+	delay time.Duration
+}
+
+func (c *slowConn) reply() ([]byte, string, error) {
+	time.Sleep(c.delay)
+	return []byte(`["vol1"]`), "", nil
+}
+
+func (c *slowConn) MgrCommand(buf [][]byte) ([]byte, string, error) {
+	return c.reply()
+}
+
+func (c *slowConn) MonCommand(buf []byte) ([]byte, string, error) {
+	return c.reply()
+}
+
+func TestFSAdminTimeout(t *testing.T) {
+	t.Run("inTime", func(t *testing.T) {
+		fsa := NewFromConnWithTimeout(&slowConn{}, time.Second)
+		res := fsa.marshalMgrCommand(map[string]string{"prefix": "x"})
+		assert.NoError(t, res.End())
+		assert.Equal(t, []byte(`["vol1"]`), res.Body())
+	})
+	t.Run("timeout", func(t *testing.T) {
+		fsa := NewFromConnWithTimeout(&slowConn{delay: time.Second}, 10*time.Millisecond)
+		err := fsa.marshalMonCommand(map[string]string{"prefix": "x"}).End()
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+	t.Run("notConnected", func(t *testing.T) {
+		fsa := NewFromConnWithTimeout(nil, time.Second)
+		err := fsa.marshalMgrCommand(map[string]string{"prefix": "x"}).End()
+		assert.True(t, errors.Is(err, rados.ErrNotConnected))
+	})
+}
+
+func TestFSAdminWithContext(t *testing.T) {
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		fsa := NewFromConn(&slowConn{}).WithContext(ctx)
+		err := fsa.marshalMgrCommand(map[string]string{"prefix": "x"}).End()
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+	t.Run("keepsTimeout", func(t *testing.T) {
+		fsa := NewFromConnWithTimeout(&slowConn{delay: time.Second}, 10*time.Millisecond)
+		fsa = fsa.WithContext(context.Background())
+		err := fsa.marshalMgrCommand(map[string]string{"prefix": "x"}).End()
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+	t.Run("cluster", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		fsa := getFSAdmin(t).WithContext(ctx)
+		vols, err := fsa.ListVolumes()
+		assert.NoError(t, err)
+		assert.Contains(t, vols, "cephfs")
+	})
+}
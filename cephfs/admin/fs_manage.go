@@ -0,0 +1,90 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"strconv"
+)
+
+// setFS sets a variable of the file system map.
+func (fsa *FSAdmin) setFS(fsname, name, value string) error {
+	m := map[string]string{
+		"prefix":  "fs set",
+		"fs_name": fsname,
+		"var":     name,
+		"val":     value,
+		"format":  "json",
+	}
+	// the status may contain informational messages on success
+	return fsa.marshalMonCommand(m).NoBody().End()
+}
+
+// SetMaxMDS sets the number of active MDS daemons of a file system. Standby
+// daemons are promoted, or active daemons are stopped, until the number of
+// active daemons matches.
+//
+// Similar To:
+//
+//	ceph fs set <fsname> max_mds <n>
+func (fsa *FSAdmin) SetMaxMDS(fsname string, n int) error {
+	return fsa.setFS(fsname, "max_mds", strconv.Itoa(n))
+}
+
+// SetAllowStandbyReplay enables or disables standby-replay daemons for a
+// file system. A standby-replay daemon follows the journal of an active MDS
+// to be able to take over more quickly if the active MDS fails.
+//
+// Similar To:
+//
+//	ceph fs set <fsname> allow_standby_replay <true|false>
+func (fsa *FSAdmin) SetAllowStandbyReplay(fsname string, allow bool) error {
+	return fsa.setFS(fsname, "allow_standby_replay", strconv.FormatBool(allow))
+}
+
+// FailMDS marks an MDS daemon as failed, causing a standby daemon, if any,
+// to take over. The daemon may be given by its role, like "cephfs:0", its
+// name or its GID.
+//
+// Similar To:
+//
+//	ceph mds fail <role_or_gid>
+func (fsa *FSAdmin) FailMDS(roleOrGID string) error {
+	m := map[string]string{
+		"prefix":      "mds fail",
+		"role_or_gid": roleOrGID,
+		"format":      "json",
+	}
+	return fsa.marshalMonCommand(m).NoBody().End()
+}
+
+func (fsa *FSAdmin) requiredClientFeatures(fsname, subop, feature string) error {
+	m := map[string]string{
+		"prefix":  "fs required_client_features",
+		"fs_name": fsname,
+		"subop":   subop,
+		"val":     feature,
+		"format":  "json",
+	}
+	return fsa.marshalMonCommand(m).NoBody().End()
+}
+
+// AddRequiredClientFeature requires clients of a file system to support the
+// given feature. The feature is given by name, like "metric_collect", or by
+// bit number. Clients lacking the feature are evicted and can not connect.
+//
+// Similar To:
+//
+//	ceph fs required_client_features <fsname> add <feature>
+func (fsa *FSAdmin) AddRequiredClientFeature(fsname, feature string) error {
+	return fsa.requiredClientFeatures(fsname, "add", feature)
+}
+
+// RemoveRequiredClientFeature removes a feature previously required by
+// AddRequiredClientFeature.
+//
+// Similar To:
+//
+//	ceph fs required_client_features <fsname> rm <feature>
+func (fsa *FSAdmin) RemoveRequiredClientFeature(fsname, feature string) error {
+	return fsa.requiredClientFeatures(fsname, "rm", feature)
+}
@@ -0,0 +1,55 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMDSMap struct {
+	MaxMDS int `json:"max_mds"`
+}
+
+func getTestMDSMap(t *testing.T, fsa *FSAdmin, fsname string) testMDSMap {
+	m := map[string]string{
+		"prefix":  "fs get",
+		"fs_name": fsname,
+		"format":  "json",
+	}
+	var v struct {
+		MDSMap testMDSMap `json:"mdsmap"`
+	}
+	err := fsa.marshalMonCommand(m).NoStatus().Unmarshal(&v).End()
+	require.NoError(t, err)
+	return v.MDSMap
+}
+
+func TestFSManage(t *testing.T) {
+	fsa := getFSAdmin(t)
+	fsname := "cephfs"
+
+	t.Run("maxMDS", func(t *testing.T) {
+		orig := getTestMDSMap(t, fsa, fsname).MaxMDS
+		assert.NoError(t, fsa.SetMaxMDS(fsname, orig))
+		assert.Equal(t, orig, getTestMDSMap(t, fsa, fsname).MaxMDS)
+	})
+
+	t.Run("standbyReplay", func(t *testing.T) {
+		assert.NoError(t, fsa.SetAllowStandbyReplay(fsname, true))
+		assert.NoError(t, fsa.SetAllowStandbyReplay(fsname, false))
+	})
+
+	t.Run("requiredClientFeatures", func(t *testing.T) {
+		assert.NoError(t, fsa.AddRequiredClientFeature(fsname, "reply_encoding"))
+		assert.NoError(t, fsa.RemoveRequiredClientFeature(fsname, "reply_encoding"))
+		assert.Error(t, fsa.AddRequiredClientFeature(fsname, "noSuchFeature"))
+	})
+
+	t.Run("noSuchFS", func(t *testing.T) {
+		assert.Error(t, fsa.SetMaxMDS("noSuchFS", 1))
+		assert.Error(t, fsa.SetAllowStandbyReplay("noSuchFS", false))
+	})
+}
@@ -0,0 +1,109 @@
+//go:build ceph_preview
+
+package admin
+
+import "time"
+
+// States of a quiesce set, or of a member of a quiesce set, as reported by
+// QuiesceState.Name.
+const (
+	QuiesceStateQuiescing = "QUIESCING"
+	QuiesceStateQuiesced  = "QUIESCED"
+	QuiesceStateReleasing = "RELEASING"
+	QuiesceStateReleased  = "RELEASED"
+	QuiesceStateExpired   = "EXPIRED"
+	QuiesceStateFailed    = "FAILED"
+	QuiesceStateCanceled  = "CANCELED"
+	QuiesceStateTimedout  = "TIMEDOUT"
+)
+
+// QuiesceSetOptions are optional values used when quiescing subvolumes with
+// QuiesceSet.
+type QuiesceSetOptions struct {
+	// Timeout is the time the members of the set have to become quiesced,
+	// after which the set fails with QuiesceStateTimedout.
+	Timeout time.Duration
+	// Expiration is the time the set stays quiesced, after which it is
+	// released automatically and reported with QuiesceStateExpired.
+	Expiration time.Duration
+	// Await makes the call block until the set is quiesced.
+	Await bool
+	// AwaitFor limits the time the call blocks if Await is set.
+	AwaitFor time.Duration
+	// IfVersion, if non-zero, makes the call fail unless the set has the
+	// given version.
+	IfVersion int
+}
+
+// QuiesceReleaseOptions are optional values used when releasing a quiesce
+// set with QuiesceRelease.
+type QuiesceReleaseOptions struct {
+	// Await makes the call block until the set is released.
+	Await bool
+	// AwaitFor limits the time the call blocks if Await is set.
+	AwaitFor time.Duration
+	// IfVersion, if non-zero, makes the call fail unless the set has the
+	// given version.
+	IfVersion int
+}
+
+// QuiesceSet quiesces the given subvolumes of a volume as a single quiesce
+// set. While the set is quiesced no I/O is performed on the subvolumes, so
+// that snapshots taken of the subvolumes are consistent with each other. If
+// setID is empty a new set with a generated ID is created. The ID of the set
+// is the key of the Sets field of the returned FSQuiesceInfo.
+//
+// Similar To:
+//
+//	ceph fs quiesce <volume> --group-name <group> --set-id <setID> <subvolumes>...
+func (fsa *FSAdmin) QuiesceSet(volume, group string, subvolumes []string,
+	setID string, o *QuiesceSetOptions) (*FSQuiesceInfo, error) {
+
+	if o == nil {
+		o = &QuiesceSetOptions{}
+	}
+	qo := &FSQuiesceOptions{
+		Timeout:    o.Timeout.Seconds(),
+		Expiration: o.Expiration.Seconds(),
+		AwaitFor:   o.AwaitFor.Seconds(),
+		Await:      o.Await,
+		IfVersion:  o.IfVersion,
+	}
+	return fsa.FSQuiesce(volume, group, subvolumes, setID, qo)
+}
+
+// QuiesceRelease releases the quiesce set with the given ID, allowing I/O on
+// its members to resume.
+//
+// Similar To:
+//
+//	ceph fs quiesce <volume> --set-id <setID> --release
+func (fsa *FSAdmin) QuiesceRelease(
+	volume, setID string, o *QuiesceReleaseOptions) (*FSQuiesceInfo, error) {
+
+	if o == nil {
+		o = &QuiesceReleaseOptions{}
+	}
+	qo := &FSQuiesceOptions{
+		AwaitFor:  o.AwaitFor.Seconds(),
+		Await:     o.Await,
+		IfVersion: o.IfVersion,
+		Release:   true,
+	}
+	return fsa.FSQuiesce(volume, NoGroup, nil, setID, qo)
+}
+
+// QuiesceQuery returns the state of the quiesce set with the given ID. If
+// setID is empty the state of all the quiesce sets of the volume is
+// returned.
+//
+// Similar To:
+//
+//	ceph fs quiesce <volume> --set-id <setID> --query
+func (fsa *FSAdmin) QuiesceQuery(volume, setID string) (*FSQuiesceInfo, error) {
+	qo := &FSQuiesceOptions{
+		Query: true,
+		All:   setID == "",
+	}
+	return fsa.FSQuiesce(volume, NoGroup, nil, setID, qo)
+}
@@ -0,0 +1,54 @@
+//go:build ceph_preview && !(nautilus || octopus || pacific || quincy || reef)
+
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuiesceSet(t *testing.T) {
+	fsa := getFSAdmin(t)
+	volume := "cephfs"
+	group := NoGroup
+	subvols := []string{"quiesceSet1", "quiesceSet2"}
+	for _, sv := range subvols {
+		require.NoError(t, fsa.CreateSubVolume(volume, group, sv, nil))
+		defer func(sv string) {
+			assert.NoError(t, fsa.RemoveSubVolume(volume, group, sv))
+		}(sv)
+	}
+
+	setID := "goceph-quiesce-set"
+	ret, err := fsa.QuiesceSet(volume, group, subvols, setID, &QuiesceSetOptions{
+		Timeout:    30 * time.Second,
+		Expiration: 60 * time.Second,
+		Await:      true,
+	})
+	require.NoError(t, err)
+	require.Contains(t, ret.Sets, setID)
+	set := ret.Sets[setID]
+	assert.Equal(t, QuiesceStateQuiesced, set.State.Name)
+	assert.Equal(t, 30.0, set.Timeout)
+	assert.Equal(t, 60.0, set.Expiration)
+	assert.Len(t, set.Members, len(subvols))
+
+	ret, err = fsa.QuiesceQuery(volume, setID)
+	require.NoError(t, err)
+	require.Contains(t, ret.Sets, setID)
+	assert.Equal(t, QuiesceStateQuiesced, ret.Sets[setID].State.Name)
+
+	ret, err = fsa.QuiesceQuery(volume, "")
+	require.NoError(t, err)
+	assert.Contains(t, ret.Sets, setID)
+
+	ret, err = fsa.QuiesceRelease(volume, setID, &QuiesceReleaseOptions{
+		Await: true,
+	})
+	require.NoError(t, err)
+	require.Contains(t, ret.Sets, setID)
+	assert.Equal(t, QuiesceStateReleased, ret.Sets[setID].State.Name)
+}
@@ -0,0 +1,160 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"encoding/json"
+
+	"github.com/ceph/go-ceph/rados"
+)
+
+// MdsCommander is an interface for sending JSON formatted commands to a
+// specific MDS. It is implemented by cephfs.MountInfo.
+type MdsCommander interface {
+	MdsCommand(mdsSpec string, args [][]byte) ([]byte, string, error)
+}
+
+// MDSAdmin is used to query and troubleshoot the MDS daemons of a CephFS
+// file system. Unlike FSAdmin, that talks to the MGR and MON, MDSAdmin sends
+// its commands directly to an MDS using a mounted CephFS client.
+type MDSAdmin struct {
+	conn MdsCommander
+}
+
+// NewMDSAdmin creates an MDSAdmin management object from an MdsCommander,
+// such as a mounted cephfs.MountInfo.
+func NewMDSAdmin(conn MdsCommander) *MDSAdmin {
+	return &MDSAdmin{conn}
+}
+
+func (ma *MDSAdmin) validate() error {
+	if ma.conn == nil {
+		return rados.ErrNotConnected
+	}
+	return nil
+}
+
+// marshalMdsCommand converts v to JSON and sends it as a command to the MDS
+// identified by mdsSpec.
+func (ma *MDSAdmin) marshalMdsCommand(mdsSpec string, v interface{}) response {
+	if err := ma.validate(); err != nil {
+		return newResponse(nil, "", err)
+	}
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return newResponse(nil, "", err)
+	}
+	return newResponse(ma.conn.MdsCommand(mdsSpec, [][]byte{buf}))
+}
+
+// MDSClientMetadata contains the metadata a client reported to the MDS when
+// establishing its session, such as "hostname", "entity_id" and "root".
+type MDSClientMetadata map[string]string
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Metadata entries
+// that are not strings, such as the client features, are skipped.
+func (m *MDSClientMetadata) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	md := MDSClientMetadata{}
+	for k, v := range raw {
+		var s string
+		if err := json.Unmarshal(v, &s); err == nil {
+			md[k] = s
+		}
+	}
+	*m = md
+	return nil
+}
+
+// MDSSession describes a client session of an MDS.
+type MDSSession struct {
+	ID                   int64             `json:"id"`
+	Inst                 string            `json:"inst"`
+	State                string            `json:"state"`
+	NumLeases            int64             `json:"num_leases"`
+	NumCaps              int64             `json:"num_caps"`
+	RequestLoadAvg       int64             `json:"request_load_avg"`
+	Uptime               float64           `json:"uptime"`
+	RequestsInFlight     int64             `json:"requests_in_flight"`
+	NumCompletedRequests int64             `json:"num_completed_requests"`
+	NumCompletedFlushes  int64             `json:"num_completed_flushes"`
+	Reconnecting         bool              `json:"reconnecting"`
+	ClientMetadata       MDSClientMetadata `json:"client_metadata"`
+}
+
+func parseMDSSessions(res response) ([]MDSSession, error) {
+	var sessions []MDSSession
+	if err := res.NoStatus().Unmarshal(&sessions).End(); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// ListSessions returns the client sessions of the MDS identified by mdsSpec,
+// the rank, GID or name of an MDS.
+//
+// Similar To:
+//
+//	ceph tell mds.<mdsSpec> session ls
+func (ma *MDSAdmin) ListSessions(mdsSpec string) ([]MDSSession, error) {
+	m := map[string]string{
+		"prefix": "session ls",
+		"format": "json",
+	}
+	return parseMDSSessions(ma.marshalMdsCommand(mdsSpec, m))
+}
+
+// MDSOpEvent is an event in the life of an MDS operation.
+type MDSOpEvent struct {
+	Time  string `json:"time"`
+	Event string `json:"event"`
+}
+
+// MDSOpTypeData contains the type specific details of an MDS operation.
+type MDSOpTypeData struct {
+	FlagPoint string       `json:"flag_point"`
+	ReqID     string       `json:"reqid"`
+	OpType    string       `json:"op_type"`
+	Events    []MDSOpEvent `json:"events"`
+}
+
+// MDSOp describes an operation being processed by an MDS.
+type MDSOp struct {
+	Description string        `json:"description"`
+	InitiatedAt string        `json:"initiated_at"`
+	Age         float64       `json:"age"`
+	Duration    float64       `json:"duration"`
+	TypeData    MDSOpTypeData `json:"type_data"`
+}
+
+// MDSOpsInFlight contains the operations currently being processed by an
+// MDS.
+type MDSOpsInFlight struct {
+	Ops    []MDSOp `json:"ops"`
+	NumOps int     `json:"num_ops"`
+}
+
+func parseMDSOpsInFlight(res response) (*MDSOpsInFlight, error) {
+	var ops MDSOpsInFlight
+	if err := res.NoStatus().Unmarshal(&ops).End(); err != nil {
+		return nil, err
+	}
+	return &ops, nil
+}
+
+// DumpOpsInFlight returns the operations currently being processed by the
+// MDS identified by mdsSpec, the rank, GID or name of an MDS.
+//
+// Similar To:
+//
+//	ceph tell mds.<mdsSpec> dump_ops_in_flight
+func (ma *MDSAdmin) DumpOpsInFlight(mdsSpec string) (*MDSOpsInFlight, error) {
+	m := map[string]string{
+		"prefix": "dump_ops_in_flight",
+		"format": "json",
+	}
+	return parseMDSOpsInFlight(ma.marshalMdsCommand(mdsSpec, m))
+}
@@ -0,0 +1,135 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sampleSessionList = []byte(`[
+  {
+    "id": 4305,
+    "entity": {
+      "name": {"type": "client", "num": 4305},
+      "addr": {"type": "v1", "addr": "10.0.0.1:0", "nonce": 1234}
+    },
+    "state": "open",
+    "num_leases": 0,
+    "num_caps": 5,
+    "request_load_avg": 2,
+    "uptime": 12.5,
+    "requests_in_flight": 0,
+    "num_completed_requests": 1,
+    "num_completed_flushes": 0,
+    "reconnecting": false,
+    "inst": "client.4305 v1:10.0.0.1:0/1234",
+    "client_metadata": {
+      "client_features": {"feature_bits": "0x0000000000003bff"},
+      "entity_id": "admin",
+      "hostname": "node1",
+      "root": "/"
+    }
+  }
+]`)
+
+var sampleOpsInFlight = []byte(`{
+  "ops": [
+    {
+      "description": "client_request(client.4305:3 getattr pAsLsXsFs #0x1 2024-01-01T00:00:00.000000+0000 caller_uid=0, caller_gid=0{})",
+      "initiated_at": "2024-01-01T00:00:00.000000+0000",
+      "age": 1.5,
+      "duration": 1.5,
+      "type_data": {
+        "flag_point": "failed to rdlock, waiting",
+        "reqid": "client.4305:3",
+        "op_type": "client_request",
+        "client_info": {"client": "client.4305", "tid": 3},
+        "events": [
+          {"time": "2024-01-01T00:00:00.000000+0000", "event": "initiated"}
+        ]
+      }
+    }
+  ],
+  "num_ops": 1
+}`)
+
+func TestParseMDSSessions(t *testing.T) {
+	R := newResponse
+	t.Run("error", func(t *testing.T) {
+		_, err := parseMDSSessions(R(nil, "", errors.New("bonk")))
+		assert.Error(t, err)
+	})
+	t.Run("badJSON", func(t *testing.T) {
+		_, err := parseMDSSessions(R([]byte("[{"), "", nil))
+		assert.Error(t, err)
+	})
+	t.Run("ok", func(t *testing.T) {
+		s, err := parseMDSSessions(R(sampleSessionList, "", nil))
+		require.NoError(t, err)
+		require.Len(t, s, 1)
+		assert.EqualValues(t, 4305, s[0].ID)
+		assert.Equal(t, "open", s[0].State)
+		assert.EqualValues(t, 5, s[0].NumCaps)
+		assert.Equal(t, MDSClientMetadata{
+			"entity_id": "admin",
+			"hostname":  "node1",
+			"root":      "/",
+		}, s[0].ClientMetadata)
+	})
+}
+
+func TestParseMDSOpsInFlight(t *testing.T) {
+	R := newResponse
+	t.Run("error", func(t *testing.T) {
+		_, err := parseMDSOpsInFlight(R(nil, "", errors.New("bonk")))
+		assert.Error(t, err)
+	})
+	t.Run("ok", func(t *testing.T) {
+		ops, err := parseMDSOpsInFlight(R(sampleOpsInFlight, "", nil))
+		require.NoError(t, err)
+		assert.Equal(t, 1, ops.NumOps)
+		require.Len(t, ops.Ops, 1)
+		assert.Equal(t, "client_request", ops.Ops[0].TypeData.OpType)
+		assert.Equal(t, "client.4305:3", ops.Ops[0].TypeData.ReqID)
+		require.Len(t, ops.Ops[0].TypeData.Events, 1)
+		assert.Equal(t, "initiated", ops.Ops[0].TypeData.Events[0].Event)
+	})
+}
+
+func TestMDSAdmin(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		ma := &MDSAdmin{}
+		_, err := ma.ListSessions("0")
+		assert.Error(t, err)
+		_, err = ma.DumpOpsInFlight("0")
+		assert.Error(t, err)
+	})
+
+	mount := fsConnect(t, "")
+	defer func() {
+		assert.NoError(t, mount.Unmount())
+		assert.NoError(t, mount.Release())
+	}()
+	ma := NewMDSAdmin(mount)
+
+	t.Run("listSessions", func(t *testing.T) {
+		sessions, err := ma.ListSessions("0")
+		require.NoError(t, err)
+		// at least the session of our own mount exists
+		assert.NotEmpty(t, sessions)
+		for _, s := range sessions {
+			assert.NotZero(t, s.ID)
+			assert.NotEmpty(t, s.State)
+		}
+	})
+
+	t.Run("dumpOpsInFlight", func(t *testing.T) {
+		ops, err := ma.DumpOpsInFlight("0")
+		require.NoError(t, err)
+		assert.Equal(t, len(ops.Ops), ops.NumOps)
+	})
+}
@@ -0,0 +1,50 @@
+//go:build !(nautilus || octopus || pacific) && ceph_preview
+
+package admin
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SetMetadataMap sets all the key-value pairs of metadata as custom metadata
+// on the subvolume in a volume belonging to an optional subvolume group. The
+// keys are set in sorted order. Setting stops at the first key that can not
+// be set, the error returned names that key.
+//
+// Similar To:
+//
+//	ceph fs subvolume metadata set <vol_name> <sub_name> <key_name> <value> [--group_name <subvol_group_name>]
+func (fsa *FSAdmin) SetMetadataMap(volume, group, subvolume string, metadata map[string]string) error {
+	return setMetadataMap(metadata, func(key, value string) error {
+		return fsa.SetMetadata(volume, group, subvolume, key, value)
+	})
+}
+
+// SetSnapshotMetadataMap sets all the key-value pairs of metadata as custom
+// metadata on the subvolume snapshot in a volume belonging to an optional
+// subvolume group. The keys are set in sorted order. Setting stops at the
+// first key that can not be set, the error returned names that key.
+//
+// Similar To:
+//
+//	ceph fs subvolume snapshot metadata set <vol_name> <sub_name> <snap_name> <key_name> <value> [--group_name <subvol_group_name>]
+func (fsa *FSAdmin) SetSnapshotMetadataMap(volume, group, subvolume, snapname string, metadata map[string]string) error {
+	return setMetadataMap(metadata, func(key, value string) error {
+		return fsa.SetSnapshotMetadata(volume, group, subvolume, snapname, key, value)
+	})
+}
+
+func setMetadataMap(metadata map[string]string, set func(key, value string) error) error {
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := set(k, metadata[k]); err != nil {
+			return fmt.Errorf("failed to set metadata key %q: %w", k, err)
+		}
+	}
+	return nil
+}
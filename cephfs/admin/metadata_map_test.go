@@ -0,0 +1,58 @@
+//go:build !(nautilus || octopus || pacific) && ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetMetadataMap(t *testing.T) {
+	fsa := getFSAdmin(t)
+	volume := "cephfs"
+	group := NoGroup
+	subname := "subVolMetaMap"
+	snapname := "snapMetaMap"
+	metadata := map[string]string{
+		"owner":   "team-a",
+		"billing": "cc-1234",
+	}
+
+	err := fsa.CreateSubVolume(volume, group, subname, nil)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolume(volume, group, subname))
+	}()
+
+	err = fsa.SetMetadataMap(volume, group, subname, metadata)
+	assert.NoError(t, err)
+	ret, err := fsa.ListMetadata(volume, group, subname)
+	assert.NoError(t, err)
+	assert.Equal(t, metadata, ret)
+
+	err = fsa.CreateSubVolumeSnapshot(volume, group, subname, snapname)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolumeSnapshot(volume, group, subname, snapname))
+	}()
+
+	err = fsa.SetSnapshotMetadataMap(volume, group, subname, snapname, metadata)
+	assert.NoError(t, err)
+	ret, err = fsa.ListSnapshotMetadata(volume, group, subname, snapname)
+	assert.NoError(t, err)
+	assert.Equal(t, metadata, ret)
+
+	t.Run("notExist", func(t *testing.T) {
+		var ec ErrCode
+		err := fsa.SetMetadataMap(volume, group, "noSuchSubVol", metadata)
+		assert.ErrorContains(t, err, `"billing"`)
+		assert.True(t, errors.As(err, &ec))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.NoError(t, fsa.SetMetadataMap(volume, group, subname, nil))
+	})
+}
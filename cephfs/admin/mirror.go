@@ -223,20 +223,3 @@ func (sma *SnapshotMirrorAdmin) PeerList(fsname string) (
 	}
 	return parsePeerList(commands.MarshalMgrCommand(sma.conn, m))
 }
-
-/*
- DirMap - figure out what last_shuffled is supposed to mean and, if it is a time
-          like it seems to be, how best to represent in Go.
-
- DirMap TODO
-ceph fs snapshot mirror dirmap
-func (sma *SnapshotMirrorAdmin) DirMap(fsname, path string) error {
-	m := map[string]string{
-		"prefix":  "fs snapshot mirror dirmap",
-		"fs_name": fsname,
-		"path":    path,
-		"format":  "json",
-	}
-	return commands.MarshalMgrCommand(sma.conn, m).NoStatus().EmptyBody().End()
-}
-*/
@@ -0,0 +1,117 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"math"
+	"time"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// PeerAddOptions are optional values used when adding a mirroring peer
+// without a bootstrap token.
+type PeerAddOptions struct {
+	// RemoteFSName is the name of the file system on the remote cluster.
+	// If empty the name of the local file system is used.
+	RemoteFSName string
+	// RemoteMonHost is the address of the monitors of the remote cluster.
+	RemoteMonHost string
+	// CephxKey is the key of the remote client.
+	CephxKey string
+}
+
+// PeerAdd adds a mirroring peer to the given file system. The remote
+// cluster spec has the form "<client>@<cluster>", for example
+// "client.mirror_remote@site-b".
+//
+// Similar To:
+//
+//	ceph fs snapshot mirror peer_add <fs_name> <remote_cluster_spec> [<remote_fs_name>] [<remote_mon_host>] [<cephx_key>]
+func (sma *SnapshotMirrorAdmin) PeerAdd(
+	fsname, remoteClusterSpec string, o *PeerAddOptions) error {
+	// ---
+	m := map[string]string{
+		"prefix":              "fs snapshot mirror peer_add",
+		"fs_name":             fsname,
+		"remote_cluster_spec": remoteClusterSpec,
+		"format":              "json",
+	}
+	if o != nil {
+		if o.RemoteFSName != "" {
+			m["remote_fs_name"] = o.RemoteFSName
+		}
+		if o.RemoteMonHost != "" {
+			m["remote_mon_host"] = o.RemoteMonHost
+		}
+		if o.CephxKey != "" {
+			m["cephx_key"] = o.CephxKey
+		}
+	}
+	return commands.MarshalMgrCommand(sma.conn, m).NoStatus().EmptyBody().End()
+}
+
+// PeerRemove removes the mirroring peer with the given UUID from the given
+// file system.
+//
+// Similar To:
+//
+//	ceph fs snapshot mirror peer_remove <fs_name> <peer_uuid>
+func (sma *SnapshotMirrorAdmin) PeerRemove(fsname string, uuid PeerUUID) error {
+	m := map[string]string{
+		"prefix":    "fs snapshot mirror peer_remove",
+		"fs_name":   fsname,
+		"peer_uuid": string(uuid),
+		"format":    "json",
+	}
+	return commands.MarshalMgrCommand(sma.conn, m).NoStatus().EmptyBody().End()
+}
+
+// DirMapInfo describes which mirroring daemon a mirrored directory is
+// assigned to.
+type DirMapInfo struct {
+	// InstanceID is the ID of the mirroring daemon instance the directory
+	// is assigned to. It is empty if the directory is not assigned.
+	InstanceID string `json:"instance_id"`
+	// LastShuffled is the time, in seconds since the epoch, the directory
+	// was last assigned to a mirroring daemon instance.
+	LastShuffled float64 `json:"last_shuffled"`
+	// State of the directory, for example "mapped" or "stalled".
+	State string `json:"state"`
+	// Reason the directory is not mapped, if any.
+	Reason string `json:"reason"`
+}
+
+// LastShuffledTime returns LastShuffled as a time. It returns the zero
+// time if the directory was never assigned.
+func (dmi *DirMapInfo) LastShuffledTime() time.Time {
+	if dmi.LastShuffled == 0 {
+		return time.Time{}
+	}
+	sec, frac := math.Modf(dmi.LastShuffled)
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
+
+func parseDirMap(res response) (*DirMapInfo, error) {
+	var dmi DirMapInfo
+	if err := res.NoStatus().Unmarshal(&dmi).End(); err != nil {
+		return nil, err
+	}
+	return &dmi, nil
+}
+
+// DirMap returns the mirroring daemon assignment of the given mirrored
+// directory of a file system.
+//
+// Similar To:
+//
+//	ceph fs snapshot mirror dirmap <fs_name> <path>
+func (sma *SnapshotMirrorAdmin) DirMap(fsname, path string) (*DirMapInfo, error) {
+	m := map[string]string{
+		"prefix":  "fs snapshot mirror dirmap",
+		"fs_name": fsname,
+		"path":    path,
+		"format":  "json",
+	}
+	return parseDirMap(commands.MarshalMgrCommand(sma.conn, m))
+}
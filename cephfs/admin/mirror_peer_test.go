@@ -0,0 +1,58 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDirMap(t *testing.T) {
+	R := newResponse
+	t.Run("error", func(t *testing.T) {
+		_, err := parseDirMap(R(nil, "", errors.New("bonk")))
+		assert.Error(t, err)
+		assert.Equal(t, "bonk", err.Error())
+	})
+	t.Run("statusSet", func(t *testing.T) {
+		_, err := parseDirMap(R(nil, "unexpected!", nil))
+		assert.Error(t, err)
+	})
+	t.Run("mapped", func(t *testing.T) {
+		dmi, err := parseDirMap(R([]byte(
+			`{"instance_id": "4176", "last_shuffled": 1611111111.5, "state": "mapped"}`),
+			"", nil))
+		require.NoError(t, err)
+		assert.Equal(t, "4176", dmi.InstanceID)
+		assert.Equal(t, "mapped", dmi.State)
+		assert.Equal(t, time.Unix(1611111111, 500000000), dmi.LastShuffledTime())
+	})
+	t.Run("stalled", func(t *testing.T) {
+		dmi, err := parseDirMap(R([]byte(
+			`{"reason": "no mirror daemons running", "state": "stalled"}`),
+			"", nil))
+		require.NoError(t, err)
+		assert.Equal(t, "stalled", dmi.State)
+		assert.Equal(t, "no mirror daemons running", dmi.Reason)
+		assert.True(t, dmi.LastShuffledTime().IsZero())
+	})
+}
+
+func TestMirrorPeerAddRemove(t *testing.T) {
+	fsa := getFSAdmin(t)
+	smadmin := fsa.SnapshotMirror()
+
+	// mirroring is not enabled on the file system, so the commands fail
+	err := smadmin.PeerAdd("no-such-fs", "client.mirror@remote", &PeerAddOptions{
+		RemoteFSName: "cephfs",
+	})
+	assert.Error(t, err)
+	err = smadmin.PeerRemove("no-such-fs", PeerUUID("00000000-0000-0000-0000-000000000000"))
+	assert.Error(t, err)
+	_, err = smadmin.DirMap("no-such-fs", "/")
+	assert.Error(t, err)
+}
@@ -0,0 +1,185 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// PerfStatsOptions are used to filter the clients reported by PerfStats.
+// Each filter is a comma separated list of values, unset filters match all
+// clients.
+type PerfStatsOptions struct {
+	// MDSRank filters by the rank of the MDS the clients have sessions with.
+	MDSRank string
+	// ClientID filters by the client ID, for example "4305".
+	ClientID string
+	// ClientIP filters by the client IP address.
+	ClientIP string
+}
+
+// ClientPerfMetadata contains the metadata of a client reported by PerfStats.
+type ClientPerfMetadata struct {
+	IP           string   `json:"IP"`
+	Hostname     string   `json:"hostname"`
+	Root         string   `json:"root"`
+	MountPoint   string   `json:"mount_point"`
+	ValidMetrics []string `json:"valid_metrics"`
+}
+
+// ClientPerfStats contains the performance metrics of a single CephFS client,
+// as displayed by cephfs-top. Metrics the client does not report, see
+// ClientPerfMetadata.ValidMetrics, are zero.
+type ClientPerfStats struct {
+	// FileSystem is the name of the file system the client has mounted.
+	FileSystem string
+	// Client is the name of the client, for example "client.4305".
+	Client   string
+	Metadata ClientPerfMetadata
+
+	CapHits           uint64
+	CapMisses         uint64
+	DentryLeaseHits   uint64
+	DentryLeaseMisses uint64
+
+	OpenedFiles  uint64
+	PinnedICaps  uint64
+	OpenedInodes uint64
+	// TotalInodes is the total number of inodes cached by the client, to
+	// which OpenedFiles, PinnedICaps and OpenedInodes relate.
+	TotalInodes uint64
+
+	ReadOps    uint64
+	ReadBytes  uint64
+	WriteOps   uint64
+	WriteBytes uint64
+
+	ReadLatency          time.Duration
+	WriteLatency         time.Duration
+	MetadataLatency      time.Duration
+	AvgReadLatency       time.Duration
+	StdevReadLatency     time.Duration
+	AvgWriteLatency      time.Duration
+	StdevWriteLatency    time.Duration
+	AvgMetadataLatency   time.Duration
+	StdevMetadataLatency time.Duration
+}
+
+// perfStatsResponse is the JSON returned by the fs perf stats command. The
+// client metadata and metrics are keyed by file system name and client.
+type perfStatsResponse struct {
+	Version        int                                      `json:"version"`
+	GlobalCounters []string                                 `json:"global_counters"`
+	ClientMetadata map[string]map[string]ClientPerfMetadata `json:"client_metadata"`
+	GlobalMetrics  map[string]map[string][][2]int64         `json:"global_metrics"`
+}
+
+func latency(v [2]int64) time.Duration {
+	return time.Duration(v[0])*time.Second + time.Duration(v[1])
+}
+
+// stdev returns the standard deviation of the latencies from the sum of the
+// squared deviations, in nanoseconds squared, and the number of samples.
+func stdev(v [2]int64) time.Duration {
+	if v[1] <= 1 {
+		return 0
+	}
+	return time.Duration(math.Sqrt(float64(v[0]) / float64(v[1]-1)))
+}
+
+func (s *ClientPerfStats) setCounter(name string, v [2]int64) {
+	switch name {
+	case "cap_hit":
+		s.CapHits, s.CapMisses = uint64(v[0]), uint64(v[1])
+	case "dentry_lease":
+		s.DentryLeaseHits, s.DentryLeaseMisses = uint64(v[0]), uint64(v[1])
+	case "opened_files":
+		s.OpenedFiles, s.TotalInodes = uint64(v[0]), uint64(v[1])
+	case "pinned_icaps":
+		s.PinnedICaps, s.TotalInodes = uint64(v[0]), uint64(v[1])
+	case "opened_inodes":
+		s.OpenedInodes, s.TotalInodes = uint64(v[0]), uint64(v[1])
+	case "read_io_sizes":
+		s.ReadOps, s.ReadBytes = uint64(v[0]), uint64(v[1])
+	case "write_io_sizes":
+		s.WriteOps, s.WriteBytes = uint64(v[0]), uint64(v[1])
+	case "read_latency":
+		s.ReadLatency = latency(v)
+	case "write_latency":
+		s.WriteLatency = latency(v)
+	case "metadata_latency":
+		s.MetadataLatency = latency(v)
+	case "avg_read_latency":
+		s.AvgReadLatency = latency(v)
+	case "stdev_read_latency":
+		s.StdevReadLatency = stdev(v)
+	case "avg_write_latency":
+		s.AvgWriteLatency = latency(v)
+	case "stdev_write_latency":
+		s.StdevWriteLatency = stdev(v)
+	case "avg_metadata_latency":
+		s.AvgMetadataLatency = latency(v)
+	case "stdev_metadata_latency":
+		s.StdevMetadataLatency = stdev(v)
+	}
+}
+
+func parsePerfStats(res response) ([]ClientPerfStats, error) {
+	var r perfStatsResponse
+	if err := res.NoStatus().Unmarshal(&r).End(); err != nil {
+		return nil, err
+	}
+	var stats []ClientPerfStats
+	for fsname, clients := range r.GlobalMetrics {
+		for client, values := range clients {
+			if len(values) > len(r.GlobalCounters) {
+				return nil, fmt.Errorf(
+					"perf stats: %d metrics for %d counters", len(values), len(r.GlobalCounters))
+			}
+			s := ClientPerfStats{
+				FileSystem: fsname,
+				Client:     client,
+				Metadata:   r.ClientMetadata[fsname][client],
+			}
+			for i, v := range values {
+				s.setCounter(r.GlobalCounters[i], v)
+			}
+			stats = append(stats, s)
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].FileSystem != stats[j].FileSystem {
+			return stats[i].FileSystem < stats[j].FileSystem
+		}
+		return stats[i].Client < stats[j].Client
+	})
+	return stats, nil
+}
+
+// PerfStats returns the performance metrics of the CephFS clients, sorted by
+// file system and client. The stats mgr module must be enabled.
+//
+// Similar To:
+//
+//	ceph fs perf stats [--mds_rank=<rank>] [--client_id=<id>] [--client_ip=<ip>]
+func (fsa *FSAdmin) PerfStats(o *PerfStatsOptions) ([]ClientPerfStats, error) {
+	m := map[string]string{
+		"prefix": "fs perf stats",
+		"format": "json",
+	}
+	if o != nil {
+		if o.MDSRank != "" {
+			m["mds_rank"] = o.MDSRank
+		}
+		if o.ClientID != "" {
+			m["client_id"] = o.ClientID
+		}
+		if o.ClientIP != "" {
+			m["client_ip"] = o.ClientIP
+		}
+	}
+	return parsePerfStats(fsa.marshalMgrCommand(m))
+}
@@ -0,0 +1,124 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/common/admin/manager"
+)
+
+var samplePerfStats1 = `{
+  "version": 2,
+  "global_counters": [
+    "cap_hit", "read_latency", "write_latency", "metadata_latency",
+    "dentry_lease", "opened_files", "pinned_icaps", "opened_inodes",
+    "read_io_sizes", "write_io_sizes", "avg_read_latency",
+    "stdev_read_latency", "avg_write_latency", "stdev_write_latency",
+    "avg_metadata_latency", "stdev_metadata_latency"
+  ],
+  "counters": [],
+  "client_metadata": {
+    "cephfs": {
+      "client.4305": {
+        "IP": "192.168.1.10",
+        "hostname": "node1",
+        "root": "/",
+        "mount_point": "N/A",
+        "valid_metrics": ["cap_hit", "read_latency"]
+      }
+    }
+  },
+  "global_metrics": {
+    "cephfs": {
+      "client.4305": [
+        [309785, 1280], [0, 5000000], [1, 500], [0, 0],
+        [10, 2], [3, 100], [4, 100], [5, 100],
+        [7, 4096], [8, 8192], [0, 1000], [9000000000000, 10],
+        [0, 2000], [0, 1], [0, 3000], [16000000000000, 5]
+      ]
+    }
+  },
+  "metrics": {"delayed_ranks": [], "mds.0": {"client.4305": []}}
+}`
+
+func TestParsePerfStats(t *testing.T) {
+	R := newResponse
+	t.Run("error", func(t *testing.T) {
+		_, err := parsePerfStats(R(nil, "", errors.New("no stats")))
+		assert.Error(t, err)
+	})
+	t.Run("ok", func(t *testing.T) {
+		stats, err := parsePerfStats(R([]byte(samplePerfStats1), "", nil))
+		assert.NoError(t, err)
+		require.Len(t, stats, 1)
+		s := stats[0]
+		assert.Equal(t, "cephfs", s.FileSystem)
+		assert.Equal(t, "client.4305", s.Client)
+		assert.Equal(t, "node1", s.Metadata.Hostname)
+		assert.Equal(t, "192.168.1.10", s.Metadata.IP)
+		assert.EqualValues(t, 309785, s.CapHits)
+		assert.EqualValues(t, 1280, s.CapMisses)
+		assert.Equal(t, 5*time.Millisecond, s.ReadLatency)
+		assert.Equal(t, time.Second+500*time.Nanosecond, s.WriteLatency)
+		assert.EqualValues(t, 10, s.DentryLeaseHits)
+		assert.EqualValues(t, 3, s.OpenedFiles)
+		assert.EqualValues(t, 4, s.PinnedICaps)
+		assert.EqualValues(t, 5, s.OpenedInodes)
+		assert.EqualValues(t, 100, s.TotalInodes)
+		assert.EqualValues(t, 7, s.ReadOps)
+		assert.EqualValues(t, 4096, s.ReadBytes)
+		assert.EqualValues(t, 8, s.WriteOps)
+		assert.EqualValues(t, 8192, s.WriteBytes)
+		assert.Equal(t, 3*time.Microsecond, s.AvgMetadataLatency)
+		assert.Equal(t, time.Millisecond, s.StdevReadLatency)
+		assert.Equal(t, time.Duration(0), s.StdevWriteLatency)
+		assert.Equal(t, 2*time.Millisecond, s.StdevMetadataLatency)
+	})
+	t.Run("tooManyMetrics", func(t *testing.T) {
+		_, err := parsePerfStats(R([]byte(`{
+			"global_counters": ["cap_hit"],
+			"global_metrics": {"cephfs": {"client.1": [[1, 2], [3, 4]]}}
+		}`), "", nil))
+		assert.Error(t, err)
+	})
+}
+
+func TestPerfStats(t *testing.T) {
+	fsa := getFSAdmin(t)
+	mgradmin := manager.NewFromConn(fsa.conn)
+	require.NoError(t, mgradmin.EnableModule("stats", false))
+	defer func() {
+		assert.NoError(t, mgradmin.DisableModule("stats"))
+	}()
+
+	mount := fsConnect(t, "")
+	defer func() {
+		assert.NoError(t, mount.Unmount())
+		assert.NoError(t, mount.Release())
+	}()
+
+	// the stats module needs some time to collect the first metrics
+	var stats []ClientPerfStats
+	for i := 0; i < 30; i++ {
+		var err error
+		stats, err = fsa.PerfStats(nil)
+		require.NoError(t, err)
+		if len(stats) > 0 {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	require.NotEmpty(t, stats)
+	assert.NotEmpty(t, stats[0].FileSystem)
+	assert.NotEmpty(t, stats[0].Client)
+
+	stats, err := fsa.PerfStats(&PerfStatsOptions{ClientIP: "192.0.2.1"})
+	assert.NoError(t, err)
+	assert.Empty(t, stats)
+}
@@ -0,0 +1,79 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"strconv"
+)
+
+// Pin is a policy for distributing the metadata of a subvolume or
+// subvolume group over the ranks of a file system with multiple active MDS
+// daemons. See ExportPin, DistributedPin and RandomPin.
+type Pin interface {
+	pinType() string
+	pinSetting() string
+}
+
+// ExportPin pins the metadata to the MDS with the given rank. The special
+// value NoExportPin removes the pin.
+type ExportPin int
+
+// NoExportPin removes an export pin.
+const NoExportPin = ExportPin(-1)
+
+func (ExportPin) pinType() string      { return "export" }
+func (p ExportPin) pinSetting() string { return strconv.Itoa(int(p)) }
+
+// DistributedPin, if true, distributes the immediate children of the
+// directory over all ranks.
+type DistributedPin bool
+
+func (DistributedPin) pinType() string { return "distributed" }
+func (p DistributedPin) pinSetting() string {
+	if p {
+		return "1"
+	}
+	return "0"
+}
+
+// RandomPin pins each descendant directory to a random rank with the given
+// probability, a value between 0.0 and 1.0.
+type RandomPin float64
+
+func (RandomPin) pinType() string { return "random" }
+func (p RandomPin) pinSetting() string {
+	return strconv.FormatFloat(float64(p), 'f', -1, 64)
+}
+
+// SetSubVolumePin applies the pin policy to the subvolume in a volume
+// belonging to an optional subvolume group.
+//
+// Similar To:
+//
+//	ceph fs subvolume pin <vol_name> <sub_name> <pin_type> <pin_setting> [--group_name <group>]
+func (fsa *FSAdmin) SetSubVolumePin(volume, group, subvolume string, pin Pin) error {
+	m := map[string]string{
+		"prefix":      "fs subvolume pin",
+		"format":      "json",
+		"vol_name":    volume,
+		"sub_name":    subvolume,
+		"pin_type":    pin.pinType(),
+		"pin_setting": pin.pinSetting(),
+	}
+	if group != NoGroup {
+		m["group_name"] = group
+	}
+	_, err := parsePathResponse(fsa.marshalMgrCommand(m))
+	return err
+}
+
+// SetSubVolumeGroupPin applies the pin policy to the subvolume group in a
+// volume.
+//
+// Similar To:
+//
+//	ceph fs subvolumegroup pin <vol_name> <group_name> <pin_type> <pin_setting>
+func (fsa *FSAdmin) SetSubVolumeGroupPin(volume, group string, pin Pin) error {
+	_, err := fsa.PinSubVolumeGroup(volume, group, pin.pinType(), pin.pinSetting())
+	return err
+}
@@ -0,0 +1,59 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinSettings(t *testing.T) {
+	tcases := []struct {
+		pin     Pin
+		typ     string
+		setting string
+	}{
+		{ExportPin(1), "export", "1"},
+		{NoExportPin, "export", "-1"},
+		{DistributedPin(true), "distributed", "1"},
+		{DistributedPin(false), "distributed", "0"},
+		{RandomPin(0.01), "random", "0.01"},
+		{RandomPin(0), "random", "0"},
+	}
+	for _, tc := range tcases {
+		assert.Equal(t, tc.typ, tc.pin.pinType())
+		assert.Equal(t, tc.setting, tc.pin.pinSetting())
+	}
+}
+
+func TestSetSubVolumePin(t *testing.T) {
+	fsa := getFSAdmin(t)
+	volume := "cephfs"
+	group := "pinGroup"
+	subname := "pinSubVol"
+
+	require.NoError(t, fsa.CreateSubVolumeGroup(volume, group, nil))
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolumeGroup(volume, group))
+	}()
+	require.NoError(t, fsa.CreateSubVolume(volume, group, subname, nil))
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolume(volume, group, subname))
+	}()
+
+	assert.NoError(t, fsa.SetSubVolumePin(volume, group, subname, ExportPin(0)))
+	assert.NoError(t, fsa.SetSubVolumePin(volume, group, subname, NoExportPin))
+	assert.NoError(t, fsa.SetSubVolumePin(volume, group, subname, DistributedPin(true)))
+	assert.NoError(t, fsa.SetSubVolumePin(volume, group, subname, DistributedPin(false)))
+
+	assert.NoError(t, fsa.SetSubVolumeGroupPin(volume, group, RandomPin(0.01)))
+	// mds_export_ephemeral_random_max has a default value of 0.01
+	var ec errorCode
+	err := fsa.SetSubVolumeGroupPin(volume, group, RandomPin(0.5))
+	assert.True(t, errors.As(err, &ec))
+	assert.Equal(t, -22, ec.ErrorCode())
+	assert.NoError(t, fsa.SetSubVolumeGroupPin(volume, group, RandomPin(0)))
+}
@@ -0,0 +1,72 @@
+//go:build !(nautilus || octopus) && ceph_preview
+
+package admin
+
+// PendingSubVolumeDeletions returns the number of subvolumes of a volume that
+// have been removed but whose data has not yet been purged. The space used by
+// these subvolumes is released asynchronously by the volumes module.
+//
+// Similar To:
+//
+//	ceph fs volume info <vol_name>
+func (fsa *FSAdmin) PendingSubVolumeDeletions(volume string) (int, error) {
+	info, err := fsa.FetchVolumeInfo(volume)
+	if err != nil {
+		return 0, err
+	}
+	return info.PendingSubvolDels, nil
+}
+
+// PurgeQueueStatus reports the state of the purge queue of an MDS. The purge
+// queue holds the files that have been unlinked but whose data objects are
+// still to be removed from the data pools.
+type PurgeQueueStatus struct {
+	// Executing is the number of purge queue items currently being purged.
+	Executing int64 `json:"pq_executing"`
+	// ExecutingHighWater is the maximum value Executing has reached.
+	ExecutingHighWater int64 `json:"pq_executing_high_water"`
+	// ExecutingOps is the number of RADOS operations currently being
+	// executed to purge items.
+	ExecutingOps int64 `json:"pq_executing_ops"`
+	// ExecutingOpsHighWater is the maximum value ExecutingOps has reached.
+	ExecutingOpsHighWater int64 `json:"pq_executing_ops_high_water"`
+	// Executed is the number of items purged since the MDS started.
+	Executed int64 `json:"pq_executed"`
+	// ExecutedOps is the number of RADOS operations executed to purge items
+	// since the MDS started.
+	ExecutedOps int64 `json:"pq_executed_ops"`
+	// ItemsInJournal is the number of items waiting in the purge queue
+	// journal to be purged. It is not reported by older versions of Ceph.
+	ItemsInJournal int64 `json:"pq_item_in_journal"`
+}
+
+type purgeQueuePerfDump struct {
+	PurgeQueue *PurgeQueueStatus `json:"purge_queue"`
+}
+
+func parsePurgeQueueStatus(res response) (*PurgeQueueStatus, error) {
+	var d purgeQueuePerfDump
+	if err := res.NoStatus().Unmarshal(&d).End(); err != nil {
+		return nil, err
+	}
+	if d.PurgeQueue == nil {
+		return &PurgeQueueStatus{}, nil
+	}
+	return d.PurgeQueue, nil
+}
+
+// PurgeQueueStatus returns the state of the purge queue of the MDS identified
+// by mdsSpec, the rank, GID or name of an MDS. Each active MDS rank has its
+// own purge queue.
+//
+// Similar To:
+//
+//	ceph tell mds.<mdsSpec> perf dump purge_queue
+func (ma *MDSAdmin) PurgeQueueStatus(mdsSpec string) (*PurgeQueueStatus, error) {
+	m := map[string]string{
+		"prefix": "perf dump",
+		"logger": "purge_queue",
+		"format": "json",
+	}
+	return parsePurgeQueueStatus(ma.marshalMdsCommand(mdsSpec, m))
+}
@@ -0,0 +1,78 @@
+//go:build !(nautilus || octopus) && ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var samplePurgeQueuePerfDump = []byte(`{
+  "purge_queue": {
+    "pq_executing_ops": 2,
+    "pq_executing_ops_high_water": 16,
+    "pq_executing": 1,
+    "pq_executing_high_water": 8,
+    "pq_executed": 120,
+    "pq_executed_ops": 240,
+    "pq_item_in_journal": 5
+  }
+}`)
+
+func TestParsePurgeQueueStatus(t *testing.T) {
+	R := newResponse
+	t.Run("error", func(t *testing.T) {
+		_, err := parsePurgeQueueStatus(R(nil, "", errors.New("bonk")))
+		assert.Error(t, err)
+	})
+	t.Run("empty", func(t *testing.T) {
+		s, err := parsePurgeQueueStatus(R([]byte(`{}`), "", nil))
+		require.NoError(t, err)
+		assert.Equal(t, &PurgeQueueStatus{}, s)
+	})
+	t.Run("ok", func(t *testing.T) {
+		s, err := parsePurgeQueueStatus(R(samplePurgeQueuePerfDump, "", nil))
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, s.Executing)
+		assert.EqualValues(t, 8, s.ExecutingHighWater)
+		assert.EqualValues(t, 2, s.ExecutingOps)
+		assert.EqualValues(t, 16, s.ExecutingOpsHighWater)
+		assert.EqualValues(t, 120, s.Executed)
+		assert.EqualValues(t, 240, s.ExecutedOps)
+		assert.EqualValues(t, 5, s.ItemsInJournal)
+	})
+}
+
+func TestPendingSubVolumeDeletions(t *testing.T) {
+	fsa := getFSAdmin(t)
+
+	n, err := fsa.PendingSubVolumeDeletions("cephfs")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, n, 0)
+
+	_, err = fsa.PendingSubVolumeDeletions("blah")
+	assert.Error(t, err)
+}
+
+func TestPurgeQueueStatus(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		ma := &MDSAdmin{}
+		_, err := ma.PurgeQueueStatus("0")
+		assert.Error(t, err)
+	})
+
+	mount := fsConnect(t, "")
+	defer func() {
+		assert.NoError(t, mount.Unmount())
+		assert.NoError(t, mount.Release())
+	}()
+	ma := NewMDSAdmin(mount)
+
+	s, err := ma.PurgeQueueStatus("0")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, s.ExecutingHighWater, s.Executing)
+	assert.GreaterOrEqual(t, s.ExecutingOpsHighWater, s.ExecutingOps)
+}
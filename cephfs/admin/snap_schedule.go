@@ -0,0 +1,232 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"encoding/json"
+
+	"github.com/ceph/go-ceph/common/admin/manager"
+	ccom "github.com/ceph/go-ceph/common/commands"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+const snapSchedule = "snap_schedule"
+
+// EnableSnapScheduleModule will enable the snapshot schedule module for
+// cephfs.
+//
+// Similar To:
+//
+//	ceph mgr module enable snap_schedule [--force]
+func (fsa *FSAdmin) EnableSnapScheduleModule(force bool) error {
+	mgradmin := manager.NewFromConn(fsa.conn)
+	return mgradmin.EnableModule(snapSchedule, force)
+}
+
+// DisableSnapScheduleModule will disable the snapshot schedule module for
+// cephfs.
+//
+// Similar To:
+//
+//	ceph mgr module disable snap_schedule
+func (fsa *FSAdmin) DisableSnapScheduleModule() error {
+	mgradmin := manager.NewFromConn(fsa.conn)
+	return mgradmin.DisableModule(snapSchedule)
+}
+
+// SnapScheduleAdmin helps administer the snapshot schedules of cephfs.
+// Snapshot schedules are managed by the snap_schedule mgr module, which must
+// be enabled.
+type SnapScheduleAdmin struct {
+	conn ccom.MgrCommander
+}
+
+// SnapSchedule returns a new SnapScheduleAdmin to be used for the
+// administration of snapshot schedules.
+func (fsa *FSAdmin) SnapSchedule() *SnapScheduleAdmin {
+	return &SnapScheduleAdmin{conn: fsa.conn}
+}
+
+// SnapSchedulePath identifies the directory snapshot schedules apply to.
+type SnapSchedulePath struct {
+	// Path of the directory. If SubVolume is set the path is relative to
+	// the subvolume.
+	Path string
+	// FileSystem is the name of the file system. It may be omitted if the
+	// cluster has a single file system.
+	FileSystem string
+	// SubVolume is the optional name of a subvolume.
+	SubVolume string
+	// Group is the optional subvolume group of the subvolume.
+	Group string
+}
+
+func (p SnapSchedulePath) command(prefix string) map[string]string {
+	m := map[string]string{
+		"prefix": prefix,
+		"path":   p.Path,
+		"format": "json",
+	}
+	if p.FileSystem != "" {
+		m["fs"] = p.FileSystem
+	}
+	if p.SubVolume != "" {
+		m["subvol"] = p.SubVolume
+	}
+	if p.Group != NoGroup {
+		m["group"] = p.Group
+	}
+	return m
+}
+
+// SnapRetention maps the period of a retention rule, like "h" for hourly or
+// "d" for daily, to the number of snapshots retained for that period.
+type SnapRetention map[string]int
+
+// UnmarshalJSON implements the json Unmarshaler interface. Depending on the
+// Ceph version the retention is encoded as an object or as a string
+// containing the JSON of the object.
+func (r *SnapRetention) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		if s == "" {
+			*r = SnapRetention{}
+			return nil
+		}
+		data = []byte(s)
+	}
+	var m map[string]int
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*r = m
+	return nil
+}
+
+// SnapSchedule reports the state of a snapshot schedule. The times are
+// reported as formatted by the mgr module.
+type SnapSchedule struct {
+	FileSystem   string        `json:"fs"`
+	SubVolume    string        `json:"subvol"`
+	Group        string        `json:"group"`
+	Path         string        `json:"path"`
+	RelPath      string        `json:"rel_path"`
+	Schedule     string        `json:"schedule"`
+	Retention    SnapRetention `json:"retention"`
+	Start        string        `json:"start"`
+	Created      string        `json:"created"`
+	First        string        `json:"first"`
+	Last         string        `json:"last"`
+	LastPruned   string        `json:"last_pruned"`
+	CreatedCount int           `json:"created_count"`
+	PrunedCount  int           `json:"pruned_count"`
+	Active       bool          `json:"active"`
+}
+
+func parseSnapSchedules(res response) ([]SnapSchedule, error) {
+	var s []SnapSchedule
+	if err := res.NoStatus().Unmarshal(&s).End(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (ssa *SnapScheduleAdmin) run(m map[string]string) response {
+	return commands.MarshalMgrCommand(ssa.conn, m)
+}
+
+// Status returns the snapshot schedules of the directory identified by p.
+//
+// Similar To:
+//
+//	ceph fs snap-schedule status <path> [<fs>] [--subvol <subvol>] [--group <group>]
+func (ssa *SnapScheduleAdmin) Status(p SnapSchedulePath) ([]SnapSchedule, error) {
+	return parseSnapSchedules(ssa.run(p.command("fs snap-schedule status")))
+}
+
+// Add a snapshot schedule to the directory identified by p. The schedule is
+// given as a number and a period, like "1h" or "2d". The optional start time,
+// in ISO 8601 format, determines when the first snapshot is taken.
+//
+// Similar To:
+//
+//	ceph fs snap-schedule add <path> <schedule> [<start>] [<fs>] [--subvol <subvol>] [--group <group>]
+func (ssa *SnapScheduleAdmin) Add(p SnapSchedulePath, schedule, start string) error {
+	m := p.command("fs snap-schedule add")
+	m["snap_schedule"] = schedule
+	if start != "" {
+		m["start"] = start
+	}
+	return ssa.run(m).End()
+}
+
+// Remove the snapshot schedules of the directory identified by p. If
+// schedule is empty all the schedules of the directory are removed,
+// otherwise only those matching the schedule and the optional start time.
+//
+// Similar To:
+//
+//	ceph fs snap-schedule remove <path> [<repeat>] [<start>] [<fs>] [--subvol <subvol>] [--group <group>]
+func (ssa *SnapScheduleAdmin) Remove(p SnapSchedulePath, schedule, start string) error {
+	return ssa.run(p.scheduleCommand("fs snap-schedule remove", schedule, start)).End()
+}
+
+// Activate the snapshot schedules of the directory identified by p. If
+// schedule is empty all the schedules of the directory are activated,
+// otherwise only those matching the schedule and the optional start time.
+//
+// Similar To:
+//
+//	ceph fs snap-schedule activate <path> [<repeat>] [<start>] [<fs>] [--subvol <subvol>] [--group <group>]
+func (ssa *SnapScheduleAdmin) Activate(p SnapSchedulePath, schedule, start string) error {
+	return ssa.run(p.scheduleCommand("fs snap-schedule activate", schedule, start)).End()
+}
+
+// Deactivate the snapshot schedules of the directory identified by p. A
+// deactivated schedule is kept but no snapshots are taken or pruned for it.
+// If schedule is empty all the schedules of the directory are deactivated,
+// otherwise only those matching the schedule and the optional start time.
+//
+// Similar To:
+//
+//	ceph fs snap-schedule deactivate <path> [<repeat>] [<start>] [<fs>] [--subvol <subvol>] [--group <group>]
+func (ssa *SnapScheduleAdmin) Deactivate(p SnapSchedulePath, schedule, start string) error {
+	return ssa.run(p.scheduleCommand("fs snap-schedule deactivate", schedule, start)).End()
+}
+
+func (p SnapSchedulePath) scheduleCommand(prefix, schedule, start string) map[string]string {
+	m := p.command(prefix)
+	if schedule != "" {
+		m["repeat"] = schedule
+	}
+	if start != "" {
+		m["start"] = start
+	}
+	return m
+}
+
+// AddRetention adds a retention rule to the snapshot schedules of the
+// directory identified by p. The spec is made of counts and periods, for
+// example "24h" retains 24 hourly snapshots and "24h4w" additionally retains
+// 4 weekly snapshots.
+//
+// Similar To:
+//
+//	ceph fs snap-schedule retention add <path> <spec> [<fs>] [--subvol <subvol>] [--group <group>]
+func (ssa *SnapScheduleAdmin) AddRetention(p SnapSchedulePath, spec string) error {
+	m := p.command("fs snap-schedule retention add")
+	m["retention_spec_or_period"] = spec
+	return ssa.run(m).End()
+}
+
+// RemoveRetention removes a retention rule from the snapshot schedules of
+// the directory identified by p. See AddRetention for the format of spec.
+//
+// Similar To:
+//
+//	ceph fs snap-schedule retention remove <path> <spec> [<fs>] [--subvol <subvol>] [--group <group>]
+func (ssa *SnapScheduleAdmin) RemoveRetention(p SnapSchedulePath, spec string) error {
+	m := p.command("fs snap-schedule retention remove")
+	m["retention_spec_or_period"] = spec
+	return ssa.run(m).End()
+}
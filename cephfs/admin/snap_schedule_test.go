@@ -0,0 +1,135 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sampleSnapScheduleStatus1 = `[
+  {
+    "fs": "cephfs",
+    "subvol": null,
+    "path": "/",
+    "rel_path": "/",
+    "schedule": "1h",
+    "retention": {"h": 24, "d": 7},
+    "start": "2024-03-01T00:00:00",
+    "created": "2024-03-01T10:12:01",
+    "first": "2024-03-01T11:00:00",
+    "last": "2024-03-01T12:00:00",
+    "last_pruned": null,
+    "created_count": 2,
+    "pruned_count": 0,
+    "active": true
+  }
+]`
+
+var sampleSnapScheduleStatus2 = `[
+  {
+    "fs": "cephfs",
+    "subvol": "sv1",
+    "group": "g1",
+    "path": "/volumes/g1/sv1/abcd/",
+    "rel_path": "/volumes/g1/sv1/abcd/",
+    "schedule": "1d",
+    "retention": "{}",
+    "start": "2024-03-01T00:00:00",
+    "created": "2024-03-01T10:12:01",
+    "first": null,
+    "last": null,
+    "last_pruned": null,
+    "created_count": 0,
+    "pruned_count": 0,
+    "active": false
+  }
+]`
+
+func TestParseSnapSchedules(t *testing.T) {
+	R := newResponse
+	t.Run("error", func(t *testing.T) {
+		_, err := parseSnapSchedules(R(nil, "", errors.New("flub")))
+		assert.Error(t, err)
+	})
+	t.Run("ok", func(t *testing.T) {
+		s, err := parseSnapSchedules(R([]byte(sampleSnapScheduleStatus1), "", nil))
+		assert.NoError(t, err)
+		if assert.Len(t, s, 1) {
+			assert.Equal(t, "cephfs", s[0].FileSystem)
+			assert.Equal(t, "", s[0].SubVolume)
+			assert.Equal(t, "/", s[0].Path)
+			assert.Equal(t, "1h", s[0].Schedule)
+			assert.Equal(t, SnapRetention{"h": 24, "d": 7}, s[0].Retention)
+			assert.Equal(t, "2024-03-01T12:00:00", s[0].Last)
+			assert.Equal(t, "", s[0].LastPruned)
+			assert.Equal(t, 2, s[0].CreatedCount)
+			assert.True(t, s[0].Active)
+		}
+	})
+	t.Run("subvolume", func(t *testing.T) {
+		s, err := parseSnapSchedules(R([]byte(sampleSnapScheduleStatus2), "", nil))
+		assert.NoError(t, err)
+		if assert.Len(t, s, 1) {
+			assert.Equal(t, "sv1", s[0].SubVolume)
+			assert.Equal(t, "g1", s[0].Group)
+			assert.Equal(t, SnapRetention{}, s[0].Retention)
+			assert.Equal(t, "", s[0].First)
+			assert.False(t, s[0].Active)
+		}
+	})
+	t.Run("badRetention", func(t *testing.T) {
+		_, err := parseSnapSchedules(R([]byte(`[{"retention": "24h"}]`), "", nil))
+		assert.Error(t, err)
+	})
+}
+
+func TestSnapSchedule(t *testing.T) {
+	fsa := getFSAdmin(t)
+	require.NoError(t, fsa.EnableSnapScheduleModule(false))
+	defer func() {
+		assert.NoError(t, fsa.DisableSnapScheduleModule())
+	}()
+	ssa := fsa.SnapSchedule()
+
+	p := SnapSchedulePath{Path: "/", FileSystem: "cephfs"}
+	err := ssa.Add(p, "1h", "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, ssa.Remove(p, "", ""))
+	}()
+
+	err = ssa.AddRetention(p, "24h")
+	assert.NoError(t, err)
+
+	s, err := ssa.Status(p)
+	require.NoError(t, err)
+	require.Len(t, s, 1)
+	assert.Equal(t, "1h", s[0].Schedule)
+	assert.Equal(t, 24, s[0].Retention["h"])
+	assert.True(t, s[0].Active)
+
+	err = ssa.Deactivate(p, "1h", "")
+	assert.NoError(t, err)
+	s, err = ssa.Status(p)
+	require.NoError(t, err)
+	require.Len(t, s, 1)
+	assert.False(t, s[0].Active)
+
+	err = ssa.Activate(p, "", "")
+	assert.NoError(t, err)
+	s, err = ssa.Status(p)
+	require.NoError(t, err)
+	require.Len(t, s, 1)
+	assert.True(t, s[0].Active)
+
+	err = ssa.RemoveRetention(p, "24h")
+	assert.NoError(t, err)
+	s, err = ssa.Status(p)
+	require.NoError(t, err)
+	require.Len(t, s, 1)
+	assert.NotContains(t, s[0].Retention, "h")
+}
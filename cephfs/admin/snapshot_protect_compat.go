@@ -0,0 +1,44 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"errors"
+)
+
+// ignoreNotImplemented returns nil if err indicates that the command is not
+// known to the Ceph cluster.
+func ignoreNotImplemented(err error) error {
+	var notImplemented NotImplementedError
+	if errors.As(err, &notImplemented) {
+		return nil
+	}
+	return err
+}
+
+// ProtectSubVolumeSnapshotCompat protects the specified snapshot on Ceph
+// versions that require snapshots to be protected before they are cloned.
+// Newer versions of Ceph, that no longer require protection and no longer
+// provide the protect command, are detected and nil is returned. This
+// allows the same code to work across Ceph versions.
+//
+// Similar To:
+//
+//	ceph fs subvolume snapshot protect <volume> --group-name=<group> <subvolume> <name>
+func (fsa *FSAdmin) ProtectSubVolumeSnapshotCompat(volume, group, subvolume, name string) error {
+	return ignoreNotImplemented(
+		fsa.ProtectSubVolumeSnapshot(volume, group, subvolume, name))
+}
+
+// UnprotectSubVolumeSnapshotCompat removes protection from the specified
+// snapshot on Ceph versions that support snapshot protection. On newer
+// versions of Ceph, that no longer provide the unprotect command, nil is
+// returned.
+//
+// Similar To:
+//
+//	ceph fs subvolume snapshot unprotect <volume> --group-name=<group> <subvolume> <name>
+func (fsa *FSAdmin) UnprotectSubVolumeSnapshotCompat(volume, group, subvolume, name string) error {
+	return ignoreNotImplemented(
+		fsa.UnprotectSubVolumeSnapshot(volume, group, subvolume, name))
+}
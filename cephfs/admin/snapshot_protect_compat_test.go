@@ -0,0 +1,47 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreNotImplemented(t *testing.T) {
+	assert.NoError(t, ignoreNotImplemented(nil))
+	err := NotImplementedError{
+		Response: newResponse(nil, "No handler found", errors.New("EINVAL")),
+	}
+	assert.NoError(t, ignoreNotImplemented(err))
+	assert.NoError(t, ignoreNotImplemented(fmt.Errorf("protect: %w", err)))
+	err2 := errors.New("boom")
+	assert.Equal(t, err2, ignoreNotImplemented(err2))
+}
+
+func TestSubVolumeSnapshotProtectCompat(t *testing.T) {
+	fsa := getFSAdmin(t)
+	volume := "cephfs"
+	group := NoGroup
+	subname := "protectCompat"
+	snapname := "protectCompatSnap"
+
+	require.NoError(t, fsa.CreateSubVolume(volume, group, subname, nil))
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolume(volume, group, subname))
+	}()
+	require.NoError(t, fsa.CreateSubVolumeSnapshot(volume, group, subname, snapname))
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolumeSnapshot(volume, group, subname, snapname))
+	}()
+
+	assert.NoError(t, fsa.ProtectSubVolumeSnapshotCompat(volume, group, subname, snapname))
+	assert.NoError(t, fsa.UnprotectSubVolumeSnapshotCompat(volume, group, subname, snapname))
+
+	// other errors are still reported
+	err := fsa.ProtectSubVolumeSnapshotCompat(volume, group, subname, "noSuchSnap")
+	assert.Error(t, err)
+}
@@ -1,5 +1,9 @@
 package admin
 
+import (
+	"encoding/json"
+)
+
 // this is the internal type used to create JSON for ceph.
 // See SubVolumeOptions for the type that users of the library
 // interact with.
@@ -161,12 +165,23 @@ func (fsa *FSAdmin) ResizeSubVolume(
 		NewSize:   newSize.resizeValue(),
 		NoShrink:  noShrink,
 	}
-	var result []*SubVolumeResizeResult
-	res := fsa.marshalMgrCommand(f)
-	if err := res.NoStatus().Unmarshal(&result).End(); err != nil {
+	return parseResizeResult(fsa.marshalMgrCommand(f))
+}
+
+// parseResizeResult parses the response of the resize commands. Ceph reports
+// each value of the result as an object of its own within a list.
+func parseResizeResult(res response) (*SubVolumeResizeResult, error) {
+	var items []json.RawMessage
+	if err := res.NoStatus().Unmarshal(&items).End(); err != nil {
 		return nil, err
 	}
-	return result[0], nil
+	result := &SubVolumeResizeResult{}
+	for _, item := range items {
+		if err := json.Unmarshal(item, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
 }
 
 // SubVolumePath returns the path to the subvolume from the root of the file system.
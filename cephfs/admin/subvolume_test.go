@@ -253,7 +253,9 @@ func TestResizeSubVolume(t *testing.T) {
 
 	rr, err := fsa.ResizeSubVolume(volume, group, subname, 30*gibiByte, false)
 	assert.NoError(t, err)
-	assert.NotNil(t, rr)
+	if assert.NotNil(t, rr) {
+		assert.Equal(t, 30*gibiByte, rr.BytesQuota)
+	}
 
 	rr, err = fsa.ResizeSubVolume(volume, group, subname, 10*gibiByte, true)
 	assert.NoError(t, err)
@@ -264,6 +266,31 @@ func TestResizeSubVolume(t *testing.T) {
 	assert.NotNil(t, rr)
 }
 
+func TestParseResizeResult(t *testing.T) {
+	R := newResponse
+	t.Run("error", func(t *testing.T) {
+		_, err := parseResizeResult(R(nil, "", errors.New("bonk")))
+		assert.Error(t, err)
+	})
+	t.Run("ok", func(t *testing.T) {
+		rr, err := parseResizeResult(R([]byte(`[
+			{"bytes_used": 1024},
+			{"bytes_quota": 4096},
+			{"bytes_pcent": "25.00"}
+		]`), "", nil))
+		assert.NoError(t, err)
+		if assert.NotNil(t, rr) {
+			assert.Equal(t, ByteCount(1024), rr.BytesUsed)
+			assert.Equal(t, ByteCount(4096), rr.BytesQuota)
+			assert.Equal(t, "25.00", rr.BytesPercent)
+		}
+	})
+	t.Run("badValue", func(t *testing.T) {
+		_, err := parseResizeResult(R([]byte(`[{"bytes_used": "lots"}]`), "", nil))
+		assert.Error(t, err)
+	})
+}
+
 func TestSubVolumePath(t *testing.T) {
 	fsa := getFSAdmin(t)
 	volume := "cephfs"
@@ -5,14 +5,15 @@ package admin
 // interact with.
 // note that the ceph json takes mode as a string.
 type subVolumeGroupFields struct {
-	Prefix     string `json:"prefix"`
-	Format     string `json:"format"`
-	VolName    string `json:"vol_name"`
-	GroupName  string `json:"group_name"`
-	Uid        int    `json:"uid,omitempty"`
-	Gid        int    `json:"gid,omitempty"`
-	Mode       string `json:"mode,omitempty"`
-	PoolLayout string `json:"pool_layout,omitempty"`
+	Prefix     string    `json:"prefix"`
+	Format     string    `json:"format"`
+	VolName    string    `json:"vol_name"`
+	GroupName  string    `json:"group_name"`
+	Uid        int       `json:"uid,omitempty"`
+	Gid        int       `json:"gid,omitempty"`
+	Mode       string    `json:"mode,omitempty"`
+	PoolLayout string    `json:"pool_layout,omitempty"`
+	Size       ByteCount `json:"size,omitempty"`
 }
 
 // SubVolumeGroupOptions are used to specify optional, non-identifying, values
@@ -22,6 +23,9 @@ type SubVolumeGroupOptions struct {
 	Gid        int
 	Mode       int
 	PoolLayout string
+	// Size is the optional quota of the subvolume group. It is supported
+	// by Ceph Reef and later.
+	Size ByteCount
 }
 
 func (s *SubVolumeGroupOptions) toFields(v, g string) *subVolumeGroupFields {
@@ -34,6 +38,7 @@ func (s *SubVolumeGroupOptions) toFields(v, g string) *subVolumeGroupFields {
 		Gid:        s.Gid,
 		Mode:       modeString(s.Mode, false),
 		PoolLayout: s.PoolLayout,
+		Size:       s.Size,
 	}
 }
 
@@ -0,0 +1,36 @@
+//go:build ceph_preview
+
+package admin
+
+type subVolumeGroupResizeFields struct {
+	Prefix    string `json:"prefix"`
+	Format    string `json:"format"`
+	VolName   string `json:"vol_name"`
+	GroupName string `json:"group_name"`
+	NewSize   string `json:"new_size"`
+	NoShrink  bool   `json:"no_shrink"`
+}
+
+// ResizeSubVolumeGroup will resize a CephFS subvolume group. The newSize
+// value may be a ByteCount or the special Infinite constant. Setting
+// noShrink to true will prevent reducing the size of the group below the
+// current used size. The resulting quota and usage of the group are
+// returned. Resizing subvolume groups is supported by Ceph Reef and later.
+//
+// Similar To:
+//
+//	ceph fs subvolumegroup resize <volume> <group> <new_size> [--no_shrink]
+func (fsa *FSAdmin) ResizeSubVolumeGroup(
+	volume, group string,
+	newSize QuotaSize, noShrink bool) (*SubVolumeResizeResult, error) {
+
+	f := &subVolumeGroupResizeFields{
+		Prefix:    "fs subvolumegroup resize",
+		Format:    "json",
+		VolName:   volume,
+		GroupName: group,
+		NewSize:   newSize.resizeValue(),
+		NoShrink:  noShrink,
+	}
+	return parseResizeResult(fsa.marshalMgrCommand(f))
+}
@@ -0,0 +1,43 @@
+//go:build ceph_preview && !(nautilus || octopus || pacific || quincy)
+
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResizeSubVolumeGroup(t *testing.T) {
+	fsa := getFSAdmin(t)
+	volume := "cephfs"
+	group := "sizedGroup2"
+
+	err := fsa.CreateSubVolumeGroup(volume, group, &SubVolumeGroupOptions{
+		Size: 20 * gibiByte,
+	})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolumeGroup(volume, group))
+	}()
+
+	rr, err := fsa.ResizeSubVolumeGroup(volume, group, 30*gibiByte, false)
+	assert.NoError(t, err)
+	if assert.NotNil(t, rr) {
+		assert.Equal(t, 30*gibiByte, rr.BytesQuota)
+	}
+
+	rr, err = fsa.ResizeSubVolumeGroup(volume, group, 10*gibiByte, true)
+	assert.NoError(t, err)
+	if assert.NotNil(t, rr) {
+		assert.Equal(t, 10*gibiByte, rr.BytesQuota)
+	}
+
+	rr, err = fsa.ResizeSubVolumeGroup(volume, group, Infinite, true)
+	assert.NoError(t, err)
+	assert.NotNil(t, rr)
+
+	_, err = fsa.ResizeSubVolumeGroup(volume, "noSuchGroup", 10*gibiByte, false)
+	assert.Error(t, err)
+}
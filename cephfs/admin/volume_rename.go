@@ -0,0 +1,22 @@
+//go:build ceph_preview
+
+package admin
+
+// RenameVolume renames a CephFS volume, along with its data and metadata
+// pools. Clients of the volume must be reconfigured to use the new name.
+// Depending on the Ceph version the volume may need to be offline.
+//
+// Similar To:
+//
+//	ceph fs volume rename <volume> <newName> --yes-i-really-mean-it
+func (fsa *FSAdmin) RenameVolume(volume, newName string) error {
+	m := map[string]interface{}{
+		"prefix":               "fs volume rename",
+		"vol_name":             volume,
+		"new_vol_name":         newName,
+		"yes_i_really_mean_it": true,
+		"format":               "json",
+	}
+	// the status contains an informational message on success
+	return fsa.marshalMgrCommand(m).NoBody().End()
+}
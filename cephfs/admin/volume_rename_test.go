@@ -0,0 +1,21 @@
+//go:build ceph_preview && !(nautilus || octopus || pacific)
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameVolume(t *testing.T) {
+	fsa := getFSAdmin(t)
+
+	t.Run("notExist", func(t *testing.T) {
+		var ec ErrCode
+		err := fsa.RenameVolume("noSuchVolume", "stillNoSuchVolume")
+		assert.True(t, errors.As(err, &ec))
+		assert.Equal(t, -2, ec.ErrorCode())
+	})
+}
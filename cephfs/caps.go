@@ -0,0 +1,121 @@
+//go:build ceph_preview
+
+package cephfs
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <stdlib.h>
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import (
+	"strings"
+	"unsafe"
+)
+
+// Caps is a set of capabilities the MDS has issued to the client for an
+// inode. The capabilities control which state of the inode the client may
+// cache and modify locally.
+type Caps uint32
+
+// Capability bits. The Auth caps cover the ownership and mode, the Link caps
+// the link count, the Xattr caps the extended attributes and the File caps
+// the size, times and data of the inode.
+const (
+	CapPin          = Caps(1)
+	CapAuthShared   = Caps(1 << 2)
+	CapAuthExcl     = Caps(1 << 3)
+	CapLinkShared   = Caps(1 << 4)
+	CapLinkExcl     = Caps(1 << 5)
+	CapXattrShared  = Caps(1 << 6)
+	CapXattrExcl    = Caps(1 << 7)
+	CapFileShared   = Caps(1 << 8)
+	CapFileExcl     = Caps(1 << 9)
+	CapFileCache    = Caps(1 << 10)
+	CapFileRead     = Caps(1 << 11)
+	CapFileWrite    = Caps(1 << 12)
+	CapFileBuffer   = Caps(1 << 13)
+	CapFileWrExtend = Caps(1 << 14)
+	CapFileLazyIO   = Caps(1 << 15)
+)
+
+// capsShifts are the offsets of the non-pin capability groups, along with
+// the letter ceph uses for them.
+var capsShifts = []struct {
+	name  byte
+	shift uint
+}{
+	{'A', 2},
+	{'L', 4},
+	{'X', 6},
+	{'F', 8},
+}
+
+// String returns the caps in the compact format used by ceph, for example
+// "pAsLsXsFscr".
+func (c Caps) String() string {
+	var sb strings.Builder
+	if c&CapPin != 0 {
+		sb.WriteByte('p')
+	}
+	for _, g := range capsShifts {
+		bits := c >> g.shift
+		if g.name != 'F' {
+			// only the file caps have more than two bits
+			bits &= 3
+		}
+		if bits == 0 {
+			continue
+		}
+		sb.WriteByte(g.name)
+		for i, l := range "sxcrwbal" {
+			if bits&(1<<i) != 0 {
+				sb.WriteRune(l)
+			}
+		}
+	}
+	if sb.Len() == 0 {
+		return "-"
+	}
+	return sb.String()
+}
+
+// DebugGetCaps returns the caps held by the client for the open file. This
+// is intended for diagnosing stalls caused by caps that are not released by
+// another client.
+//
+// Implements:
+//
+//	int ceph_debug_get_fd_caps(struct ceph_mount_info *cmount, int fd);
+func (f *File) DebugGetCaps() (Caps, error) {
+	if err := f.validate(); err != nil {
+		return 0, err
+	}
+	ret := C.ceph_debug_get_fd_caps(f.mount.mount, f.fd)
+	if err := getErrorIfNegative(ret); err != nil {
+		return 0, err
+	}
+	return Caps(ret), nil
+}
+
+// DebugGetFileCaps returns the caps held by the client for the file at the
+// given path.
+//
+// Implements:
+//
+//	int ceph_debug_get_file_caps(struct ceph_mount_info *cmount, const char *path);
+func (mount *MountInfo) DebugGetFileCaps(path string) (Caps, error) {
+	if err := mount.validate(); err != nil {
+		return 0, err
+	}
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	ret := C.ceph_debug_get_file_caps(mount.mount, cPath)
+	if err := getErrorIfNegative(ret); err != nil {
+		return 0, err
+	}
+	return Caps(ret), nil
+}
@@ -0,0 +1,50 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapsString(t *testing.T) {
+	assert.Equal(t, "-", Caps(0).String())
+	assert.Equal(t, "p", CapPin.String())
+	assert.Equal(t, "pAsLsXsFscr",
+		(CapPin | CapAuthShared | CapLinkShared | CapXattrShared |
+			CapFileShared | CapFileCache | CapFileRead).String())
+	assert.Equal(t, "AxFxwbl",
+		(CapAuthExcl | CapFileExcl | CapFileWrite | CapFileBuffer |
+			CapFileLazyIO).String())
+	assert.Equal(t, "Fa", CapFileWrExtend.String())
+}
+
+func TestDebugGetCaps(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	fname := "test_debug_caps.txt"
+	f, err := mount.Open(fname, os.O_RDWR|os.O_CREATE, 0644)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, f.Close())
+		assert.NoError(t, mount.Unlink(fname))
+	}()
+
+	caps, err := f.DebugGetCaps()
+	assert.NoError(t, err)
+	assert.NotZero(t, caps&CapPin)
+
+	caps, err = mount.DebugGetFileCaps(fname)
+	assert.NoError(t, err)
+	assert.NotZero(t, caps&CapPin)
+
+	_, err = mount.DebugGetFileCaps("no-such-file")
+	assert.ErrorIs(t, err, ErrNotExist)
+
+	_, err = (&File{}).DebugGetCaps()
+	assert.Error(t, err)
+}
@@ -236,7 +236,14 @@ func (mount *MountInfo) Release() error {
 }
 
 // SyncFs synchronizes all filesystem data to persistent media.
+//
+// Implements:
+//
+//	int ceph_sync_fs(struct ceph_mount_info *cmount);
 func (mount *MountInfo) SyncFs() error {
+	if err := mount.validate(); err != nil {
+		return err
+	}
 	ret := C.ceph_sync_fs(mount.mount)
 	return getError(ret)
 }
@@ -69,6 +69,13 @@ func TestSyncFs(t *testing.T) {
 
 	err := mount.SyncFs()
 	assert.NoError(t, err)
+
+	t.Run("released", func(t *testing.T) {
+		m, err := CreateMount()
+		require.NoError(t, err)
+		require.NoError(t, m.Release())
+		assert.ErrorIs(t, m.SyncFs(), ErrNotConnected)
+	})
 }
 
 func TestUnmountMount(t *testing.T) {
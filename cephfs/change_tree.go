@@ -0,0 +1,127 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// permBits are the mode bits changed by ChangeTree.
+const permBits = 0o7777
+
+// ChangeTreeProgress reports the progress of a ChangeTree call.
+type ChangeTreeProgress struct {
+	// Path of the entry that was just processed.
+	Path string
+	// Visited is the number of entries processed so far.
+	Visited uint64
+	// Changed is the number of entries that were modified so far. Entries
+	// that already had the requested ownership and mode are not modified.
+	Changed uint64
+}
+
+// ChangeTreeOptions describe the changes applied by ChangeTree.
+type ChangeTreeOptions struct {
+	// Chown enables changing the ownership of all entries to Uid and Gid.
+	// Symbolic links are changed themselves rather than their targets.
+	Chown bool
+	Uid   uint32
+	Gid   uint32
+	// Chmod enables changing the permission bits of all directories to
+	// DirMode and of all other entries, except symbolic links, to FileMode.
+	Chmod    bool
+	DirMode  uint32
+	FileMode uint32
+	// Workers is the number of directories that are processed in parallel.
+	// If zero a default number of workers is used.
+	Workers int
+	// Progress, if set, is called after each entry has been processed. It
+	// is called concurrently from multiple goroutines.
+	Progress func(ChangeTreeProgress)
+}
+
+// ChangeTree recursively changes the ownership and/or permission bits of
+// the directory root and of everything below it.
+//
+// Entries that already have the requested ownership and mode are left
+// untouched. An interrupted ChangeTree can therefore be restarted by
+// calling it again with the same options, only the remaining entries are
+// changed.
+//
+// The tree is walked with WalkDir. See WalkDir for how the walk can be
+// canceled.
+func (mount *MountInfo) ChangeTree(
+	ctx context.Context, root string, opts ChangeTreeOptions) error {
+
+	if err := mount.validate(); err != nil {
+		return err
+	}
+	if !opts.Chown && !opts.Chmod {
+		return errInvalid
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c := &treeChanger{mount: mount, opts: opts}
+
+	stx, err := mount.Statx(root, StatxBasicStats, AtSymlinkNofollow)
+	if err != nil {
+		return err
+	}
+	if err := c.change(root, stx); err != nil {
+		return err
+	}
+
+	walkOpts := &WalkOptions{
+		Workers: opts.Workers,
+		Want:    StatxBasicStats,
+		Flags:   AtSymlinkNofollow,
+	}
+	return mount.WalkDir(ctx, root, walkOpts, func(p string, e *DirEntryPlus) error {
+		return c.change(p, e.Statx())
+	})
+}
+
+type treeChanger struct {
+	mount   *MountInfo
+	opts    ChangeTreeOptions
+	visited atomic.Uint64
+	changed atomic.Uint64
+}
+
+func (c *treeChanger) change(p string, stx *CephStatx) error {
+	isLink := stx.Mode&modeIFMT == modeIFLNK
+	isDir := stx.Mode&modeIFMT == modeIFDIR
+	modified := false
+
+	if c.opts.Chown && (stx.Uid != c.opts.Uid || stx.Gid != c.opts.Gid) {
+		if err := c.mount.Lchown(p, c.opts.Uid, c.opts.Gid); err != nil {
+			return err
+		}
+		modified = true
+	}
+	if c.opts.Chmod && !isLink {
+		mode := c.opts.FileMode
+		if isDir {
+			mode = c.opts.DirMode
+		}
+		if uint32(stx.Mode)&permBits != mode&permBits {
+			if err := c.mount.Chmod(p, mode&permBits); err != nil {
+				return err
+			}
+			modified = true
+		}
+	}
+
+	progress := ChangeTreeProgress{Path: p, Visited: c.visited.Add(1)}
+	if modified {
+		progress.Changed = c.changed.Add(1)
+	} else {
+		progress.Changed = c.changed.Load()
+	}
+	if c.opts.Progress != nil {
+		c.opts.Progress(progress)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeTree(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	root := "/change-tree-test"
+	var cleanup []func()
+	defer func() {
+		for i := len(cleanup) - 1; i >= 0; i-- {
+			cleanup[i]()
+		}
+	}()
+	mkdir := func(p string) {
+		require.NoError(t, mount.MakeDir(p, 0755))
+		cleanup = append(cleanup, func() { assert.NoError(t, mount.RemoveDir(p)) })
+	}
+	mkfile := func(p string) {
+		writeFile(t, mount, p, []byte("x"))
+		cleanup = append(cleanup, func() { assert.NoError(t, mount.Unlink(p)) })
+	}
+	mkdir(root)
+	entries := []string{root}
+	for i := 0; i < 3; i++ {
+		d := path.Join(root, fmt.Sprintf("d%d", i))
+		mkdir(d)
+		entries = append(entries, d)
+		for j := 0; j < 2; j++ {
+			f := path.Join(d, fmt.Sprintf("f%d", j))
+			mkfile(f)
+			entries = append(entries, f)
+		}
+	}
+	link := path.Join(root, "link")
+	require.NoError(t, mount.Symlink("d0", link))
+	cleanup = append(cleanup, func() { assert.NoError(t, mount.Unlink(link)) })
+	entries = append(entries, link)
+
+	opts := ChangeTreeOptions{
+		Chown:    true,
+		Uid:      1010,
+		Gid:      1011,
+		Chmod:    true,
+		DirMode:  0750,
+		FileMode: 0640,
+		Workers:  2,
+	}
+
+	t.Run("change", func(t *testing.T) {
+		var (
+			mu   sync.Mutex
+			last ChangeTreeProgress
+			seen = map[string]bool{}
+		)
+		o := opts
+		o.Progress = func(p ChangeTreeProgress) {
+			mu.Lock()
+			defer mu.Unlock()
+			seen[p.Path] = true
+			if p.Visited > last.Visited {
+				last = p
+			}
+		}
+		err := mount.ChangeTree(context.Background(), root, o)
+		require.NoError(t, err)
+		assert.Len(t, seen, len(entries))
+		assert.EqualValues(t, len(entries), last.Visited)
+
+		for _, p := range entries {
+			stx, err := mount.Statx(p, StatxBasicStats, AtSymlinkNofollow)
+			require.NoError(t, err)
+			assert.EqualValues(t, 1010, stx.Uid, p)
+			assert.EqualValues(t, 1011, stx.Gid, p)
+			switch stx.Mode & modeIFMT {
+			case modeIFDIR:
+				assert.EqualValues(t, 0750, stx.Mode&permBits, p)
+			case modeIFREG:
+				assert.EqualValues(t, 0640, stx.Mode&permBits, p)
+			}
+		}
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		var changed uint64
+		o := opts
+		o.Progress = func(p ChangeTreeProgress) {
+			changed = p.Changed
+		}
+		o.Workers = 1
+		err := mount.ChangeTree(context.Background(), root, o)
+		require.NoError(t, err)
+		// everything was changed by the previous call already
+		assert.EqualValues(t, 0, changed)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		o := opts
+		o.Uid = 1020
+		err := mount.ChangeTree(ctx, root, o)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		// not even the root was changed
+		stx, err := mount.Statx(root, StatxBasicStats, AtSymlinkNofollow)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1010, stx.Uid)
+	})
+
+	t.Run("noChange", func(t *testing.T) {
+		err := mount.ChangeTree(context.Background(), root, ChangeTreeOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("notExist", func(t *testing.T) {
+		err := mount.ChangeTree(context.Background(), "/no-such-dir", opts)
+		assert.ErrorIs(t, err, ErrNotExist)
+	})
+}
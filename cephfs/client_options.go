@@ -0,0 +1,60 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// The setters in this file wrap SetConfigOption for commonly tuned client
+// options, formatting the values as libcephfs expects them. libcephfs
+// rejects options it does not know and values that fail its validation.
+// Most of the options only take effect if they are set before the file
+// system is mounted.
+
+// formatSeconds formats a duration as the whole number of seconds, rounded
+// up, that is expected by the time based client options.
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(math.Ceil(d.Seconds()), 'f', 0, 64)
+}
+
+func formatBool(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+// SetReadaheadMaxBytes sets the maximum number of bytes the client reads
+// ahead of sequential reads. Zero means no limit.
+func (mount *MountInfo) SetReadaheadMaxBytes(n uint64) error {
+	return mount.SetConfigOption(
+		"client_readahead_max_bytes", strconv.FormatUint(n, 10))
+}
+
+// SetReadaheadMaxPeriods sets the maximum read-ahead as a number of file
+// layout periods (object size times stripe count).
+func (mount *MountInfo) SetReadaheadMaxPeriods(n uint64) error {
+	return mount.SetConfigOption(
+		"client_readahead_max_periods", strconv.FormatUint(n, 10))
+}
+
+// SetObjectCache enables or disables the object cache of the client, which
+// caches file data and buffers writes.
+func (mount *MountInfo) SetObjectCache(enable bool) error {
+	return mount.SetConfigOption("client_oc", formatBool(enable))
+}
+
+// SetObjectCacheSize sets the size, in bytes, of the object cache of the
+// client.
+func (mount *MountInfo) SetObjectCacheSize(n uint64) error {
+	return mount.SetConfigOption("client_oc_size", strconv.FormatUint(n, 10))
+}
+
+// SetMountTimeout sets the time to wait for the mount to be established. It
+// is rounded up to whole seconds.
+func (mount *MountInfo) SetMountTimeout(d time.Duration) error {
+	if d <= 0 {
+		return errInvalid
+	}
+	return mount.SetConfigOption("client_mount_timeout", formatSeconds(d))
+}
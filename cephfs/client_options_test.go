@@ -0,0 +1,54 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatSeconds(t *testing.T) {
+	assert.Equal(t, "30", formatSeconds(30*time.Second))
+	assert.Equal(t, "2", formatSeconds(1100*time.Millisecond))
+	assert.Equal(t, "1", formatSeconds(time.Nanosecond))
+}
+
+func TestClientOptions(t *testing.T) {
+	mount, err := CreateMount()
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, mount.Release()) }()
+
+	check := func(option, expected string) {
+		v, err := mount.GetConfigOption(option)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, v)
+	}
+	// sizes and durations may be formatted with units by libcephfs
+	checkSet := func(option string) {
+		v, err := mount.GetConfigOption(option)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, v)
+	}
+
+	assert.NoError(t, mount.SetReadaheadMaxBytes(8<<20))
+	checkSet("client_readahead_max_bytes")
+	assert.NoError(t, mount.SetReadaheadMaxPeriods(2))
+	check("client_readahead_max_periods", "2")
+	assert.NoError(t, mount.SetObjectCache(false))
+	check("client_oc", "false")
+	assert.NoError(t, mount.SetObjectCacheSize(64<<20))
+	checkSet("client_oc_size")
+	assert.NoError(t, mount.SetMountTimeout(45*time.Second))
+	checkSet("client_mount_timeout")
+
+	assert.Error(t, mount.SetMountTimeout(0))
+
+	// the client options are usable for mounting
+	require.NoError(t, mount.ReadDefaultConfigFile())
+	assert.NoError(t, mount.SetObjectCache(true))
+	require.NoError(t, mount.Mount())
+	assert.NoError(t, mount.Unmount())
+}
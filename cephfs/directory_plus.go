@@ -0,0 +1,33 @@
+//go:build ceph_preview
+
+package cephfs
+
+// ListPlus returns all the entries of the directory together with their
+// stat information, using a single pass over the directory. This avoids the
+// need to call Statx for each entry after listing the directory.
+//
+// ListPlus is implemented using ReadDirPlus. If any of the calls to
+// ReadDirPlus returns an error ListPlus will return the error together with
+// all the entries collected so far.
+// ListPlus rewinds the directory stream every time it is called to get a full
+// listing of the directory contents.
+// See Statx for a description of the wants and flags parameters.
+func (dir *Directory) ListPlus(
+	want StatxMask, flags AtFlags) ([]*DirEntryPlus, error) {
+
+	if dir.dir == nil {
+		return nil, errBadFile
+	}
+	entries := make([]*DirEntryPlus, 0)
+	dir.RewindDir()
+	for {
+		entry, err := dir.ReadDirPlus(want, flags)
+		if err != nil {
+			return entries, err
+		}
+		if entry == nil {
+			return entries, nil
+		}
+		entries = append(entries, entry)
+	}
+}
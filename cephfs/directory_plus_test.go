@@ -0,0 +1,53 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPlus(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	dir1 := "/base-listplus"
+	err := mount.MakeDir(dir1, 0755)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, mount.RemoveDir(dir1)) }()
+
+	subdirs := []string{"a", "bb", "ccc"}
+	for _, s := range subdirs {
+		spath := dir1 + "/" + s
+		require.NoError(t, mount.MakeDir(spath, 0700))
+		defer func(d string) {
+			assert.NoError(t, mount.RemoveDir(d))
+		}(spath)
+	}
+
+	dir, err := mount.OpenDir(dir1)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, dir.Close()) }()
+
+	// call twice to verify the directory stream is rewound
+	for i := 0; i < 2; i++ {
+		entries, err := dir.ListPlus(StatxBasicStats, AtSymlinkNofollow)
+		assert.NoError(t, err)
+		found := map[string]*CephStatx{}
+		for _, e := range entries {
+			found[e.Name()] = e.Statx()
+		}
+		for _, s := range subdirs {
+			if assert.Contains(t, found, s) {
+				assert.EqualValues(t, 0700, found[s].Mode&0777)
+				assert.NotEqual(t, Inode(0), found[s].Inode)
+			}
+		}
+	}
+
+	closed := &Directory{}
+	_, err = closed.ListPlus(StatxBasicStats, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,47 @@
+//go:build ceph_preview
+
+package cephfs
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+// TellDir returns the current position of the directory stream. The
+// position can be passed to SeekDir, also of another Directory handle of
+// the same directory, to resume reading the directory at the entry that
+// would have been read next. This allows paginated listings to continue
+// from a stored position rather than reading the directory from the start.
+//
+// Implements:
+//
+//	int64_t ceph_telldir(struct ceph_mount_info *cmount, struct ceph_dir_result *dirp);
+func (dir *Directory) TellDir() (int64, error) {
+	if dir.dir == nil {
+		return 0, errBadFile
+	}
+	ret := C.ceph_telldir(dir.mount.mount, dir.dir)
+	if ret < 0 {
+		return 0, getError(C.int(ret))
+	}
+	return int64(ret), nil
+}
+
+// SeekDir sets the position of the directory stream to a position
+// previously returned by TellDir.
+//
+// Implements:
+//
+//	void ceph_seekdir(struct ceph_mount_info *cmount, struct ceph_dir_result *dirp, int64_t offset);
+func (dir *Directory) SeekDir(offset int64) error {
+	if dir.dir == nil {
+		return errBadFile
+	}
+	if offset < 0 {
+		return errInvalid
+	}
+	C.ceph_seekdir(dir.mount.mount, dir.dir, C.int64_t(offset))
+	return nil
+}
@@ -0,0 +1,72 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryTellSeek(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	dir := "/dir-seek-test"
+	require.NoError(t, mount.MakeDir(dir, 0755))
+	defer func() { assert.NoError(t, mount.RemoveDir(dir)) }()
+	for i := 0; i < 10; i++ {
+		fname := path.Join(dir, fmt.Sprintf("file%d", i))
+		writeFile(t, mount, fname, nil)
+		defer func() { assert.NoError(t, mount.Unlink(fname)) }()
+	}
+
+	readNames := func(d *Directory, n int) []string {
+		var names []string
+		for n < 0 || len(names) < n {
+			de, err := d.ReadDir()
+			require.NoError(t, err)
+			if de == nil {
+				break
+			}
+			names = append(names, de.Name())
+		}
+		return names
+	}
+
+	d1, err := mount.OpenDir(dir)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, d1.Close()) }()
+
+	first := readNames(d1, 5)
+	require.Len(t, first, 5)
+	pos, err := d1.TellDir()
+	require.NoError(t, err)
+	rest := readNames(d1, -1)
+	// 10 files plus "." and ".."
+	assert.Len(t, append(first, rest...), 12)
+
+	t.Run("sameHandle", func(t *testing.T) {
+		assert.NoError(t, d1.SeekDir(pos))
+		assert.Equal(t, rest, readNames(d1, -1))
+	})
+
+	t.Run("otherHandle", func(t *testing.T) {
+		d2, err := mount.OpenDir(dir)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, d2.Close()) }()
+		assert.NoError(t, d2.SeekDir(pos))
+		assert.Equal(t, rest, readNames(d2, -1))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, d1.SeekDir(-1))
+		d := &Directory{}
+		_, err := d.TellDir()
+		assert.Error(t, err)
+		assert.Error(t, d.SeekDir(0))
+	})
+}
@@ -0,0 +1,79 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DirStats contains the statistics CephFS maintains for a directory. The
+// fields prefixed with R are recursive and account for the whole tree below
+// the directory.
+type DirStats struct {
+	// Entries is the number of entries of the directory.
+	Entries uint64
+	// Files is the number of files in the directory.
+	Files uint64
+	// Subdirs is the number of sub-directories of the directory.
+	Subdirs uint64
+	// REntries is the number of entries below the directory.
+	REntries uint64
+	// RFiles is the number of files below the directory.
+	RFiles uint64
+	// RSubdirs is the number of directories below the directory.
+	RSubdirs uint64
+	// RBytes is the total size of the files below the directory.
+	RBytes uint64
+	// RCtime is the latest ctime of all the files and directories below
+	// the directory.
+	RCtime time.Time
+}
+
+// GetDirStats returns the statistics of the directory at path.
+func (mount *MountInfo) GetDirStats(path string) (*DirStats, error) {
+	ds := &DirStats{}
+	counters := []struct {
+		name  string
+		value *uint64
+	}{
+		{"ceph.dir.entries", &ds.Entries},
+		{"ceph.dir.files", &ds.Files},
+		{"ceph.dir.subdirs", &ds.Subdirs},
+		{"ceph.dir.rentries", &ds.REntries},
+		{"ceph.dir.rfiles", &ds.RFiles},
+		{"ceph.dir.rsubdirs", &ds.RSubdirs},
+		{"ceph.dir.rbytes", &ds.RBytes},
+	}
+	for _, c := range counters {
+		value, err := mount.GetXattr(path, c.name)
+		if err != nil {
+			return nil, err
+		}
+		*c.value, err = parseDirStat(string(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.name, err)
+		}
+	}
+
+	rctime, err := mount.GetXattr(path, "ceph.dir.rctime")
+	if err != nil {
+		return nil, err
+	}
+	ds.RCtime, err = parseVxattrTime(string(rctime))
+	if err != nil {
+		return nil, fmt.Errorf("ceph.dir.rctime: %w", err)
+	}
+	return ds, nil
+}
+
+func parseDirStat(s string) (uint64, error) {
+	s = strings.TrimRight(s, "\x00")
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value: %q: %w", s, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,63 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDirStat(t *testing.T) {
+	v, err := parseDirStat("42")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 42, v)
+	v, err = parseDirStat("7\x00")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 7, v)
+	_, err = parseDirStat("")
+	assert.Error(t, err)
+	_, err = parseDirStat("x")
+	assert.Error(t, err)
+}
+
+func TestGetDirStats(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	dir := "/dirstats-test"
+	require.NoError(t, mount.MakeDir(dir, 0755))
+	defer func() { assert.NoError(t, mount.RemoveDir(dir)) }()
+	sub := dir + "/sub"
+	require.NoError(t, mount.MakeDir(sub, 0755))
+	defer func() { assert.NoError(t, mount.RemoveDir(sub)) }()
+
+	data := []byte("some data for the statistics")
+	for _, p := range []string{dir + "/f1", sub + "/f2"} {
+		f, err := mount.Open(p, os.O_RDWR|os.O_CREATE, 0644)
+		require.NoError(t, err)
+		_, err = f.Write(data)
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+		defer func(p string) { assert.NoError(t, mount.Unlink(p)) }(p)
+	}
+	// make sure the client flushes the metadata to the MDS
+	assert.NoError(t, mount.SyncFs())
+
+	ds, err := mount.GetDirStats(dir)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, ds.Entries)
+	assert.EqualValues(t, 1, ds.Files)
+	assert.EqualValues(t, 1, ds.Subdirs)
+	// the recursive statistics are propagated lazily by the MDS, so only
+	// check that they are sane
+	assert.GreaterOrEqual(t, ds.REntries, ds.RFiles)
+	assert.LessOrEqual(t, ds.RFiles, uint64(2))
+	assert.LessOrEqual(t, ds.RBytes, uint64(2*len(data)))
+	assert.False(t, ds.RCtime.IsZero())
+
+	_, err = mount.GetDirStats("/dirstats-test-missing")
+	assert.ErrorIs(t, err, ErrNotExist)
+}
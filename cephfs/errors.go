@@ -50,4 +50,5 @@ var (
 	errRange       = getError(-C.ERANGE)
 	errBadFile     = getError(-C.EBADF)
 	errNotDir      = getError(-C.ENOTDIR)
+	errNoData      = getError(-C.ENODATA)
 )
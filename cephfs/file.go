@@ -29,6 +29,13 @@ const (
 	SeekCur = int(C.SEEK_CUR)
 	// SeekEnd is used with Seek to position the file relative to the end.
 	SeekEnd = int(C.SEEK_END)
+	// SeekData is used with Seek to position the file at the next location
+	// greater than or equal to the offset that contains data.
+	SeekData = int(C.SEEK_DATA)
+	// SeekHole is used with Seek to position the file at the next hole
+	// greater than or equal to the offset. The end of the file is treated
+	// as a hole.
+	SeekHole = int(C.SEEK_HOLE)
 )
 
 // SyncChoice is used to control how metadata and/or data is sync'ed to
@@ -51,6 +58,16 @@ type File struct {
 // Open a file at the given path. The flags are the same os flags as
 // a local open call. Mode is the same mode bits as a local open call.
 //
+// libcephfs has no per-file controls for its object cache or read-ahead.
+// Passing os.O_SYNC in flags makes each write through the returned File
+// flush the written range from the client cache before returning. See also
+// LazyIO for relaxing the cache coherency of a file shared between clients.
+//
+// If flags include os.O_APPEND, the data of every Write is placed at the end
+// of the file as it is at the time of the write, regardless of the current
+// file position. This also holds for concurrent appends through multiple
+// open files.
+//
 // Implements:
 //
 //	int ceph_open(struct ceph_mount_info *cmount, const char *path, int flags, mode_t mode);
@@ -154,6 +171,9 @@ func (f *File) Preadv(data [][]byte, offset int64) (int, error) {
 	if err := f.validate(); err != nil {
 		return 0, err
 	}
+	if len(data) == 0 {
+		return 0, nil
+	}
 	iov := cutil.ByteSlicesToIovec(data)
 	defer iov.Free()
 
@@ -224,6 +244,9 @@ func (f *File) Pwritev(data [][]byte, offset int64) (int, error) {
 	if err := f.validate(); err != nil {
 		return 0, err
 	}
+	if len(data) == 0 {
+		return 0, nil
+	}
 	iov := cutil.ByteSlicesToIovec(data)
 	defer iov.Free()
 
@@ -250,9 +273,8 @@ func (f *File) Seek(offset int64, whence int) (int64, error) {
 	}
 	// validate the seek whence value in case the caller skews
 	// from the seek values we technically support from C as documented.
-	// TODO: need to support seek-(hole|data) in mimic and later.
 	switch whence {
-	case SeekSet, SeekCur, SeekEnd:
+	case SeekSet, SeekCur, SeekEnd, SeekData, SeekHole:
 	default:
 		return 0, errInvalid
 	}
@@ -0,0 +1,146 @@
+//go:build ceph_preview
+
+package cephfs
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#define _GNU_SOURCE
+#include <stdlib.h>
+#include <fcntl.h>
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// AtRemoveDir indicates that UnlinkAt should remove a directory rather than
+// a file.
+const AtRemoveDir = AtFlags(C.AT_REMOVEDIR)
+
+// OpenAt opens a file at the given path relative to the directory
+// represented by this open File. The flags are the same os flags as a local
+// open call. Mode is the same mode bits as a local open call.
+//
+// Implements:
+//
+//	int ceph_openat(struct ceph_mount_info *cmount, int dirfd, const char *relpath,
+//	                int flags, mode_t mode);
+func (f *File) OpenAt(path string, flags int, mode uint32) (*File, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	ret := C.ceph_openat(f.mount.mount, f.fd, cPath, C.int(flags), C.mode_t(mode))
+	if ret < 0 {
+		return nil, getError(ret)
+	}
+	return &File{mount: f.mount, fd: ret}, nil
+}
+
+// MakeDirAt creates a directory at the given path relative to the directory
+// represented by this open File.
+//
+// Implements:
+//
+//	int ceph_mkdirat(struct ceph_mount_info *cmount, int dirfd, const char *relpath,
+//	                 mode_t mode);
+func (f *File) MakeDirAt(path string, mode uint32) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	ret := C.ceph_mkdirat(f.mount.mount, f.fd, cPath, C.mode_t(mode))
+	return getError(ret)
+}
+
+// UnlinkAt removes the file at the given path relative to the directory
+// represented by this open File. If flags contains AtRemoveDir the empty
+// directory at the given path is removed instead.
+//
+// Implements:
+//
+//	int ceph_unlinkat(struct ceph_mount_info *cmount, int dirfd, const char *relpath,
+//	                  int flags);
+func (f *File) UnlinkAt(path string, flags AtFlags) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	ret := C.ceph_unlinkat(f.mount.mount, f.fd, cPath, C.int(flags))
+	return getError(ret)
+}
+
+// StatxAt returns information about the file at the given path relative to
+// the directory represented by this open File.
+//
+// Implements:
+//
+//	int ceph_statxat(struct ceph_mount_info *cmount, int dirfd, const char *relpath,
+//	                 struct ceph_statx *stx, unsigned int want, unsigned int flags);
+func (f *File) StatxAt(path string, want StatxMask, flags AtFlags) (*CephStatx, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	var stx C.struct_ceph_statx
+	ret := C.ceph_statxat(
+		f.mount.mount,
+		f.fd,
+		cPath,
+		&stx,
+		C.uint(want),
+		C.uint(flags),
+	)
+	if err := getError(ret); err != nil {
+		return nil, err
+	}
+	return cStructToCephStatx(stx), nil
+}
+
+// FchmodAt changes the mode bits (permissions) of the file at the given path
+// relative to the directory represented by this open File.
+//
+// Implements:
+//
+//	int ceph_chmodat(struct ceph_mount_info *cmount, int dirfd, const char *relpath,
+//	                 mode_t mode, int flags);
+func (f *File) FchmodAt(path string, mode uint32, flags AtFlags) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	ret := C.ceph_chmodat(f.mount.mount, f.fd, cPath, C.mode_t(mode), C.int(flags))
+	return getError(ret)
+}
+
+// FchownAt changes the ownership of the file at the given path relative to
+// the directory represented by this open File.
+//
+// Implements:
+//
+//	int ceph_chownat(struct ceph_mount_info *cmount, int dirfd, const char *relpath,
+//	                 uid_t uid, gid_t gid, int flags);
+func (f *File) FchownAt(path string, user uint32, group uint32, flags AtFlags) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	ret := C.ceph_chownat(
+		f.mount.mount, f.fd, cPath, C.uid_t(user), C.gid_t(group), C.int(flags))
+	return getError(ret)
+}
@@ -0,0 +1,82 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileAt(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	dirName := "dir-at"
+	err := mount.MakeDir(dirName, 0755)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, mount.RemoveDir(dirName)) }()
+
+	dir, err := mount.Open(dirName, os.O_RDONLY, 0)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, dir.Close()) }()
+
+	t.Run("openAt", func(t *testing.T) {
+		f, err := dir.OpenAt("file1", os.O_RDWR|os.O_CREATE, 0644)
+		require.NoError(t, err)
+		_, err = f.Write([]byte("relative"))
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+
+		st, err := mount.Statx(dirName+"/file1", StatxSize, 0)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 8, st.Size)
+
+		st, err = dir.StatxAt("file1", StatxBasicStats, 0)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 8, st.Size)
+		assert.EqualValues(t, 0644, st.Mode&0777)
+
+		assert.NoError(t, dir.FchmodAt("file1", 0600, 0))
+		assert.NoError(t, dir.FchownAt("file1", 1000, 1000, 0))
+		st, err = dir.StatxAt("file1", StatxBasicStats, 0)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0600, st.Mode&0777)
+		assert.EqualValues(t, 1000, st.Uid)
+		assert.EqualValues(t, 1000, st.Gid)
+
+		assert.NoError(t, dir.UnlinkAt("file1", 0))
+		_, err = dir.StatxAt("file1", StatxBasicStats, 0)
+		assert.ErrorIs(t, err, ErrNotExist)
+	})
+
+	t.Run("makeDirAt", func(t *testing.T) {
+		assert.NoError(t, dir.MakeDirAt("sub", 0700))
+		st, err := dir.StatxAt("sub", StatxBasicStats, 0)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0700, st.Mode&0777)
+
+		// unlinking a directory requires AtRemoveDir
+		assert.Error(t, dir.UnlinkAt("sub", 0))
+		assert.NoError(t, dir.UnlinkAt("sub", AtRemoveDir))
+	})
+
+	t.Run("notExist", func(t *testing.T) {
+		_, err := dir.OpenAt("missing", os.O_RDONLY, 0)
+		assert.ErrorIs(t, err, ErrNotExist)
+	})
+
+	t.Run("invalidFile", func(t *testing.T) {
+		f := &File{}
+		_, err := f.OpenAt("file1", os.O_RDONLY, 0)
+		assert.ErrorIs(t, err, ErrNotConnected)
+		assert.ErrorIs(t, f.MakeDirAt("x", 0755), ErrNotConnected)
+		assert.ErrorIs(t, f.UnlinkAt("x", 0), ErrNotConnected)
+		_, err = f.StatxAt("x", StatxBasicStats, 0)
+		assert.ErrorIs(t, err, ErrNotConnected)
+		assert.ErrorIs(t, f.FchmodAt("x", 0755, 0), ErrNotConnected)
+		assert.ErrorIs(t, f.FchownAt("x", 0, 0, 0), ErrNotConnected)
+	})
+}
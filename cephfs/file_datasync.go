@@ -0,0 +1,11 @@
+//go:build ceph_preview
+
+package cephfs
+
+// DataSync ensures the file data that may be cached is committed to stable
+// storage. Unlike Sync, metadata that is not needed to read back the data,
+// such as the modification time, is not necessarily committed.
+// DataSync behaves like fdatasync on linux.
+func (f *File) DataSync() error {
+	return f.Fsync(SyncDataOnly)
+}
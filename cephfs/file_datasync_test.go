@@ -0,0 +1,31 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSync(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	fname := "test_datasync.txt"
+	defer mount.Unlink(fname)
+
+	f, err := mount.Open(fname, os.O_RDWR|os.O_CREATE, 0644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("wonderwoman"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.DataSync())
+	assert.NoError(t, f.Close())
+
+	t.Run("invalid", func(t *testing.T) {
+		f := &File{}
+		assert.Error(t, f.DataSync())
+	})
+}
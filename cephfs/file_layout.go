@@ -0,0 +1,73 @@
+//go:build ceph_preview
+
+package cephfs
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <stdlib.h>
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// OpenWithLayout opens the file at the given path like Open and, if the
+// file is created by the call, creates it with the given layout. Setting
+// the layout as part of the creation avoids data being written using the
+// default layout before SetFileLayout is called. Fields of the layout with
+// a zero value use the layout inherited from the parent directory. The Pool
+// of the layout must be the name of a data pool of the file system and the
+// PoolNamespace is not supported. If the file already exists its layout is
+// not changed.
+//
+// Implements:
+//
+//	int ceph_open_layout(struct ceph_mount_info *cmount, const char *path, int flags,
+//	                     mode_t mode, int stripe_unit, int stripe_count, int object_size,
+//	                     const char *data_pool);
+func (mount *MountInfo) OpenWithLayout(
+	path string, flags int, mode uint32, layout Layout) (*File, error) {
+
+	if err := mount.validate(); err != nil {
+		return nil, err
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	if layout.PoolNamespace != "" {
+		return nil, fmt.Errorf("%w: pool namespace not supported",
+			ErrInvalidLayout)
+	}
+	if layout.StripeUnit > math.MaxInt32 ||
+		layout.StripeCount > math.MaxInt32 ||
+		layout.ObjectSize > math.MaxInt32 {
+		return nil, fmt.Errorf("%w: value out of range", ErrInvalidLayout)
+	}
+
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	var cPool *C.char
+	if layout.Pool != "" {
+		cPool = C.CString(layout.Pool)
+		defer C.free(unsafe.Pointer(cPool))
+	}
+
+	ret := C.ceph_open_layout(
+		mount.mount,
+		cPath,
+		C.int(flags),
+		C.mode_t(mode),
+		C.int(layout.StripeUnit),
+		C.int(layout.StripeCount),
+		C.int(layout.ObjectSize),
+		cPool)
+	if ret < 0 {
+		return nil, getError(ret)
+	}
+	return &File{mount: mount, fd: ret}, nil
+}
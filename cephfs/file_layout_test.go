@@ -0,0 +1,61 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenWithLayout(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	fname := "/test_open_with_layout.txt"
+	layout := Layout{StripeUnit: 65536, StripeCount: 2, ObjectSize: 131072}
+	f, err := mount.OpenWithLayout(fname, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640, layout)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, mount.Unlink(fname)) }()
+	_, err = f.Write([]byte("striped"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	l, err := mount.GetFileLayout(fname)
+	require.NoError(t, err)
+	assert.Equal(t, layout.StripeUnit, l.StripeUnit)
+	assert.Equal(t, layout.StripeCount, l.StripeCount)
+	assert.Equal(t, layout.ObjectSize, l.ObjectSize)
+	assert.NotEmpty(t, l.Pool)
+
+	st, err := mount.Statx(fname, StatxMode, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0640, st.Mode&0777)
+
+	t.Run("explicitPool", func(t *testing.T) {
+		fname2 := "/test_open_with_layout_pool.txt"
+		f, err := mount.OpenWithLayout(fname2, os.O_RDWR|os.O_CREATE, 0644,
+			Layout{Pool: l.Pool})
+		require.NoError(t, err)
+		assert.NoError(t, f.Close())
+		defer func() { assert.NoError(t, mount.Unlink(fname2)) }()
+
+		l2, err := mount.GetFileLayout(fname2)
+		require.NoError(t, err)
+		assert.Equal(t, l.Pool, l2.Pool)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := mount.OpenWithLayout("/x", os.O_RDWR|os.O_CREATE, 0644,
+			Layout{StripeUnit: 1000})
+		assert.ErrorIs(t, err, ErrInvalidLayout)
+		_, err = mount.OpenWithLayout("/x", os.O_RDWR|os.O_CREATE, 0644,
+			Layout{PoolNamespace: "ns"})
+		assert.ErrorIs(t, err, ErrInvalidLayout)
+		_, err = mount.OpenWithLayout("/x", os.O_RDWR|os.O_CREATE, 0644,
+			Layout{Pool: "no-such-pool"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,70 @@
+//go:build ceph_preview
+
+package cephfs
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+// LazyIO enables or disables lazy I/O for the open file. With lazy I/O
+// enabled the client relaxes the cache coherency between clients sharing
+// the file, allowing them to buffer writes and cache reads. Applications
+// must then use LazyIOPropagate and LazyIOSynchronize to make their writes
+// visible to, and see the writes of, other clients.
+//
+// Implements:
+//
+//	int ceph_lazyio(struct ceph_mount_info *cmount, int fd, int enable);
+func (f *File) LazyIO(enable bool) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	var cEnable C.int
+	if enable {
+		cEnable = 1
+	}
+	ret := C.ceph_lazyio(f.mount.mount, f.fd, cEnable)
+	return getError(ret)
+}
+
+// LazyIOPropagate flushes the buffered writes of the given range of the
+// file, so that they become visible to other clients.
+//
+// Implements:
+//
+//	int ceph_lazyio_propagate(struct ceph_mount_info *cmount, int fd,
+//	                          int64_t offset, size_t count);
+func (f *File) LazyIOPropagate(offset int64, count uint64) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	if offset < 0 {
+		return errInvalid
+	}
+	ret := C.ceph_lazyio_propagate(
+		f.mount.mount, f.fd, C.int64_t(offset), C.size_t(count))
+	return getError(ret)
+}
+
+// LazyIOSynchronize flushes the buffered writes and invalidates the cached
+// data of the given range of the file, so that subsequent reads see the
+// writes propagated by other clients.
+//
+// Implements:
+//
+//	int ceph_lazyio_synchronize(struct ceph_mount_info *cmount, int fd,
+//	                            int64_t offset, size_t count);
+func (f *File) LazyIOSynchronize(offset int64, count uint64) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	if offset < 0 {
+		return errInvalid
+	}
+	ret := C.ceph_lazyio_synchronize(
+		f.mount.mount, f.fd, C.int64_t(offset), C.size_t(count))
+	return getError(ret)
+}
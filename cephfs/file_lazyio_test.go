@@ -0,0 +1,53 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyIO(t *testing.T) {
+	mount1 := fsConnect(t)
+	defer fsDisconnect(t, mount1)
+	mount2 := fsConnect(t)
+	defer fsDisconnect(t, mount2)
+
+	fname := "lazyio.txt"
+	f1, err := mount1.Open(fname, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, f1.Close())
+		assert.NoError(t, mount1.Unlink(fname))
+	}()
+	f2, err := mount2.Open(fname, os.O_RDWR, 0)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, f2.Close()) }()
+
+	assert.NoError(t, f1.LazyIO(true))
+	assert.NoError(t, f2.LazyIO(true))
+
+	data := []byte("written lazily")
+	_, err = f1.WriteAt(data, 0)
+	require.NoError(t, err)
+	assert.NoError(t, f1.LazyIOPropagate(0, uint64(len(data))))
+
+	assert.NoError(t, f2.LazyIOSynchronize(0, uint64(len(data))))
+	buf := make([]byte, len(data))
+	n, err := f2.ReadAt(buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, data, buf)
+
+	assert.NoError(t, f1.LazyIO(false))
+	assert.NoError(t, f2.LazyIO(false))
+
+	assert.Error(t, f1.LazyIOPropagate(-1, 1))
+	assert.Error(t, f1.LazyIOSynchronize(-1, 1))
+
+	f := &File{}
+	assert.ErrorIs(t, f.LazyIO(true), ErrNotConnected)
+}
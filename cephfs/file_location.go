@@ -0,0 +1,188 @@
+//go:build ceph_preview
+
+package cephfs
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <stdlib.h>
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import (
+	"bytes"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/retry"
+)
+
+// ExtentOSDs returns the IDs of the OSDs storing the file extent containing
+// the given offset, as well as the number of bytes from offset to the end of
+// that extent. The first OSD returned is the primary OSD of the extent.
+//
+// Implements:
+//
+//	int ceph_get_file_extent_osds(struct ceph_mount_info *cmount, int fh,
+//	                              int64_t offset, int64_t *length, int *osds, int nosds);
+func (f *File) ExtentOSDs(offset int64) ([]int, int64, error) {
+	if err := f.validate(); err != nil {
+		return nil, 0, err
+	}
+	if offset < 0 {
+		return nil, 0, errInvalid
+	}
+
+	var (
+		ret    C.int
+		err    error
+		length C.int64_t
+		osds   []C.int
+	)
+	retry.WithSizes(8, 1024, func(size int) retry.Hint {
+		osds = make([]C.int, size)
+		ret = C.ceph_get_file_extent_osds(
+			f.mount.mount,
+			f.fd,
+			C.int64_t(offset),
+			&length,
+			&osds[0],
+			C.int(size))
+		err = getErrorIfNegative(ret)
+		return retry.DoubleSize.If(err == errRange)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	result := make([]int, ret)
+	for i := range result {
+		result[i] = int(osds[i])
+	}
+	return result, int64(length), nil
+}
+
+// StripeUnit returns the stripe unit, in bytes, of the file.
+//
+// Implements:
+//
+//	int ceph_get_file_stripe_unit(struct ceph_mount_info *cmount, int fh);
+func (f *File) StripeUnit() (int64, error) {
+	if err := f.validate(); err != nil {
+		return 0, err
+	}
+	ret := C.ceph_get_file_stripe_unit(f.mount.mount, f.fd)
+	if err := getErrorIfNegative(ret); err != nil {
+		return 0, err
+	}
+	return int64(ret), nil
+}
+
+// PoolName returns the name of the data pool the file is stored in.
+//
+// Implements:
+//
+//	int ceph_get_file_pool_name(struct ceph_mount_info *cmount, int fh, char *buf,
+//	                            size_t buflen);
+func (f *File) PoolName() (string, error) {
+	if err := f.validate(); err != nil {
+		return "", err
+	}
+
+	var (
+		ret C.int
+		err error
+		buf []byte
+	)
+	// range from 256 bytes to 64KiB
+	retry.WithSizes(256, 1<<16, func(size int) retry.Hint {
+		buf = make([]byte, size)
+		ret = C.ceph_get_file_pool_name(
+			f.mount.mount,
+			f.fd,
+			(*C.char)(unsafe.Pointer(&buf[0])),
+			C.size_t(size))
+		err = getErrorIfNegative(ret)
+		return retry.DoubleSize.If(err == errRange)
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimRight(buf[:ret], "\x00")), nil
+}
+
+// Replication returns the number of replicas of the file's data, as
+// configured for the data pool the file is stored in.
+//
+// Implements:
+//
+//	int ceph_get_file_replication(struct ceph_mount_info *cmount, int fh);
+func (f *File) Replication() (int, error) {
+	if err := f.validate(); err != nil {
+		return 0, err
+	}
+	ret := C.ceph_get_file_replication(f.mount.mount, f.fd)
+	if err := getErrorIfNegative(ret); err != nil {
+		return 0, err
+	}
+	return int(ret), nil
+}
+
+// CrushLocation is an element of the location of an OSD in the CRUSH
+// hierarchy, for example the host or rack the OSD is placed in.
+type CrushLocation struct {
+	// Type of the CRUSH bucket, for example "host".
+	Type string
+	// Name of the CRUSH bucket.
+	Name string
+}
+
+// GetOSDCrushLocation returns the location of the OSD with the given ID in
+// the CRUSH hierarchy, starting with the bucket the OSD is placed in.
+//
+// Implements:
+//
+//	int ceph_get_osd_crush_location(struct ceph_mount_info *cmount, int osd,
+//	                                char *path, size_t len);
+func (mount *MountInfo) GetOSDCrushLocation(osd int) ([]CrushLocation, error) {
+	if err := mount.validate(); err != nil {
+		return nil, err
+	}
+	if osd < 0 {
+		return nil, errInvalid
+	}
+
+	var (
+		ret C.int
+		err error
+		buf []byte
+	)
+	// range from 1k to 64KiB
+	retry.WithSizes(1024, 1<<16, func(size int) retry.Hint {
+		buf = make([]byte, size)
+		ret = C.ceph_get_osd_crush_location(
+			mount.mount,
+			C.int(osd),
+			(*C.char)(unsafe.Pointer(&buf[0])),
+			C.size_t(size))
+		err = getErrorIfNegative(ret)
+		return retry.DoubleSize.If(err == errRange)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseCrushLocation(buf[:ret]), nil
+}
+
+// parseCrushLocation parses the crush location returned by libcephfs, a
+// sequence of NUL terminated bucket type and name pairs.
+func parseCrushLocation(b []byte) []CrushLocation {
+	parts := bytes.Split(bytes.TrimRight(b, "\x00"), []byte{0})
+	loc := make([]CrushLocation, 0, len(parts)/2)
+	for i := 0; i+1 < len(parts); i += 2 {
+		loc = append(loc, CrushLocation{
+			Type: string(parts[i]),
+			Name: string(parts[i+1]),
+		})
+	}
+	return loc
+}
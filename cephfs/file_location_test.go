@@ -0,0 +1,86 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLocation(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	fname := "test_file_location.txt"
+	f, err := mount.Open(fname, os.O_RDWR|os.O_CREATE, 0644)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, f.Close())
+		assert.NoError(t, mount.Unlink(fname))
+	}()
+	_, err = f.Write([]byte("locality"))
+	require.NoError(t, err)
+	require.NoError(t, f.Sync())
+
+	t.Run("stripeUnit", func(t *testing.T) {
+		su, err := f.StripeUnit()
+		assert.NoError(t, err)
+		assert.Greater(t, su, int64(0))
+	})
+
+	t.Run("poolName", func(t *testing.T) {
+		name, err := f.PoolName()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, name)
+		assert.NotContains(t, name, "\x00")
+	})
+
+	t.Run("replication", func(t *testing.T) {
+		r, err := f.Replication()
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, r, 1)
+	})
+
+	t.Run("extentOSDs", func(t *testing.T) {
+		osds, length, err := f.ExtentOSDs(0)
+		require.NoError(t, err)
+		assert.NotEmpty(t, osds)
+		assert.Greater(t, length, int64(0))
+
+		loc, err := mount.GetOSDCrushLocation(osds[0])
+		assert.NoError(t, err)
+		for _, l := range loc {
+			assert.NotEmpty(t, l.Type)
+			assert.NotEmpty(t, l.Name)
+		}
+
+		_, _, err = f.ExtentOSDs(-1)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		f := &File{}
+		_, _, err := f.ExtentOSDs(0)
+		assert.Error(t, err)
+		_, err = f.PoolName()
+		assert.Error(t, err)
+		_, err = f.Replication()
+		assert.Error(t, err)
+		_, err = f.StripeUnit()
+		assert.Error(t, err)
+		_, err = mount.GetOSDCrushLocation(-1)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseCrushLocation(t *testing.T) {
+	loc := parseCrushLocation([]byte("host\x00node1\x00root\x00default\x00"))
+	assert.Equal(t, []CrushLocation{
+		{Type: "host", Name: "node1"},
+		{Type: "root", Name: "default"},
+	}, loc)
+	assert.Empty(t, parseCrushLocation(nil))
+}
@@ -0,0 +1,17 @@
+//go:build ceph_preview
+
+package cephfs
+
+// PunchHole deallocates the space of the given byte range of the file,
+// without changing the size of the file. Reading the range afterwards
+// returns zeros.
+//
+// PunchHole is a convenience wrapper around Fallocate, that passes the
+// FallocFlPunchHole flag together with the FallocFlKeepSize flag it
+// requires.
+func (f *File) PunchHole(offset, length int64) error {
+	if offset < 0 || length <= 0 {
+		return errInvalid
+	}
+	return f.Fallocate(FallocFlPunchHole|FallocFlKeepSize, offset, length)
+}
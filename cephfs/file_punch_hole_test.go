@@ -0,0 +1,46 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPunchHole(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	fname := "punch-hole.txt"
+	f, err := mount.Open(fname, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, f.Close())
+		assert.NoError(t, mount.Unlink(fname))
+	}()
+
+	data := bytes.Repeat([]byte("x"), 8192)
+	_, err = f.WriteAt(data, 0)
+	require.NoError(t, err)
+
+	assert.NoError(t, f.PunchHole(1024, 2048))
+
+	st, err := f.Fstatx(StatxSize, 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, len(data), st.Size)
+
+	buf := make([]byte, len(data))
+	n, err := f.ReadAt(buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, data[:1024], buf[:1024])
+	assert.Equal(t, make([]byte, 2048), buf[1024:3072])
+	assert.Equal(t, data[3072:], buf[3072:])
+
+	assert.Error(t, f.PunchHole(-1, 10))
+	assert.Error(t, f.PunchHole(0, 0))
+}
@@ -268,6 +268,30 @@ func TestFileSeek(t *testing.T) {
 		assert.EqualValues(t, 8, n)
 	})
 
+	t.Run("dataAndHole", func(t *testing.T) {
+		f1, err := mount.Open(fname, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, f1.Close()) }()
+		defer func() { assert.NoError(t, mount.Unlink(fname)) }()
+
+		n, err := f1.Write([]byte("flimflam"))
+		assert.NoError(t, err)
+		assert.EqualValues(t, 8, n)
+
+		o, err := f1.Seek(2, SeekData)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, o)
+
+		// the end of the file is treated as a hole
+		o, err = f1.Seek(0, SeekHole)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 8, o)
+
+		// there is no data past the end of the file
+		_, err = f1.Seek(100, SeekData)
+		assert.Error(t, err)
+	})
+
 	t.Run("invalidWhence", func(t *testing.T) {
 		f1, err := mount.Open(fname, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 		assert.NoError(t, err)
@@ -898,6 +922,19 @@ func TestFilePreadvPwritev(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("emptyVector", func(t *testing.T) {
+		f, err := mount.Open(fname, os.O_RDWR|os.O_CREATE, 0644)
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, f.Close()) }()
+
+		n, err := f.Pwritev(nil, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+		n, err = f.Preadv([][]byte{}, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
 	t.Run("openForReadOnly", func(t *testing.T) {
 		// "touch" the file
 		f1, err := mount.Open(fname, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
@@ -0,0 +1,98 @@
+//go:build ceph_preview
+
+package cephfs
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#define _GNU_SOURCE
+#include <stdlib.h>
+#include <fcntl.h>
+#include <cephfs/libcephfs.h>
+
+static inline int go_ceph_lookup_inode(struct ceph_mount_info *cmount,
+	uint64_t ino, struct Inode **inode) {
+	struct inodeno_t i;
+	i.val = ino;
+	return ceph_ll_lookup_inode(cmount, i, inode);
+}
+*/
+import "C"
+
+import (
+	"path"
+	"unsafe"
+)
+
+// OpenTemp creates an unnamed temporary file in the directory dir, like
+// open(2) does when passed O_TMPFILE. The flags must include either
+// os.O_WRONLY or os.O_RDWR. Mode is the permission bits of the new file.
+//
+// The file has no name and is removed when closed, unless it is given a name
+// using Link first. Together with Fsync this allows publishing a file
+// atomically: the complete content is written to the temporary file before
+// it appears in the file system.
+//
+// If flags contains os.O_EXCL the file can not be linked into the file
+// system. Older versions of libcephfs do not support unnamed temporary files
+// and fail with an error.
+//
+// Implements:
+//
+//	int ceph_open(struct ceph_mount_info *cmount, const char *path, int flags, mode_t mode);
+func (mount *MountInfo) OpenTemp(dir string, flags int, mode uint32) (*File, error) {
+	return mount.Open(dir, flags|int(C.O_TMPFILE), mode)
+}
+
+// Link gives the open file the additional name newname, similar to
+// linkat(2) with AT_EMPTY_PATH. Link is typically used to give a file
+// created by OpenTemp a name.
+//
+// Implements:
+//
+//	int ceph_ll_lookup_inode(struct ceph_mount_info *cmount, struct inodeno_t ino,
+//	                         Inode **inode);
+//	int ceph_ll_walk(struct ceph_mount_info *cmount, const char* name, Inode **i,
+//	                 struct ceph_statx *stx, unsigned int want, unsigned int flags,
+//	                 const UserPerm *perms);
+//	int ceph_ll_link(struct ceph_mount_info *cmount, struct Inode *in,
+//	                 struct Inode *newparent, const char *name, const UserPerm *perms);
+func (f *File) Link(newname string) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	if newname == "" {
+		return errInvalid
+	}
+	stx, err := f.Fstatx(StatxIno, 0)
+	if err != nil {
+		return err
+	}
+	cmount := f.mount.mount
+
+	var inode *C.struct_Inode
+	ret := C.go_ceph_lookup_inode(cmount, C.uint64_t(stx.Inode), &inode)
+	if ret != 0 {
+		return getError(ret)
+	}
+	defer C.ceph_ll_put(cmount, inode)
+
+	cDir := C.CString(path.Dir(newname))
+	defer C.free(unsafe.Pointer(cDir))
+	cName := C.CString(path.Base(newname))
+	defer C.free(unsafe.Pointer(cName))
+	perms := C.ceph_mount_perms(cmount)
+
+	var (
+		parent *C.struct_Inode
+		pstx   C.struct_ceph_statx
+	)
+	ret = C.ceph_ll_walk(cmount, cDir, &parent, &pstx, 0, 0, perms)
+	if ret != 0 {
+		return getError(ret)
+	}
+	defer C.ceph_ll_put(cmount, parent)
+
+	ret = C.ceph_ll_link(cmount, inode, parent, cName, perms)
+	return getError(ret)
+}
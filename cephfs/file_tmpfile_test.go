@@ -0,0 +1,129 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenTemp(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	dname := "tmpfile-dir"
+	require.NoError(t, mount.MakeDir(dname, 0755))
+	defer func() { assert.NoError(t, mount.RemoveDir(dname)) }()
+
+	t.Run("linkIntoPlace", func(t *testing.T) {
+		f, err := mount.OpenTemp(dname, os.O_RDWR, 0644)
+		if err != nil {
+			t.Skipf("unnamed temporary files not supported: %v", err)
+		}
+		defer func() { assert.NoError(t, f.Close()) }()
+
+		data := []byte("published atomically")
+		_, err = f.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, f.Fsync(SyncAll))
+
+		// the temporary file has no name
+		entries, err := listDir(mount, dname)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+
+		fname := dname + "/published.txt"
+		require.NoError(t, f.Link(fname))
+		defer func() { assert.NoError(t, mount.Unlink(fname)) }()
+
+		f2, err := mount.Open(fname, os.O_RDONLY, 0)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, f2.Close()) }()
+		buf, err := io.ReadAll(f2)
+		assert.NoError(t, err)
+		assert.Equal(t, data, buf)
+	})
+
+	t.Run("closeWithoutLink", func(t *testing.T) {
+		f, err := mount.OpenTemp(dname, os.O_WRONLY, 0644)
+		if err != nil {
+			t.Skipf("unnamed temporary files not supported: %v", err)
+		}
+		_, err = f.Write([]byte("discarded"))
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+
+		entries, err := listDir(mount, dname)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("emptyName", func(t *testing.T) {
+		f, err := mount.Open(dname+"/named.txt", os.O_WRONLY|os.O_CREATE, 0644)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, mount.Unlink(dname+"/named.txt")) }()
+		defer func() { assert.NoError(t, f.Close()) }()
+		assert.Error(t, f.Link(""))
+	})
+
+	t.Run("invalidFile", func(t *testing.T) {
+		f := &File{}
+		assert.Error(t, f.Link("foo"))
+	})
+}
+
+func TestOpenAppend(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	fname := "append-test.txt"
+	writeFile(t, mount, fname, []byte("0123"))
+	defer func() { assert.NoError(t, mount.Unlink(fname)) }()
+
+	f1, err := mount.Open(fname, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, f1.Close()) }()
+	f2, err := mount.Open(fname, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, f2.Close()) }()
+
+	// moving the file position does not affect where data is appended
+	_, err = f1.Seek(0, SeekSet)
+	require.NoError(t, err)
+	_, err = f1.Write([]byte("abc"))
+	assert.NoError(t, err)
+	_, err = f2.Write([]byte("xyz"))
+	assert.NoError(t, err)
+	_, err = f1.Write([]byte("!"))
+	assert.NoError(t, err)
+
+	f3, err := mount.Open(fname, os.O_RDONLY, 0)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, f3.Close()) }()
+	buf, err := io.ReadAll(f3)
+	assert.NoError(t, err)
+	assert.Equal(t, "0123abcxyz!", string(buf))
+}
+
+func listDir(mount *MountInfo, dname string) ([]string, error) {
+	dir, err := mount.OpenDir(dname)
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+	entries, err := dir.list()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, name := range entries.names() {
+		if name != "." && name != ".." {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,18 @@
+//go:build ceph_preview
+
+package cephfs
+
+// Readv reads data from the current position of the file into the
+// byte-slice data buffers sequentially and advances the position by the
+// number of bytes read. The number of bytes read is returned. When nothing
+// is left to read from the file Readv returns 0, io.EOF.
+func (f *File) Readv(data [][]byte) (int, error) {
+	return f.Preadv(data, -1)
+}
+
+// Writev writes the data of the byte-slice buffers sequentially to the
+// current position of the file and advances the position by the number of
+// bytes written. The number of bytes written is returned.
+func (f *File) Writev(data [][]byte) (int, error) {
+	return f.Pwritev(data, -1)
+}
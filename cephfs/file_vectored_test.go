@@ -0,0 +1,54 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileReadvWritev(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	fname := "TestFileReadvWritev.txt"
+	defer mount.Unlink(fname)
+
+	f, err := mount.Open(fname, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, f.Close()) }()
+
+	n, err := f.Writev([][]byte{[]byte("foo"), []byte("bar")})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, n)
+	n, err = f.Writev([][]byte{[]byte("baz")})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	pos, err := f.Seek(0, SeekCur)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 9, pos)
+
+	_, err = f.Seek(0, SeekSet)
+	require.NoError(t, err)
+	x := [][]byte{make([]byte, 2), make([]byte, 2)}
+	n, err = f.Readv(x)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, "fo", string(x[0]))
+	assert.Equal(t, "ob", string(x[1]))
+
+	y := [][]byte{make([]byte, 8)}
+	n, err = f.Readv(y)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, "arbaz", string(y[0][:n]))
+
+	n, err = f.Readv(y)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 0, n)
+}
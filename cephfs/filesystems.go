@@ -0,0 +1,110 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ceph/go-ceph/rados"
+)
+
+var fsDumpCmd = []byte(`{"prefix":"fs dump","format":"json"}`)
+
+// FileSystemInfo describes a file system of the Ceph cluster.
+type FileSystemInfo struct {
+	// Name of the file system. The name can be passed to SelectFilesystem.
+	Name string
+	// ID is the file system cluster ID (FSCID).
+	ID int64
+	// MetadataPool is the name of the metadata pool of the file system.
+	MetadataPool string
+	// MetadataPoolID is the ID of the metadata pool of the file system.
+	MetadataPoolID int64
+	// DataPools are the names of the data pools of the file system.
+	DataPools []string
+	// DataPoolIDs are the IDs of the data pools of the file system.
+	DataPoolIDs []int64
+}
+
+type fsDumpFileSystem struct {
+	ID     int64 `json:"id"`
+	MDSMap struct {
+		FSName       string  `json:"fs_name"`
+		MetadataPool int64   `json:"metadata_pool"`
+		DataPools    []int64 `json:"data_pools"`
+	} `json:"mdsmap"`
+}
+
+type fsDump struct {
+	FileSystems []fsDumpFileSystem `json:"filesystems"`
+}
+
+func parseFSDump(buf []byte) ([]FileSystemInfo, error) {
+	var dump fsDump
+	if err := json.Unmarshal(buf, &dump); err != nil {
+		return nil, err
+	}
+	fss := make([]FileSystemInfo, len(dump.FileSystems))
+	for i, fs := range dump.FileSystems {
+		fss[i] = FileSystemInfo{
+			Name:           fs.MDSMap.FSName,
+			ID:             fs.ID,
+			MetadataPoolID: fs.MDSMap.MetadataPool,
+			DataPoolIDs:    fs.MDSMap.DataPools,
+		}
+	}
+	return fss, nil
+}
+
+// ListFileSystems returns the file systems of the Ceph cluster conn is
+// connected to, including the pools used by each file system.
+//
+// Similar To:
+//
+//	ceph fs dump
+func ListFileSystems(conn *rados.Conn) ([]FileSystemInfo, error) {
+	if conn == nil {
+		return nil, ErrNotConnected
+	}
+	buf, _, err := conn.MonCommand(fsDumpCmd)
+	if err != nil {
+		return nil, err
+	}
+	fss, err := parseFSDump(buf)
+	if err != nil {
+		return nil, err
+	}
+	for i := range fss {
+		fs := &fss[i]
+		fs.MetadataPool, err = conn.GetPoolByID(fs.MetadataPoolID)
+		if err != nil {
+			return nil, err
+		}
+		fs.DataPools = make([]string, len(fs.DataPoolIDs))
+		for j, id := range fs.DataPoolIDs {
+			fs.DataPools[j], err = conn.GetPoolByID(id)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fss, nil
+}
+
+// SelectFilesystemByID selects the file system with the given file system
+// cluster ID (FSCID) to be mounted. The ID is resolved to the name of the
+// file system using conn, see SelectFilesystem for details. ErrNotExist is
+// returned if the cluster has no file system with the given ID.
+func (mount *MountInfo) SelectFilesystemByID(conn *rados.Conn, fscid int64) error {
+	fss, err := ListFileSystems(conn)
+	if err != nil {
+		return err
+	}
+	for _, fs := range fss {
+		if fs.ID == fscid {
+			return mount.SelectFilesystem(fs.Name)
+		}
+	}
+	return fmt.Errorf("%w: no file system with id %d", ErrNotExist, fscid)
+}
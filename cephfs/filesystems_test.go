@@ -0,0 +1,82 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFSDump(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		buf := []byte(`{"epoch":12,"filesystems":[
+			{"id":1,"mdsmap":{"fs_name":"cephfs","metadata_pool":2,"data_pools":[3]}},
+			{"id":4,"mdsmap":{"fs_name":"altfs","metadata_pool":5,"data_pools":[6,7]}}
+		]}`)
+		fss, err := parseFSDump(buf)
+		assert.NoError(t, err)
+		if assert.Len(t, fss, 2) {
+			assert.Equal(t, "cephfs", fss[0].Name)
+			assert.EqualValues(t, 1, fss[0].ID)
+			assert.EqualValues(t, 2, fss[0].MetadataPoolID)
+			assert.Equal(t, []int64{3}, fss[0].DataPoolIDs)
+			assert.Equal(t, "altfs", fss[1].Name)
+			assert.EqualValues(t, 4, fss[1].ID)
+			assert.Equal(t, []int64{6, 7}, fss[1].DataPoolIDs)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		fss, err := parseFSDump([]byte(`{"epoch":1,"filesystems":[]}`))
+		assert.NoError(t, err)
+		assert.Len(t, fss, 0)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := parseFSDump([]byte(`dumped fsmap epoch 1`))
+		assert.Error(t, err)
+	})
+}
+
+func TestListFileSystems(t *testing.T) {
+	t.Run("notConnected", func(t *testing.T) {
+		_, err := ListFileSystems(nil)
+		assert.ErrorIs(t, err, ErrNotConnected)
+	})
+
+	conn := radosConnect(t)
+	defer conn.Shutdown()
+
+	fss, err := ListFileSystems(conn)
+	require.NoError(t, err)
+	var found *FileSystemInfo
+	for i := range fss {
+		// first fs is always called cephfs for go-ceph tests
+		if fss[i].Name == "cephfs" {
+			found = &fss[i]
+		}
+	}
+	require.NotNil(t, found)
+	assert.NotEmpty(t, found.MetadataPool)
+	assert.NotEmpty(t, found.DataPools)
+	assert.Len(t, found.DataPools, len(found.DataPoolIDs))
+
+	t.Run("selectByID", func(t *testing.T) {
+		mount, err := CreateFromRados(conn)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, mount.Release()) }()
+
+		assert.NoError(t, mount.SelectFilesystemByID(conn, found.ID))
+		require.NoError(t, mount.Mount())
+		assert.NoError(t, mount.Unmount())
+	})
+
+	t.Run("unknownID", func(t *testing.T) {
+		mount, err := CreateFromRados(conn)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, mount.Release()) }()
+
+		err = mount.SelectFilesystemByID(conn, -1)
+		assert.ErrorIs(t, err, ErrNotExist)
+	})
+}
@@ -21,6 +21,7 @@ var (
 // io.FS compatible type.
 type MountWrapper struct {
 	mount       *MountInfo
+	root        string
 	enableTrace bool
 }
 
@@ -80,6 +81,14 @@ func (mw *MountWrapper) trace() bool {
 	return mw.enableTrace
 }
 
+// cephPath returns the path within the CephFS mount for the given io.FS name.
+func (mw *MountWrapper) cephPath(name string) string {
+	if mw.root == "" {
+		return name
+	}
+	return path.Join(mw.root, name)
+}
+
 // Open opens the named file. This may be either a regular file or a directory.
 // Directories opened with this function will return object compatible with the
 // io.ReadDirFile interface.
@@ -97,7 +106,7 @@ func (mw *MountWrapper) Open(name string) (fs.File, error) {
 		return nil, &fs.PathError{Op: "open", Path: name, Err: errInvalid}
 	}
 
-	d, err := mw.mount.OpenDir(name)
+	d, err := mw.mount.OpenDir(mw.cephPath(name))
 	if err == nil {
 		debugf(mw, "Open", "(%v): dir ok", name)
 		dw := &dirWrapper{parent: mw, directory: d, name: name}
@@ -108,7 +117,7 @@ func (mw *MountWrapper) Open(name string) (fs.File, error) {
 		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
 	}
 
-	f, err := mw.mount.Open(name, os.O_RDONLY, 0)
+	f, err := mw.mount.Open(mw.cephPath(name), os.O_RDONLY, 0)
 	if err == nil {
 		debugf(mw, "Open", "(%v): file ok", name)
 		fw := &fileWrapper{parent: mw, file: f, name: name}
@@ -159,7 +168,8 @@ func (fw *fileWrapper) trace() bool {
 
 func (dw *dirWrapper) Stat() (fs.FileInfo, error) {
 	debugf(dw, "Stat", "()")
-	sx, err := dw.parent.mount.Statx(dw.name, StatxBasicStats, AtSymlinkNofollow)
+	sx, err := dw.parent.mount.Statx(
+		dw.parent.cephPath(dw.name), StatxBasicStats, AtSymlinkNofollow)
 	if err != nil {
 		debugf(dw, "Stat", "() -> err:%v", err)
 		return nil, &fs.PathError{Op: "stat", Path: dw.name, Err: err}
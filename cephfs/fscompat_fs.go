@@ -0,0 +1,63 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+)
+
+// WrapAt wraps a CephFS Mount object into a new type that is compatible with
+// Go's io.FS interface, like Wrap, but with all names resolved relative to
+// the given root directory of the mount.
+func WrapAt(mount *MountInfo, root string) *MountWrapper {
+	wm := &MountWrapper{mount: mount, root: root}
+	debugf(wm, "WrapAt", "created for %v", root)
+	return wm
+}
+
+/* MountWrapper:
+** Implements https://pkg.go.dev/io/fs#StatFS
+** Implements https://pkg.go.dev/io/fs#ReadDirFS
+ */
+
+// Stat returns a FileInfo describing the named file.
+func (mw *MountWrapper) Stat(name string) (fs.FileInfo, error) {
+	debugf(mw, "Stat", "(%v)", name)
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	sx, err := mw.mount.Statx(mw.cephPath(name), StatxBasicStats, 0)
+	if err != nil {
+		debugf(mw, "Stat", "(%v) -> err:%v", name, err)
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	debugf(mw, "Stat", "(%v) ok", name)
+	return &infoWrapper{mw, sx, path.Base(name)}, nil
+}
+
+// ReadDir reads the named directory and returns a list of directory entries
+// sorted by filename.
+func (mw *MountWrapper) ReadDir(name string) ([]fs.DirEntry, error) {
+	debugf(mw, "ReadDir", "(%v)", name)
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	d, err := mw.mount.OpenDir(mw.cephPath(name))
+	if err != nil {
+		debugf(mw, "ReadDir", "(%v) -> err:%v", name, err)
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	dw := &dirWrapper{parent: mw, directory: d, name: name}
+	defer dw.Close()
+
+	entries, err := dw.readDirAll()
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	if err != nil {
+		return entries, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	return entries, nil
+}
@@ -0,0 +1,87 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFSCompatWrapAt(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	err := mount.MakeDir("fsat_root", 0755)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, mount.RemoveDir("fsat_root")) }()
+	err = mount.MakeDir("fsat_root/sub", 0755)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, mount.RemoveDir("fsat_root/sub")) }()
+
+	writeFile(t, mount, "fsat_root/b.txt", []byte("bbb"))
+	defer func() { assert.NoError(t, mount.Unlink("fsat_root/b.txt")) }()
+	writeFile(t, mount, "fsat_root/a.txt", []byte("a"))
+	defer func() { assert.NoError(t, mount.Unlink("fsat_root/a.txt")) }()
+	writeFile(t, mount, "fsat_root/sub/c.txt", []byte("cc"))
+	defer func() { assert.NoError(t, mount.Unlink("fsat_root/sub/c.txt")) }()
+
+	w := WrapAt(mount, "/fsat_root")
+	var (
+		_ fs.StatFS    = w
+		_ fs.ReadDirFS = w
+	)
+
+	t.Run("testFS", func(t *testing.T) {
+		if err := fstest.TestFS(w, "a.txt", "b.txt", "sub/c.txt"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("stat", func(t *testing.T) {
+		fi, err := w.Stat("b.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "b.txt", fi.Name())
+		assert.EqualValues(t, 3, fi.Size())
+		assert.False(t, fi.IsDir())
+
+		fi, err = fs.Stat(w, "sub")
+		require.NoError(t, err)
+		assert.Equal(t, "sub", fi.Name())
+		assert.True(t, fi.IsDir())
+
+		fi, err = w.Stat(".")
+		require.NoError(t, err)
+		assert.True(t, fi.IsDir())
+
+		_, err = w.Stat("missing.txt")
+		assert.True(t, errors.Is(err, ErrNotExist))
+		_, err = w.Stat("/b.txt")
+		assert.True(t, errors.Is(err, fs.ErrInvalid))
+	})
+
+	t.Run("readDir", func(t *testing.T) {
+		entries, err := fs.ReadDir(w, ".")
+		require.NoError(t, err)
+		names := []string{}
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		assert.Equal(t, []string{"a.txt", "b.txt", "sub"}, names)
+
+		_, err = w.ReadDir("missing")
+		assert.True(t, errors.Is(err, ErrNotExist))
+		_, err = w.ReadDir("../")
+		assert.True(t, errors.Is(err, fs.ErrInvalid))
+	})
+
+	t.Run("readFile", func(t *testing.T) {
+		data, err := fs.ReadFile(w, "sub/c.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, "cc", string(data))
+	})
+}
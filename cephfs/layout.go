@@ -0,0 +1,157 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	fileLayoutXattr = "ceph.file.layout"
+	dirLayoutXattr  = "ceph.dir.layout"
+
+	// minStripeUnit is the granularity the stripe unit must be a multiple
+	// of.
+	minStripeUnit = 64 * 1024
+)
+
+// ErrInvalidLayout is returned if a Layout fails validation.
+var ErrInvalidLayout = errors.New("invalid layout")
+
+// Layout describes how the data of a file is striped over RADOS objects.
+// When setting a layout, fields with a zero value are left unchanged.
+type Layout struct {
+	// StripeUnit is the size, in bytes, of the blocks data is striped in.
+	// It must be a multiple of 64KiB.
+	StripeUnit uint64
+	// StripeCount is the number of objects a stripe is distributed over.
+	StripeCount uint64
+	// ObjectSize is the maximum size, in bytes, of the objects. It must be
+	// a multiple of the stripe unit.
+	ObjectSize uint64
+	// Pool is the name or ID of the data pool the objects are stored in.
+	Pool string
+	// PoolNamespace is the RADOS namespace within the data pool.
+	PoolNamespace string
+}
+
+// Validate checks that the non-zero values of the layout are consistent.
+func (l *Layout) Validate() error {
+	if l.StripeUnit%minStripeUnit != 0 {
+		return fmt.Errorf("%w: stripe unit %d is not a multiple of %d",
+			ErrInvalidLayout, l.StripeUnit, minStripeUnit)
+	}
+	if l.StripeUnit != 0 && l.ObjectSize%l.StripeUnit != 0 {
+		return fmt.Errorf("%w: object size %d is not a multiple of stripe unit %d",
+			ErrInvalidLayout, l.ObjectSize, l.StripeUnit)
+	}
+	if strings.ContainsAny(l.Pool, " =") ||
+		strings.ContainsAny(l.PoolNamespace, " =") {
+		return fmt.Errorf("%w: invalid pool or pool namespace", ErrInvalidLayout)
+	}
+	return nil
+}
+
+// String returns the layout in the format of the layout vxattrs.
+func (l *Layout) String() string {
+	var parts []string
+	add := func(k string, v uint64) {
+		if v != 0 {
+			parts = append(parts, k+"="+strconv.FormatUint(v, 10))
+		}
+	}
+	add("stripe_unit", l.StripeUnit)
+	add("stripe_count", l.StripeCount)
+	add("object_size", l.ObjectSize)
+	if l.Pool != "" {
+		parts = append(parts, "pool="+l.Pool)
+	}
+	if l.PoolNamespace != "" {
+		parts = append(parts, "pool_namespace="+l.PoolNamespace)
+	}
+	return strings.Join(parts, " ")
+}
+
+// GetFileLayout returns the layout of the file at path.
+func (mount *MountInfo) GetFileLayout(path string) (*Layout, error) {
+	value, err := mount.GetXattr(path, fileLayoutXattr)
+	if err != nil {
+		return nil, err
+	}
+	return parseLayout(string(value))
+}
+
+// SetFileLayout sets the layout of the file at path. The layout of a file
+// can only be changed while the file is empty.
+func (mount *MountInfo) SetFileLayout(path string, layout Layout) error {
+	return mount.setLayout(path, fileLayoutXattr, layout)
+}
+
+// GetDirLayout returns the layout of the directory at path, which new files
+// created below the directory inherit. If the directory has no layout set a
+// nil Layout is returned.
+func (mount *MountInfo) GetDirLayout(path string) (*Layout, error) {
+	value, err := mount.GetXattr(path, dirLayoutXattr)
+	if errors.Is(err, errNoData) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseLayout(string(value))
+}
+
+// SetDirLayout sets the layout of the directory at path, which new files
+// created below the directory inherit.
+func (mount *MountInfo) SetDirLayout(path string, layout Layout) error {
+	return mount.setLayout(path, dirLayoutXattr, layout)
+}
+
+// ClearDirLayout removes the layout of the directory at path, so that new
+// files inherit the layout of the parent directories again.
+func (mount *MountInfo) ClearDirLayout(path string) error {
+	return mount.RemoveXattr(path, dirLayoutXattr)
+}
+
+func (mount *MountInfo) setLayout(path, name string, layout Layout) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+	value := layout.String()
+	if value == "" {
+		return fmt.Errorf("%w: empty layout", ErrInvalidLayout)
+	}
+	return mount.SetXattr(path, name, []byte(value), XattrDefault)
+}
+
+// parseLayout parses the value of a layout vxattr, for example:
+// "stripe_unit=4194304 stripe_count=1 object_size=4194304 pool=cephfs.data"
+func parseLayout(s string) (*Layout, error) {
+	l := &Layout{}
+	for _, field := range strings.Fields(strings.TrimRight(s, "\x00")) {
+		k, v, found := strings.Cut(field, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid layout field: %q", field)
+		}
+		var err error
+		switch k {
+		case "stripe_unit":
+			l.StripeUnit, err = strconv.ParseUint(v, 10, 64)
+		case "stripe_count":
+			l.StripeCount, err = strconv.ParseUint(v, 10, 64)
+		case "object_size":
+			l.ObjectSize, err = strconv.ParseUint(v, 10, 64)
+		case "pool":
+			l.Pool = v
+		case "pool_namespace":
+			l.PoolNamespace = v
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid layout field: %q: %w", field, err)
+		}
+	}
+	return l, nil
+}
@@ -0,0 +1,102 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLayout(t *testing.T) {
+	l, err := parseLayout(
+		"stripe_unit=4194304 stripe_count=1 object_size=4194304 pool=cephfs.data\x00")
+	assert.NoError(t, err)
+	assert.Equal(t, Layout{
+		StripeUnit:  4194304,
+		StripeCount: 1,
+		ObjectSize:  4194304,
+		Pool:        "cephfs.data",
+	}, *l)
+
+	l, err = parseLayout(
+		"stripe_unit=65536 stripe_count=4 object_size=262144 pool=3 pool_namespace=ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1", l.PoolNamespace)
+	assert.Equal(t, "3", l.Pool)
+
+	_, err = parseLayout("stripe_unit")
+	assert.Error(t, err)
+	_, err = parseLayout("stripe_count=many")
+	assert.Error(t, err)
+}
+
+func TestLayoutValidateAndString(t *testing.T) {
+	l := Layout{StripeUnit: 65536, StripeCount: 2, ObjectSize: 131072, Pool: "data"}
+	assert.NoError(t, l.Validate())
+	assert.Equal(t,
+		"stripe_unit=65536 stripe_count=2 object_size=131072 pool=data",
+		l.String())
+
+	l = Layout{StripeCount: 4}
+	assert.NoError(t, l.Validate())
+	assert.Equal(t, "stripe_count=4", l.String())
+
+	l = Layout{StripeUnit: 1000}
+	assert.ErrorIs(t, l.Validate(), ErrInvalidLayout)
+	l = Layout{StripeUnit: 65536, ObjectSize: 100000}
+	assert.ErrorIs(t, l.Validate(), ErrInvalidLayout)
+	l = Layout{Pool: "a pool"}
+	assert.ErrorIs(t, l.Validate(), ErrInvalidLayout)
+}
+
+func TestLayouts(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	dir := "/layout-test"
+	require.NoError(t, mount.MakeDir(dir, 0755))
+	defer func() { assert.NoError(t, mount.RemoveDir(dir)) }()
+
+	l, err := mount.GetDirLayout(dir)
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+
+	dl := Layout{StripeUnit: 65536, StripeCount: 2, ObjectSize: 1048576}
+	require.NoError(t, mount.SetDirLayout(dir, dl))
+	l, err = mount.GetDirLayout(dir)
+	require.NoError(t, err)
+	require.NotNil(t, l)
+	assert.Equal(t, dl.StripeUnit, l.StripeUnit)
+	assert.Equal(t, dl.StripeCount, l.StripeCount)
+	assert.Equal(t, dl.ObjectSize, l.ObjectSize)
+	assert.NotEqual(t, "", l.Pool)
+
+	// new files inherit the directory layout
+	fname := dir + "/file1"
+	f, err := mount.Open(fname, os.O_RDWR|os.O_CREATE, 0644)
+	require.NoError(t, err)
+	assert.NoError(t, f.Close())
+	defer func() { assert.NoError(t, mount.Unlink(fname)) }()
+	fl, err := mount.GetFileLayout(fname)
+	require.NoError(t, err)
+	assert.Equal(t, dl.StripeCount, fl.StripeCount)
+
+	// the layout of an empty file can be changed
+	require.NoError(t, mount.SetFileLayout(fname, Layout{StripeCount: 1}))
+	fl, err = mount.GetFileLayout(fname)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, fl.StripeCount)
+	assert.Equal(t, dl.ObjectSize, fl.ObjectSize)
+
+	assert.ErrorIs(t,
+		mount.SetFileLayout(fname, Layout{StripeUnit: 1}), ErrInvalidLayout)
+	assert.ErrorIs(t, mount.SetFileLayout(fname, Layout{}), ErrInvalidLayout)
+
+	assert.NoError(t, mount.ClearDirLayout(dir))
+	l, err = mount.GetDirLayout(dir)
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+}
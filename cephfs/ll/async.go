@@ -0,0 +1,211 @@
+//go:build ceph_preview
+
+package ll
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <stdlib.h>
+#include <stdbool.h>
+#include <stdint.h>
+#include <sys/uio.h>
+#include <cephfs/libcephfs.h>
+
+// The type is copied from libcephfs.h with added "_" as prefix. This prevents
+// redefinition of the type on libcephfs versions that have it already.
+typedef struct _ceph_ll_io_info {
+  void (*callback)(struct _ceph_ll_io_info *cb_info);
+  void *priv;
+  struct Fh *fh;
+  const struct iovec *iov;
+  int iovcnt;
+  int64_t off;
+  int64_t result;
+  bool write;
+  bool fsync;
+  bool syncdataonly;
+} _ceph_ll_io_info;
+
+extern void llIOCallback(_ceph_ll_io_info *info);
+
+// ceph_ll_nonblocking_readv_writev_fn matches the
+// ceph_ll_nonblocking_readv_writev function signature.
+typedef int64_t(*ceph_ll_nonblocking_readv_writev_fn)(
+	struct ceph_mount_info *cmount, _ceph_ll_io_info *io_info);
+
+// ceph_ll_nonblocking_readv_writev_dlsym calls the dynamically loaded
+// ceph_ll_nonblocking_readv_writev function passed as 1st argument, after
+// connecting the io_info to the Go callback.
+static inline int64_t ceph_ll_nonblocking_readv_writev_dlsym(void *fn,
+	struct ceph_mount_info *cmount, _ceph_ll_io_info *io_info, uintptr_t index) {
+	io_info->callback = llIOCallback;
+	io_info->priv = (void*)index;
+	return ((ceph_ll_nonblocking_readv_writev_fn) fn)(cmount, io_info);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/cephfs"
+	"github.com/ceph/go-ceph/internal/callbacks"
+	"github.com/ceph/go-ceph/internal/cutil"
+	"github.com/ceph/go-ceph/internal/dlsym"
+)
+
+var (
+	cephLLNonblockingReadvWritevOnce sync.Once
+	cephLLNonblockingReadvWritev     unsafe.Pointer
+	cephLLNonblockingReadvWritevErr  error
+
+	ioCallbacks = callbacks.New()
+)
+
+// IOCompletion tracks a nonblocking I/O operation that has been started
+// using PreadvAsync or PwritevAsync.
+type IOCompletion struct {
+	done chan struct{}
+	n    int
+	err  error
+}
+
+// Done returns a channel that is closed once the I/O operation has
+// completed.
+func (c *IOCompletion) Done() <-chan struct{} {
+	return c.done
+}
+
+// Wait blocks until the I/O operation has completed and returns the number
+// of bytes that were read or written. A completed read that did not return
+// any data results in io.EOF.
+func (c *IOCompletion) Wait() (int, error) {
+	<-c.done
+	return c.n, c.err
+}
+
+type ioCallbackCtx struct {
+	completion *IOCompletion
+	info       *C._ceph_ll_io_info
+	iov        cutil.Iovec
+	write      bool
+
+	// Hold a reference to the File so that Go doesn't garbage collect it
+	// while the I/O is in flight.
+	file *File
+}
+
+// PreadvAsync starts reading data from the file into the slices of data,
+// starting at the given offset, without waiting for the read to complete.
+// The content of the slices must not be accessed until the returned
+// IOCompletion has completed.
+//
+// Implements:
+//
+//	int64_t ceph_ll_nonblocking_readv_writev(struct ceph_mount_info *cmount,
+//	                                         struct ceph_ll_io_info *io_info);
+func (f *File) PreadvAsync(data [][]byte, offset int64) (*IOCompletion, error) {
+	return f.nonblockingReadvWritev(data, offset, false)
+}
+
+// PwritevAsync starts writing the content of the slices of data to the file,
+// starting at the given offset, without waiting for the write to complete.
+// The slices must not be modified until the returned IOCompletion has
+// completed.
+//
+// Implements:
+//
+//	int64_t ceph_ll_nonblocking_readv_writev(struct ceph_mount_info *cmount,
+//	                                         struct ceph_ll_io_info *io_info);
+func (f *File) PwritevAsync(data [][]byte, offset int64) (*IOCompletion, error) {
+	return f.nonblockingReadvWritev(data, offset, true)
+}
+
+func (f *File) nonblockingReadvWritev(
+	data [][]byte, offset int64, write bool) (*IOCompletion, error) {
+
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	if offset < 0 || len(data) == 0 {
+		return nil, errInvalid
+	}
+	for _, b := range data {
+		if len(b) == 0 {
+			return nil, errInvalid
+		}
+	}
+
+	cephLLNonblockingReadvWritevOnce.Do(func() {
+		cephLLNonblockingReadvWritev, cephLLNonblockingReadvWritevErr =
+			dlsym.LookupSymbol("ceph_ll_nonblocking_readv_writev")
+	})
+	if cephLLNonblockingReadvWritevErr != nil {
+		return nil, fmt.Errorf("%w: %w",
+			cephfs.ErrNotImplemented, cephLLNonblockingReadvWritevErr)
+	}
+
+	// The io info and the iovec are used by libcephfs after the call
+	// returns, so both need to live in C memory until the callback has
+	// been called.
+	info := (*C._ceph_ll_io_info)(C.calloc(1, C.sizeof__ceph_ll_io_info))
+	iov := cutil.ByteSlicesToIovec(data)
+	info.fh = f.fh
+	info.iov = (*C.struct_iovec)(iov.Pointer())
+	info.iovcnt = C.int(iov.Len())
+	info.off = C.int64_t(offset)
+	info.write = C.bool(write)
+
+	ctx := &ioCallbackCtx{
+		completion: &IOCompletion{done: make(chan struct{})},
+		info:       info,
+		iov:        iov,
+		write:      write,
+		file:       f,
+	}
+	index := ioCallbacks.Add(ctx)
+
+	ret := C.ceph_ll_nonblocking_readv_writev_dlsym(
+		cephLLNonblockingReadvWritev, f.m.cmount(), info, C.uintptr_t(index))
+	if ret < 0 {
+		// the operation was not started, the callback will not be called
+		ioCallbacks.Remove(index)
+		ctx.release()
+		return nil, getError(C.int(ret))
+	}
+	return ctx.completion, nil
+}
+
+func (ctx *ioCallbackCtx) release() {
+	ctx.iov.Free()
+	C.free(unsafe.Pointer(ctx.info))
+	ctx.info = nil
+	ctx.file = nil
+}
+
+//export llIOCallback
+func llIOCallback(info *C._ceph_ll_io_info) {
+	index := uintptr(info.priv)
+	v := ioCallbacks.Lookup(index)
+	ioCallbacks.Remove(index)
+	ctx := v.(*ioCallbackCtx)
+
+	c := ctx.completion
+	result := int64(info.result)
+	switch {
+	case result < 0:
+		c.err = getError(C.int(result))
+	case result == 0 && !ctx.write:
+		c.err = io.EOF
+	default:
+		if !ctx.write {
+			ctx.iov.Sync()
+		}
+		c.n = int(result)
+	}
+	ctx.release()
+	close(c.done)
+}
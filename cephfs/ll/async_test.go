@@ -0,0 +1,88 @@
+//go:build ceph_preview
+
+package ll
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/cephfs"
+)
+
+func TestNonblockingReadvWritev(t *testing.T) {
+	m, done := llMount(t)
+	defer done()
+
+	root, err := m.LookupRoot()
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, root.Put()) }()
+
+	fileName := "lltest-async"
+	fin, f, _, err := root.Create(
+		fileName, 0644, os.O_RDWR|os.O_CREATE, cephfs.StatxBasicStats, 0, nil)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, f.Close())
+		assert.NoError(t, fin.Put())
+		assert.NoError(t, root.Unlink(fileName, nil))
+	}()
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := f.PwritevAsync(nil, 0)
+		assert.Error(t, err)
+		_, err = f.PreadvAsync([][]byte{{}}, 0)
+		assert.Error(t, err)
+		_, err = f.PreadvAsync([][]byte{make([]byte, 4)}, -1)
+		assert.Error(t, err)
+	})
+
+	c, err := f.PwritevAsync([][]byte{
+		[]byte("hello "),
+		[]byte("async "),
+		[]byte("world"),
+	}, 0)
+	if errors.Is(err, cephfs.ErrNotImplemented) {
+		t.Skipf("ceph_ll_nonblocking_readv_writev is not available: %v", err)
+	}
+	require.NoError(t, err)
+	n, err := c.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, 17, n)
+
+	// keep several reads in flight at once
+	bufs := make([][]byte, 4)
+	completions := make([]*IOCompletion, len(bufs))
+	for i := range bufs {
+		bufs[i] = make([]byte, 5)
+		completions[i], err = f.PreadvAsync([][]byte{bufs[i]}, int64(i*6))
+		require.NoError(t, err)
+	}
+	expected := []string{"hello", "async", "world"}
+	for i, c := range completions {
+		<-c.Done()
+		n, err := c.Wait()
+		if i < len(expected) {
+			assert.NoError(t, err)
+			assert.Equal(t, 5, n)
+			assert.Equal(t, expected[i], string(bufs[i]))
+		} else {
+			assert.Equal(t, io.EOF, err)
+			assert.Equal(t, 0, n)
+		}
+	}
+
+	// split a read over multiple buffers
+	b1, b2 := make([]byte, 3), make([]byte, 20)
+	c, err = f.PreadvAsync([][]byte{b1, b2}, 6)
+	require.NoError(t, err)
+	n, err = c.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, 11, n)
+	assert.Equal(t, "asy", string(b1))
+	assert.Equal(t, "nc world", string(b2[:8]))
+}
@@ -0,0 +1,128 @@
+//go:build ceph_preview
+
+package ll
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <stdint.h>
+#include <cephfs/libcephfs.h>
+
+extern void llDelegCallback(struct Fh *fh, void *priv);
+
+static inline int go_ceph_ll_delegation(struct ceph_mount_info *cmount,
+	struct Fh *fh, unsigned cmd, uintptr_t index) {
+	return ceph_ll_delegation(cmount, fh, cmd,
+		(ceph_deleg_cb_t)llDelegCallback, (void*)index);
+}
+*/
+import "C"
+
+import (
+	"math"
+	"time"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/callbacks"
+)
+
+var delegCallbacks = callbacks.New()
+
+// DelegationType is the type of a delegation held on an open file.
+type DelegationType uint
+
+const (
+	// DelegationNone returns a delegation held on the file.
+	DelegationNone = DelegationType(C.CEPH_DELEGATION_NONE)
+	// DelegationRead is a read delegation. It is recalled when another
+	// client opens the file for writing.
+	DelegationRead = DelegationType(C.CEPH_DELEGATION_RD)
+	// DelegationWrite is a write delegation. It is recalled when another
+	// client opens the file.
+	DelegationWrite = DelegationType(C.CEPH_DELEGATION_WR)
+)
+
+// RecallFunc is called when a delegation held on the file is recalled.
+// The function is called from a libcephfs thread holding internal locks. It
+// must not block or call into libcephfs, but should arrange for the
+// delegation to be returned, by calling Delegation with DelegationNone,
+// from another goroutine before the delegation timeout expires.
+type RecallFunc func(f *File)
+
+type delegCallbackCtx struct {
+	file   *File
+	recall RecallFunc
+}
+
+// Delegation requests a delegation of the given type on the file or, if typ
+// is DelegationNone, returns the delegation held on the file. The recall
+// function is called when the MDS recalls the delegation. If the delegation
+// is not returned within the delegation timeout the client is blocklisted.
+// Requesting a delegation replaces any delegation held before.
+//
+// Implements:
+//
+//	int ceph_ll_delegation(struct ceph_mount_info *cmount, Fh *fh, unsigned cmd,
+//	                       ceph_deleg_cb_t cb, void *priv);
+func (f *File) Delegation(typ DelegationType, recall RecallFunc) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	if typ != DelegationNone && recall == nil {
+		return errInvalid
+	}
+
+	var index uintptr
+	if typ != DelegationNone {
+		index = delegCallbacks.Add(&delegCallbackCtx{file: f, recall: recall})
+	}
+	ret := C.go_ceph_ll_delegation(
+		f.m.cmount(), f.fh, C.uint(typ), C.uintptr_t(index))
+	if err := getError(ret); err != nil {
+		if index != 0 {
+			delegCallbacks.Remove(index)
+		}
+		return err
+	}
+	f.releaseDelegation()
+	f.delegIndex = index
+	return nil
+}
+
+// releaseDelegation removes the recall callback of the delegation held on
+// the file.
+func (f *File) releaseDelegation() {
+	if f.delegIndex != 0 {
+		delegCallbacks.Remove(f.delegIndex)
+		f.delegIndex = 0
+	}
+}
+
+// SetDelegationTimeout sets the time a client has to return a recalled
+// delegation before it is blocklisted. The timeout has a granularity of
+// seconds and must be less than half of the MDS session autoclose time.
+//
+// Implements:
+//
+//	int ceph_set_deleg_timeout(struct ceph_mount_info *cmount, uint32_t timeout);
+func (m *Mount) SetDelegationTimeout(timeout time.Duration) error {
+	if err := m.validate(); err != nil {
+		return err
+	}
+	secs := timeout / time.Second
+	if secs <= 0 || secs > math.MaxUint32 {
+		return errInvalid
+	}
+	ret := C.ceph_set_deleg_timeout(m.cmount(), C.uint32_t(secs))
+	return getError(ret)
+}
+
+//export llDelegCallback
+func llDelegCallback(_ *C.struct_Fh, priv unsafe.Pointer) {
+	v := delegCallbacks.Lookup(uintptr(priv))
+	if v == nil {
+		return
+	}
+	ctx := v.(*delegCallbackCtx)
+	ctx.recall(ctx.file)
+}
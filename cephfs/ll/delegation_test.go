@@ -0,0 +1,98 @@
+//go:build ceph_preview
+
+package ll
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegation(t *testing.T) {
+	m, done := llMount(t)
+	defer done()
+
+	root, err := m.LookupRoot()
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, root.Put()) }()
+
+	fname := "ll-deleg-test.txt"
+	in, f, _, err := root.Create(fname, 0644, os.O_RDWR|os.O_CREATE, 0, 0, nil)
+	require.NoError(t, err)
+	assert.NoError(t, f.Close())
+	defer func() {
+		assert.NoError(t, in.Put())
+		assert.NoError(t, root.Unlink(fname, nil))
+	}()
+
+	t.Run("timeout", func(t *testing.T) {
+		assert.NoError(t, m.SetDelegationTimeout(30*time.Second))
+		assert.Error(t, m.SetDelegationTimeout(0))
+		assert.Error(t, m.SetDelegationTimeout(time.Millisecond))
+	})
+
+	t.Run("grantAndReturn", func(t *testing.T) {
+		f, err := in.Open(os.O_RDONLY, nil)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, f.Close()) }()
+
+		err = f.Delegation(DelegationRead, func(*File) {})
+		require.NoError(t, err)
+		assert.NotZero(t, f.delegIndex)
+		assert.NoError(t, f.Delegation(DelegationNone, nil))
+		assert.Zero(t, f.delegIndex)
+	})
+
+	t.Run("recall", func(t *testing.T) {
+		f, err := in.Open(os.O_RDONLY, nil)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, f.Close()) }()
+
+		recalled := make(chan *File, 1)
+		err = f.Delegation(DelegationRead, func(f *File) {
+			select {
+			case recalled <- f:
+			default:
+			}
+		})
+		require.NoError(t, err)
+
+		// opening the file for writing from another client recalls the
+		// read delegation
+		mount2 := fsConnect(t)
+		defer fsDisconnect(t, mount2)
+		opened := make(chan error, 1)
+		go func() {
+			f2, err := mount2.Open("/"+fname, os.O_WRONLY, 0)
+			if err == nil {
+				err = f2.Close()
+			}
+			opened <- err
+		}()
+
+		select {
+		case rf := <-recalled:
+			assert.Equal(t, f, rf)
+		case <-time.After(30 * time.Second):
+			t.Fatal("timed out waiting for delegation recall")
+		}
+		assert.NoError(t, f.Delegation(DelegationNone, nil))
+		select {
+		case err := <-opened:
+			assert.NoError(t, err)
+		case <-time.After(30 * time.Second):
+			t.Fatal("timed out waiting for open")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		f, err := in.Open(os.O_RDONLY, nil)
+		require.NoError(t, err)
+		assert.Error(t, f.Delegation(DelegationRead, nil))
+		assert.NoError(t, f.Close())
+		assert.Error(t, f.Delegation(DelegationRead, func(*File) {}))
+	})
+}
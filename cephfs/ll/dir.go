@@ -0,0 +1,109 @@
+//go:build ceph_preview
+
+package ll
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <dirent.h>
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import (
+	"github.com/ceph/go-ceph/cephfs"
+)
+
+// Dir represents a directory handle opened using the low-level API.
+type Dir struct {
+	m   *Mount
+	dir *C.struct_ceph_dir_result
+}
+
+// DirEntry represents an entry within a directory. The Inode reference of
+// the entry must be released using Put when no longer needed.
+type DirEntry struct {
+	// Name of the directory entry.
+	Name string
+	// DType of the directory entry.
+	DType cephfs.DType
+	// Inode is a reference to the inode of the directory entry.
+	Inode *Inode
+	// Statx contains the stat information of the directory entry.
+	Statx *cephfs.CephStatx
+}
+
+// OpenDir opens the directory represented by this inode for reading.
+//
+// Implements:
+//
+//	int ceph_ll_opendir(struct ceph_mount_info *cmount, struct Inode *in,
+//	                    struct ceph_dir_result **dirpp, const UserPerm *perms);
+func (in *Inode) OpenDir(perm *cephfs.UserPerm) (*Dir, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+	var dir *C.struct_ceph_dir_result
+	ret := C.ceph_ll_opendir(in.m.cmount(), in.inode, &dir, in.m.perms(perm))
+	if ret != 0 {
+		return nil, getError(ret)
+	}
+	return &Dir{m: in.m, dir: dir}, nil
+}
+
+// Close the directory handle.
+//
+// Implements:
+//
+//	int ceph_ll_releasedir(struct ceph_mount_info *cmount, struct ceph_dir_result* dir);
+func (d *Dir) Close() error {
+	if d.dir == nil {
+		return nil
+	}
+	if err := d.m.validate(); err != nil {
+		return err
+	}
+	if err := getError(C.ceph_ll_releasedir(d.m.cmount(), d.dir)); err != nil {
+		return err
+	}
+	d.dir = nil
+	return nil
+}
+
+// ReadDir reads a single directory entry, including a reference to the inode
+// of the entry and its stat information, from the open directory.
+// A nil DirEntry pointer will be returned when the directory stream has been
+// exhausted.
+//
+// Implements:
+//
+//	int ceph_readdirplus_r(struct ceph_mount_info *cmount, struct ceph_dir_result *dirp,
+//	                       struct dirent *de, struct ceph_statx *stx, unsigned want,
+//	                       unsigned flags, struct Inode **out);
+func (d *Dir) ReadDir(want cephfs.StatxMask, flags cephfs.AtFlags) (*DirEntry, error) {
+	if d.dir == nil {
+		return nil, errBadFile
+	}
+	if err := d.m.validate(); err != nil {
+		return nil, err
+	}
+	var (
+		de    C.struct_dirent
+		stx   C.struct_ceph_statx
+		inode *C.struct_Inode
+	)
+	ret := C.ceph_readdirplus_r(
+		d.m.cmount(), d.dir, &de, &stx, C.uint(want), C.uint(flags), &inode)
+	if ret < 0 {
+		return nil, getError(ret)
+	}
+	if ret == 0 {
+		return nil, nil // End-of-stream
+	}
+	return &DirEntry{
+		Name:  C.GoString(&de.d_name[0]),
+		DType: cephfs.DType(de.d_type),
+		Inode: &Inode{m: d.m, inode: inode},
+		Statx: toCephStatx(&stx),
+	}, nil
+}
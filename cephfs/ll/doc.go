@@ -0,0 +1,12 @@
+/*
+Package ll contains a set of wrappers around the low-level, inode based, API
+of libcephfs.
+
+Unlike the path based API of the cephfs package the functions in this package
+operate on references to inodes. Inode references are obtained by looking up
+names relative to a parent inode and must be released when no longer needed.
+This API is intended for the implementation of file servers, such as NFS
+servers or FUSE bridges, that need to track file system objects independently
+of their paths.
+*/
+package ll
@@ -0,0 +1,27 @@
+//go:build ceph_preview
+
+package ll
+
+/*
+#include <errno.h>
+*/
+import "C"
+
+import (
+	"github.com/ceph/go-ceph/internal/errutil"
+)
+
+func getError(e C.int) error {
+	return errutil.GetError("cephfs", int(e))
+}
+
+var (
+	// ErrNotConnected may be returned when the mount is not connected to
+	// a cluster or not mounted.
+	ErrNotConnected = getError(-C.ENOTCONN)
+
+	// Private errors:
+
+	errInvalid = getError(-C.EINVAL)
+	errBadFile = getError(-C.EBADF)
+)
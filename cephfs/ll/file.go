@@ -0,0 +1,194 @@
+//go:build ceph_preview
+
+package ll
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <stdlib.h>
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import (
+	"io"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/cephfs"
+)
+
+// File represents a file handle opened using the low-level API.
+type File struct {
+	m  *Mount
+	fh *C.struct_Fh
+
+	// delegIndex is the index of the recall callback of a delegation held
+	// on the file, or zero.
+	delegIndex uintptr
+}
+
+func (f *File) validate() error {
+	if f.fh == nil {
+		return errBadFile
+	}
+	return f.m.validate()
+}
+
+// Open the file represented by this inode. The flags are the same os flags as
+// a local open call.
+//
+// Implements:
+//
+//	int ceph_ll_open(struct ceph_mount_info *cmount, struct Inode *in, int flags,
+//	                 struct Fh **fh, const UserPerm *perms);
+func (in *Inode) Open(flags int, perm *cephfs.UserPerm) (*File, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+	var fh *C.struct_Fh
+	ret := C.ceph_ll_open(in.m.cmount(), in.inode, C.int(flags), &fh, in.m.perms(perm))
+	if ret != 0 {
+		return nil, getError(ret)
+	}
+	return &File{m: in.m, fh: fh}, nil
+}
+
+// Create a new file with the given name and mode in the directory represented
+// by this inode and open it using the given flags. A reference to the inode
+// of the new file, the open file and the stat information of the new file are
+// returned.
+//
+// Implements:
+//
+//	int ceph_ll_create(struct ceph_mount_info *cmount, Inode *parent, const char *name,
+//	                   mode_t mode, int oflags, Inode **outp, Fh **fhp,
+//	                   struct ceph_statx *stx, unsigned want, unsigned lflags,
+//	                   const UserPerm *perms);
+func (in *Inode) Create(name string, mode uint32, oflags int,
+	want cephfs.StatxMask, flags cephfs.AtFlags, perm *cephfs.UserPerm) (
+	*Inode, *File, *cephfs.CephStatx, error) {
+
+	if err := in.validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var (
+		inode *C.struct_Inode
+		fh    *C.struct_Fh
+		stx   C.struct_ceph_statx
+	)
+	ret := C.ceph_ll_create(
+		in.m.cmount(), in.inode, cName, C.mode_t(mode), C.int(oflags),
+		&inode, &fh, &stx, C.uint(want), C.uint(flags), in.m.perms(perm))
+	if ret != 0 {
+		return nil, nil, nil, getError(ret)
+	}
+	return &Inode{m: in.m, inode: inode},
+		&File{m: in.m, fh: fh},
+		toCephStatx(&stx),
+		nil
+}
+
+// Close the file.
+//
+// Implements:
+//
+//	int ceph_ll_close(struct ceph_mount_info *cmount, struct Fh* filehandle);
+func (f *File) Close() error {
+	if f.fh == nil {
+		// already closed
+		return nil
+	}
+	if err := f.m.validate(); err != nil {
+		return err
+	}
+	if err := getError(C.ceph_ll_close(f.m.cmount(), f.fh)); err != nil {
+		return err
+	}
+	f.fh = nil
+	// closing the file returns any delegation held on it
+	f.releaseDelegation()
+	return nil
+}
+
+// ReadAt will read data from the file starting at the given offset.
+// Up to len(buf) bytes will be read from the file.
+// The number of bytes read will be returned.
+// When nothing is left to read from the file, ReadAt returns, 0, io.EOF.
+//
+// Implements:
+//
+//	int ceph_ll_read(struct ceph_mount_info *cmount, struct Fh* filehandle,
+//	                 int64_t off, uint64_t len, char* buf);
+func (f *File) ReadAt(buf []byte, offset int64) (int, error) {
+	if err := f.validate(); err != nil {
+		return 0, err
+	}
+	if offset < 0 {
+		return 0, errInvalid
+	}
+	if len(buf) == 0 {
+		return 0, nil
+	}
+	ret := C.ceph_ll_read(
+		f.m.cmount(),
+		f.fh,
+		C.int64_t(offset),
+		C.uint64_t(len(buf)),
+		(*C.char)(unsafe.Pointer(&buf[0])))
+	switch {
+	case ret < 0:
+		return 0, getError(ret)
+	case ret == 0:
+		return 0, io.EOF
+	}
+	return int(ret), nil
+}
+
+// WriteAt writes the content of buf to the file starting at the given
+// offset. The number of bytes written is returned.
+//
+// Implements:
+//
+//	int ceph_ll_write(struct ceph_mount_info *cmount, struct Fh* filehandle,
+//	                  int64_t off, uint64_t len, const char *data);
+func (f *File) WriteAt(buf []byte, offset int64) (int, error) {
+	if err := f.validate(); err != nil {
+		return 0, err
+	}
+	if offset < 0 {
+		return 0, errInvalid
+	}
+	if len(buf) == 0 {
+		return 0, nil
+	}
+	ret := C.ceph_ll_write(
+		f.m.cmount(),
+		f.fh,
+		C.int64_t(offset),
+		C.uint64_t(len(buf)),
+		(*C.char)(unsafe.Pointer(&buf[0])))
+	if ret < 0 {
+		return 0, getError(ret)
+	}
+	return int(ret), nil
+}
+
+// Fsync ensures the file content that may be cached is committed to stable
+// storage. If dataOnly is true only the data of the file is synchronized.
+//
+// Implements:
+//
+//	int ceph_ll_fsync(struct ceph_mount_info *cmount, struct Fh *fh, int syncdataonly);
+func (f *File) Fsync(dataOnly bool) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	var cDataOnly C.int
+	if dataOnly {
+		cDataOnly = 1
+	}
+	return getError(C.ceph_ll_fsync(f.m.cmount(), f.fh, cDataOnly))
+}
@@ -0,0 +1,133 @@
+//go:build ceph_preview
+
+package ll
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <stdlib.h>
+#include <cephfs/libcephfs.h>
+
+// ceph_ll_lookup_vino_fn matches the ceph_ll_lookup_vino function signature.
+typedef int(*ceph_ll_lookup_vino_fn)(struct ceph_mount_info *cmount,
+	vinodeno_t vino, struct Inode **inode);
+
+// ceph_ll_lookup_vino_dlsym calls the dynamically loaded ceph_ll_lookup_vino
+// function passed as 1st argument.
+static inline int ceph_ll_lookup_vino_dlsym(void *fn,
+	struct ceph_mount_info *cmount, uint64_t ino, uint64_t snapid,
+	struct Inode **inode) {
+	vinodeno_t vino;
+	vino.ino.val = ino;
+	vino.snapid.val = snapid;
+	return ((ceph_ll_lookup_vino_fn) fn)(cmount, vino, inode);
+}
+
+static inline int go_ceph_ll_lookup_inode(struct ceph_mount_info *cmount,
+	uint64_t ino, struct Inode **inode) {
+	struct inodeno_t i;
+	i.val = ino;
+	return ceph_ll_lookup_inode(cmount, i, inode);
+}
+*/
+import "C"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/cephfs"
+	"github.com/ceph/go-ceph/internal/dlsym"
+)
+
+var (
+	cephLLLookupVinoOnce sync.Once
+	cephLLLookupVino     unsafe.Pointer
+	cephLLLookupVinoErr  error
+)
+
+// NoSnapID is the snapshot ID of inodes that are not part of a snapshot.
+const NoSnapID = cephfs.NoSnapID
+
+// handleSize is the size of a marshaled Handle.
+const handleSize = 16
+
+// Handle identifies an inode of the file system, including inodes within
+// snapshots. A Handle remains valid for as long as the inode exists and can
+// be stored, for example as part of an NFS file handle, and be used to look
+// up the inode again later.
+type Handle struct {
+	// Ino is the inode number.
+	Ino uint64
+	// SnapID is the ID of the snapshot the inode belongs to or NoSnapID.
+	SnapID uint64
+}
+
+// MarshalBinary encodes the handle in a stable binary format.
+func (h Handle) MarshalBinary() ([]byte, error) {
+	b := make([]byte, handleSize)
+	binary.BigEndian.PutUint64(b[0:8], h.Ino)
+	binary.BigEndian.PutUint64(b[8:16], h.SnapID)
+	return b, nil
+}
+
+// UnmarshalBinary decodes a handle encoded by MarshalBinary.
+func (h *Handle) UnmarshalBinary(data []byte) error {
+	if len(data) != handleSize {
+		return fmt.Errorf("invalid handle size: %d", len(data))
+	}
+	h.Ino = binary.BigEndian.Uint64(data[0:8])
+	h.SnapID = binary.BigEndian.Uint64(data[8:16])
+	return nil
+}
+
+// Handle returns the Handle of the inode.
+func (in *Inode) Handle() (Handle, error) {
+	// libcephfs reports the snapshot id of the inode as the device
+	stx, err := in.GetAttr(cephfs.StatxIno, 0, nil)
+	if err != nil {
+		return Handle{}, err
+	}
+	return Handle{Ino: uint64(stx.Inode), SnapID: stx.Dev}, nil
+}
+
+// LookupHandle returns a reference to the inode identified by the given
+// handle.
+//
+// Implements:
+//
+//	int ceph_ll_lookup_vino(struct ceph_mount_info *cmount, vinodeno_t vino,
+//	                        Inode **inode);
+//	int ceph_ll_lookup_inode(struct ceph_mount_info *cmount, struct inodeno_t ino,
+//	                         Inode **inode);
+func (m *Mount) LookupHandle(h Handle) (*Inode, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	cephLLLookupVinoOnce.Do(func() {
+		cephLLLookupVino, cephLLLookupVinoErr = dlsym.LookupSymbol("ceph_ll_lookup_vino")
+	})
+
+	var (
+		inode *C.struct_Inode
+		ret   C.int
+	)
+	switch {
+	case cephLLLookupVinoErr == nil:
+		ret = C.ceph_ll_lookup_vino_dlsym(cephLLLookupVino, m.cmount(),
+			C.uint64_t(h.Ino), C.uint64_t(h.SnapID), &inode)
+	case h.SnapID == NoSnapID:
+		// older versions of libcephfs can only look up inodes that are
+		// not part of a snapshot
+		ret = C.go_ceph_ll_lookup_inode(m.cmount(), C.uint64_t(h.Ino), &inode)
+	default:
+		return nil, fmt.Errorf("%w: %w", cephfs.ErrNotImplemented, cephLLLookupVinoErr)
+	}
+	if ret != 0 {
+		return nil, getError(ret)
+	}
+	return &Inode{m: m, inode: inode}, nil
+}
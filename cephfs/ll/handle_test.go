@@ -0,0 +1,92 @@
+//go:build ceph_preview
+
+package ll
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/cephfs"
+)
+
+func TestHandleMarshal(t *testing.T) {
+	h := Handle{Ino: 0x1000000abcd, SnapID: NoSnapID}
+	b, err := h.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Len(t, b, 16)
+
+	var h2 Handle
+	assert.NoError(t, h2.UnmarshalBinary(b))
+	assert.Equal(t, h, h2)
+
+	assert.Error(t, h2.UnmarshalBinary(b[:8]))
+}
+
+func TestLookupHandle(t *testing.T) {
+	m, done := llMount(t)
+	defer done()
+
+	root, err := m.LookupRoot()
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, root.Put()) }()
+
+	dirName := "lltest-handle"
+	dir, _, err := root.Mkdir(dirName, 0755, cephfs.StatxBasicStats, 0, nil)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, dir.Put())
+		assert.NoError(t, root.Rmdir(dirName, nil))
+	}()
+
+	fin, f, stx, err := dir.Create(
+		"file", 0644, os.O_RDWR|os.O_CREATE, cephfs.StatxBasicStats, 0, nil)
+	require.NoError(t, err)
+	assert.NoError(t, f.Close())
+	defer func() { assert.NoError(t, dir.Unlink("file", nil)) }()
+
+	h, err := fin.Handle()
+	require.NoError(t, err)
+	assert.EqualValues(t, stx.Inode, h.Ino)
+	assert.Equal(t, NoSnapID, h.SnapID)
+	assert.NoError(t, fin.Put())
+
+	in, err := m.LookupHandle(h)
+	require.NoError(t, err)
+	st, err := in.GetAttr(cephfs.StatxIno, 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, stx.Inode, st.Inode)
+	assert.NoError(t, in.Put())
+
+	_, err = m.LookupHandle(Handle{Ino: 0xfffffffff0, SnapID: NoSnapID})
+	assert.Error(t, err)
+
+	t.Run("snapshot", func(t *testing.T) {
+		require.NoError(t, m.mount.MakeSnapshot("/"+dirName, "snap1", 0755, nil))
+		defer func() {
+			assert.NoError(t, m.mount.RemoveSnapshot("/"+dirName, "snap1"))
+		}()
+
+		sin, _, err := m.Walk("/"+dirName+"/.snap/snap1/file",
+			cephfs.StatxBasicStats, 0, nil)
+		require.NoError(t, err)
+		sh, err := sin.Handle()
+		assert.NoError(t, err)
+		assert.NoError(t, sin.Put())
+		assert.Equal(t, h.Ino, sh.Ino)
+		assert.NotEqual(t, NoSnapID, sh.SnapID)
+
+		in, err := m.LookupHandle(sh)
+		if errors.Is(err, cephfs.ErrNotImplemented) {
+			t.Skipf("ceph_ll_lookup_vino is not available: %v", err)
+		}
+		require.NoError(t, err)
+		h2, err := in.Handle()
+		assert.NoError(t, err)
+		assert.Equal(t, sh, h2)
+		assert.NoError(t, in.Put())
+	})
+}
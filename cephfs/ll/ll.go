@@ -0,0 +1,329 @@
+//go:build ceph_preview
+
+package ll
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <stdlib.h>
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/ceph/go-ceph/cephfs"
+)
+
+// Mount provides access to the low-level API of a mounted cephfs file
+// system.
+type Mount struct {
+	// The handle of the MountInfo is not cached as it is freed when the
+	// MountInfo is released.
+	mount *cephfs.MountInfo
+}
+
+// New returns a Mount object providing access to the low-level API of the
+// given, already mounted, cephfs MountInfo.
+func New(mount *cephfs.MountInfo) (*Mount, error) {
+	if mount == nil || mount.Pointer() == nil || !mount.IsMounted() {
+		return nil, ErrNotConnected
+	}
+	return &Mount{mount: mount}, nil
+}
+
+func (m *Mount) validate() error {
+	if m.mount == nil || m.mount.Pointer() == nil {
+		return ErrNotConnected
+	}
+	return nil
+}
+
+// cmount returns the C handle of the mount. The handle must be validated
+// before use.
+func (m *Mount) cmount() *C.struct_ceph_mount_info {
+	return (*C.struct_ceph_mount_info)(m.mount.Pointer())
+}
+
+// perms returns the C UserPerm for the given UserPerm. If perm is nil the
+// default credentials of the mount are used.
+func (m *Mount) perms(perm *cephfs.UserPerm) *C.UserPerm {
+	if perm == nil {
+		return C.ceph_mount_perms(m.cmount())
+	}
+	return (*C.UserPerm)(perm.Pointer())
+}
+
+// Inode is a reference to an inode of the file system. Inode references must
+// be released using Put when no longer needed.
+type Inode struct {
+	m     *Mount
+	inode *C.struct_Inode
+}
+
+func (in *Inode) validate() error {
+	if in == nil || in.inode == nil {
+		return errInvalid
+	}
+	return in.m.validate()
+}
+
+// LookupRoot returns a reference to the root inode of the mount.
+//
+// Implements:
+//
+//	int ceph_ll_lookup_root(struct ceph_mount_info *cmount, Inode **parent);
+func (m *Mount) LookupRoot() (*Inode, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	var inode *C.struct_Inode
+	ret := C.ceph_ll_lookup_root(m.cmount(), &inode)
+	if ret != 0 {
+		return nil, getError(ret)
+	}
+	return &Inode{m: m, inode: inode}, nil
+}
+
+// Walk returns a reference to the inode found at the given path as well as
+// the stat information of the inode. See cephfs.MountInfo.Statx for a
+// description of the want and flags parameters. If perm is nil the default
+// credentials of the mount are used.
+//
+// Implements:
+//
+//	int ceph_ll_walk(struct ceph_mount_info *cmount, const char* name, Inode **i,
+//	                 struct ceph_statx *stx, unsigned int want, unsigned int flags,
+//	                 const UserPerm *perms);
+func (m *Mount) Walk(path string, want cephfs.StatxMask, flags cephfs.AtFlags,
+	perm *cephfs.UserPerm) (*Inode, *cephfs.CephStatx, error) {
+
+	if err := m.validate(); err != nil {
+		return nil, nil, err
+	}
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	var (
+		inode *C.struct_Inode
+		stx   C.struct_ceph_statx
+	)
+	ret := C.ceph_ll_walk(
+		m.cmount(), cPath, &inode, &stx, C.uint(want), C.uint(flags), m.perms(perm))
+	if ret != 0 {
+		return nil, nil, getError(ret)
+	}
+	return &Inode{m: m, inode: inode}, toCephStatx(&stx), nil
+}
+
+// Put releases the reference to the inode. The Inode must not be used after
+// calling Put.
+//
+// Implements:
+//
+//	int ceph_ll_put(struct ceph_mount_info *cmount, struct Inode *in);
+func (in *Inode) Put() error {
+	if in == nil || in.inode == nil {
+		return nil
+	}
+	if err := in.m.validate(); err != nil {
+		return err
+	}
+	if err := getError(C.ceph_ll_put(in.m.cmount(), in.inode)); err != nil {
+		return err
+	}
+	in.inode = nil
+	return nil
+}
+
+// Lookup returns a reference to the inode with the given name in the
+// directory represented by this inode as well as the stat information of the
+// found inode.
+//
+// Implements:
+//
+//	int ceph_ll_lookup(struct ceph_mount_info *cmount, Inode *parent, const char *name,
+//	                   Inode **out, struct ceph_statx *stx, unsigned want, unsigned flags,
+//	                   const UserPerm *perms);
+func (in *Inode) Lookup(name string, want cephfs.StatxMask,
+	flags cephfs.AtFlags, perm *cephfs.UserPerm) (*Inode, *cephfs.CephStatx, error) {
+
+	if err := in.validate(); err != nil {
+		return nil, nil, err
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var (
+		inode *C.struct_Inode
+		stx   C.struct_ceph_statx
+	)
+	ret := C.ceph_ll_lookup(
+		in.m.cmount(), in.inode, cName, &inode, &stx,
+		C.uint(want), C.uint(flags), in.m.perms(perm))
+	if ret != 0 {
+		return nil, nil, getError(ret)
+	}
+	return &Inode{m: in.m, inode: inode}, toCephStatx(&stx), nil
+}
+
+// GetAttr returns the stat information of the inode.
+//
+// Implements:
+//
+//	int ceph_ll_getattr(struct ceph_mount_info *cmount, struct Inode *in,
+//	                    struct ceph_statx *stx, unsigned int want, unsigned int flags,
+//	                    const UserPerm *perms);
+func (in *Inode) GetAttr(want cephfs.StatxMask, flags cephfs.AtFlags,
+	perm *cephfs.UserPerm) (*cephfs.CephStatx, error) {
+
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+	var stx C.struct_ceph_statx
+	ret := C.ceph_ll_getattr(
+		in.m.cmount(), in.inode, &stx, C.uint(want), C.uint(flags), in.m.perms(perm))
+	if ret != 0 {
+		return nil, getError(ret)
+	}
+	return toCephStatx(&stx), nil
+}
+
+// SetAttr applies the fields of stx selected by mask to the inode.
+//
+// Implements:
+//
+//	int ceph_ll_setattr(struct ceph_mount_info *cmount, struct Inode *in,
+//	                    struct ceph_statx *stx, int mask, const UserPerm *perms);
+func (in *Inode) SetAttr(stx *cephfs.CephStatx, mask SetAttrMask,
+	perm *cephfs.UserPerm) error {
+
+	if err := in.validate(); err != nil {
+		return err
+	}
+	if stx == nil {
+		return errInvalid
+	}
+	var cStx C.struct_ceph_statx
+	fromCephStatx(stx, &cStx)
+	ret := C.ceph_ll_setattr(
+		in.m.cmount(), in.inode, &cStx, C.int(mask), in.m.perms(perm))
+	return getError(ret)
+}
+
+// Mkdir creates a new directory with the given name and mode within the
+// directory represented by this inode. A reference to the inode of the new
+// directory and its stat information are returned.
+//
+// Implements:
+//
+//	int ceph_ll_mkdir(struct ceph_mount_info *cmount, Inode *parent, const char *name,
+//	                  mode_t mode, Inode **out, struct ceph_statx *stx, unsigned want,
+//	                  unsigned flags, const UserPerm *perms);
+func (in *Inode) Mkdir(name string, mode uint32, want cephfs.StatxMask,
+	flags cephfs.AtFlags, perm *cephfs.UserPerm) (*Inode, *cephfs.CephStatx, error) {
+
+	if err := in.validate(); err != nil {
+		return nil, nil, err
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var (
+		inode *C.struct_Inode
+		stx   C.struct_ceph_statx
+	)
+	ret := C.ceph_ll_mkdir(
+		in.m.cmount(), in.inode, cName, C.mode_t(mode), &inode, &stx,
+		C.uint(want), C.uint(flags), in.m.perms(perm))
+	if ret != 0 {
+		return nil, nil, getError(ret)
+	}
+	return &Inode{m: in.m, inode: inode}, toCephStatx(&stx), nil
+}
+
+// Link creates a new hard link to this inode with the given name in the
+// directory represented by newParent.
+//
+// Implements:
+//
+//	int ceph_ll_link(struct ceph_mount_info *cmount, struct Inode *in,
+//	                 struct Inode *newparent, const char *name, const UserPerm *perms);
+func (in *Inode) Link(newParent *Inode, name string, perm *cephfs.UserPerm) error {
+	if err := in.validate(); err != nil {
+		return err
+	}
+	if err := newParent.validate(); err != nil {
+		return err
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	ret := C.ceph_ll_link(
+		in.m.cmount(), in.inode, newParent.inode, cName, in.m.perms(perm))
+	return getError(ret)
+}
+
+// Unlink removes the entry with the given name from the directory represented
+// by this inode.
+//
+// Implements:
+//
+//	int ceph_ll_unlink(struct ceph_mount_info *cmount, struct Inode *in, const char *name,
+//	                   const UserPerm *perms);
+func (in *Inode) Unlink(name string, perm *cephfs.UserPerm) error {
+	if err := in.validate(); err != nil {
+		return err
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	ret := C.ceph_ll_unlink(in.m.cmount(), in.inode, cName, in.m.perms(perm))
+	return getError(ret)
+}
+
+// Rmdir removes the empty sub-directory with the given name from the
+// directory represented by this inode.
+//
+// Implements:
+//
+//	int ceph_ll_rmdir(struct ceph_mount_info *cmount, struct Inode *in, const char *name,
+//	                  const UserPerm *perms);
+func (in *Inode) Rmdir(name string, perm *cephfs.UserPerm) error {
+	if err := in.validate(); err != nil {
+		return err
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	ret := C.ceph_ll_rmdir(in.m.cmount(), in.inode, cName, in.m.perms(perm))
+	return getError(ret)
+}
+
+// Rename moves the entry with the given name in the directory represented by
+// this inode to newName in the directory represented by newParent.
+//
+// Implements:
+//
+//	int ceph_ll_rename(struct ceph_mount_info *cmount, struct Inode *parent,
+//	                   const char *name, struct Inode *newparent, const char *newname,
+//	                   const UserPerm *perms);
+func (in *Inode) Rename(name string, newParent *Inode, newName string,
+	perm *cephfs.UserPerm) error {
+
+	if err := in.validate(); err != nil {
+		return err
+	}
+	if err := newParent.validate(); err != nil {
+		return err
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	cNewName := C.CString(newName)
+	defer C.free(unsafe.Pointer(cNewName))
+
+	ret := C.ceph_ll_rename(
+		in.m.cmount(), in.inode, cName, newParent.inode, cNewName, in.m.perms(perm))
+	return getError(ret)
+}
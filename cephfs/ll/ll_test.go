@@ -0,0 +1,202 @@
+//go:build ceph_preview
+
+package ll
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/cephfs"
+)
+
+func fsConnect(t require.TestingT) *cephfs.MountInfo {
+	mount, err := cephfs.CreateMount()
+	require.NoError(t, err)
+	require.NotNil(t, mount)
+
+	err = mount.ReadDefaultConfigFile()
+	require.NoError(t, err)
+
+	timeout := time.After(time.Second * 5)
+	ch := make(chan error)
+	go func(mount *cephfs.MountInfo) {
+		ch <- mount.Mount()
+	}(mount)
+	select {
+	case err = <-ch:
+	case <-timeout:
+		err = fmt.Errorf("timed out waiting for connect")
+	}
+	require.NoError(t, err)
+	return mount
+}
+
+func fsDisconnect(t assert.TestingT, mount *cephfs.MountInfo) {
+	assert.NoError(t, mount.Unmount())
+	assert.NoError(t, mount.Release())
+}
+
+func llMount(t *testing.T) (*Mount, func()) {
+	mount := fsConnect(t)
+	m, err := New(mount)
+	require.NoError(t, err)
+	return m, func() { fsDisconnect(t, mount) }
+}
+
+func TestNewNotMounted(t *testing.T) {
+	mount, err := cephfs.CreateMount()
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, mount.Release()) }()
+
+	_, err = New(mount)
+	assert.ErrorIs(t, err, ErrNotConnected)
+	_, err = New(nil)
+	assert.ErrorIs(t, err, ErrNotConnected)
+}
+
+func TestReleasedMount(t *testing.T) {
+	mount := fsConnect(t)
+	m, err := New(mount)
+	require.NoError(t, err)
+	root, err := m.LookupRoot()
+	require.NoError(t, err)
+	assert.NoError(t, root.Put())
+	fsDisconnect(t, mount)
+
+	_, err = m.LookupRoot()
+	assert.ErrorIs(t, err, ErrNotConnected)
+	_, _, err = m.Walk("/", cephfs.StatxBasicStats, 0, nil)
+	assert.ErrorIs(t, err, ErrNotConnected)
+}
+
+func TestLookupRootAndWalk(t *testing.T) {
+	m, done := llMount(t)
+	defer done()
+
+	root, err := m.LookupRoot()
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, root.Put()) }()
+
+	st, err := root.GetAttr(cephfs.StatxBasicStats, 0, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0040000, st.Mode&0170000)
+	assert.EqualValues(t, 1, st.Inode)
+
+	in, st2, err := m.Walk("/", cephfs.StatxBasicStats, 0, nil)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, in.Put()) }()
+	assert.Equal(t, st.Inode, st2.Inode)
+
+	_, _, err = root.Lookup("does-not-exist", cephfs.StatxBasicStats, 0, nil)
+	assert.ErrorIs(t, err, cephfs.ErrNotExist)
+}
+
+func TestMkdirCreateReadWrite(t *testing.T) {
+	m, done := llMount(t)
+	defer done()
+
+	root, err := m.LookupRoot()
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, root.Put()) }()
+
+	dirName := "lltest1"
+	dir, st, err := root.Mkdir(dirName, 0755, cephfs.StatxBasicStats, 0, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0755, st.Mode&0777)
+	defer func() {
+		assert.NoError(t, dir.Put())
+		assert.NoError(t, root.Rmdir(dirName, nil))
+	}()
+
+	fileName := "file1"
+	fin, f, _, err := dir.Create(
+		fileName, 0644, os.O_RDWR|os.O_CREATE, cephfs.StatxBasicStats, 0, nil)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fin.Put())
+		assert.NoError(t, dir.Unlink(fileName, nil))
+	}()
+
+	data := []byte("hello, low-level world")
+	n, err := f.WriteAt(data, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.NoError(t, f.Fsync(false))
+	assert.NoError(t, f.Close())
+	// closing twice is a no-op
+	assert.NoError(t, f.Close())
+
+	f, err = fin.Open(os.O_RDONLY, nil)
+	require.NoError(t, err)
+	buf := make([]byte, 64)
+	n, err = f.ReadAt(buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buf[:n])
+	n, err = f.ReadAt(buf, int64(len(data)))
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 0, n)
+	assert.NoError(t, f.Close())
+
+	st, err = fin.GetAttr(cephfs.StatxSize, 0, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, len(data), st.Size)
+
+	err = fin.SetAttr(&cephfs.CephStatx{Size: 5}, SetAttrSize, nil)
+	assert.NoError(t, err)
+	st, err = fin.GetAttr(cephfs.StatxSize, 0, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 5, st.Size)
+}
+
+func TestLinkRenameReadDir(t *testing.T) {
+	m, done := llMount(t)
+	defer done()
+
+	root, err := m.LookupRoot()
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, root.Put()) }()
+
+	dirName := "lltest2"
+	dir, _, err := root.Mkdir(dirName, 0755, cephfs.StatxBasicStats, 0, nil)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, dir.Put())
+		assert.NoError(t, root.Rmdir(dirName, nil))
+	}()
+
+	fin, f, _, err := dir.Create(
+		"a", 0644, os.O_RDWR|os.O_CREATE, cephfs.StatxBasicStats, 0, nil)
+	require.NoError(t, err)
+	assert.NoError(t, f.Close())
+	defer func() { assert.NoError(t, fin.Put()) }()
+
+	assert.NoError(t, fin.Link(dir, "b", nil))
+	assert.NoError(t, dir.Rename("b", dir, "c", nil))
+
+	d, err := dir.OpenDir(nil)
+	require.NoError(t, err)
+	names := map[string]cephfs.Inode{}
+	for {
+		de, err := d.ReadDir(cephfs.StatxBasicStats, 0)
+		require.NoError(t, err)
+		if de == nil {
+			break
+		}
+		names[de.Name] = de.Statx.Inode
+		assert.NoError(t, de.Inode.Put())
+	}
+	assert.NoError(t, d.Close())
+	assert.Contains(t, names, "a")
+	assert.Contains(t, names, "c")
+	assert.NotContains(t, names, "b")
+	assert.Equal(t, names["a"], names["c"])
+
+	assert.NoError(t, dir.Unlink("a", nil))
+	assert.NoError(t, dir.Unlink("c", nil))
+}
@@ -0,0 +1,132 @@
+//go:build ceph_preview
+
+package ll
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <fcntl.h>
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import "io"
+
+// LockType is the type of a POSIX record lock.
+type LockType int16
+
+const (
+	// LockRead is a shared (read) lock.
+	LockRead = LockType(C.F_RDLCK)
+	// LockWrite is an exclusive (write) lock.
+	LockWrite = LockType(C.F_WRLCK)
+	// LockUnlock removes a lock. It is also reported by GetLock if no
+	// conflicting lock exists.
+	LockUnlock = LockType(C.F_UNLCK)
+)
+
+// Lock describes a POSIX record (byte-range) lock on a file.
+type Lock struct {
+	// Type of the lock.
+	Type LockType
+	// Whence is the base of Start. libcephfs only supports io.SeekStart,
+	// the lock functions return an error for any other value.
+	Whence int16
+	// Start is the offset of the first byte of the locked range.
+	Start int64
+	// Len is the number of bytes of the locked range. A zero length locks
+	// up to the end of the file.
+	Len int64
+	// Pid of the process holding a conflicting lock, as returned by
+	// GetLock.
+	Pid int32
+}
+
+func (l *Lock) validate() error {
+	if l == nil || l.Whence != io.SeekStart {
+		return errInvalid
+	}
+	return nil
+}
+
+func (l *Lock) toC() C.struct_flock {
+	var fl C.struct_flock
+	fl.l_type = C.short(l.Type)
+	fl.l_whence = C.short(l.Whence)
+	fl.l_start = C.off_t(l.Start)
+	fl.l_len = C.off_t(l.Len)
+	fl.l_pid = C.pid_t(l.Pid)
+	return fl
+}
+
+func lockFromC(fl *C.struct_flock) *Lock {
+	return &Lock{
+		Type:   LockType(fl.l_type),
+		Whence: int16(fl.l_whence),
+		Start:  int64(fl.l_start),
+		Len:    int64(fl.l_len),
+		Pid:    int32(fl.l_pid),
+	}
+}
+
+// GetLock tests whether the given lock could be placed on the file by owner.
+// If a conflicting lock exists it is returned, otherwise the returned lock
+// has the type LockUnlock.
+//
+// Implements:
+//
+//	int ceph_ll_getlk(struct ceph_mount_info *cmount, Fh *fh, struct flock *fl,
+//	                  uint64_t owner);
+func (f *File) GetLock(lock *Lock, owner uint64) (*Lock, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	if err := lock.validate(); err != nil {
+		return nil, err
+	}
+	fl := lock.toC()
+	ret := C.ceph_ll_getlk(f.m.cmount(), f.fh, &fl, C.uint64_t(owner))
+	if ret != 0 {
+		return nil, getError(ret)
+	}
+	return lockFromC(&fl), nil
+}
+
+// SetLock places or, if the lock type is LockUnlock, removes the given lock
+// on the file for owner. If a conflicting lock is held an error is returned
+// immediately.
+//
+// Implements:
+//
+//	int ceph_ll_setlk(struct ceph_mount_info *cmount, Fh *fh, struct flock *fl,
+//	                  uint64_t owner, int sleep);
+func (f *File) SetLock(lock *Lock, owner uint64) error {
+	return f.setLock(lock, owner, false)
+}
+
+// SetLockWait places the given lock on the file for owner, waiting for any
+// conflicting lock to be released.
+//
+// Implements:
+//
+//	int ceph_ll_setlk(struct ceph_mount_info *cmount, Fh *fh, struct flock *fl,
+//	                  uint64_t owner, int sleep);
+func (f *File) SetLockWait(lock *Lock, owner uint64) error {
+	return f.setLock(lock, owner, true)
+}
+
+func (f *File) setLock(lock *Lock, owner uint64, wait bool) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	if err := lock.validate(); err != nil {
+		return err
+	}
+	var sleep C.int
+	if wait {
+		sleep = 1
+	}
+	fl := lock.toC()
+	ret := C.ceph_ll_setlk(f.m.cmount(), f.fh, &fl, C.uint64_t(owner), sleep)
+	return getError(ret)
+}
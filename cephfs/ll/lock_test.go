@@ -0,0 +1,86 @@
+//go:build ceph_preview
+
+package ll
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/cephfs"
+)
+
+func TestRecordLocks(t *testing.T) {
+	m, done := llMount(t)
+	defer done()
+
+	root, err := m.LookupRoot()
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, root.Put()) }()
+
+	fileName := "lltest-locks"
+	fin, f1, _, err := root.Create(
+		fileName, 0644, os.O_RDWR|os.O_CREATE, cephfs.StatxBasicStats, 0, nil)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, f1.Close())
+		assert.NoError(t, fin.Put())
+		assert.NoError(t, root.Unlink(fileName, nil))
+	}()
+	f2, err := fin.Open(os.O_RDWR, nil)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, f2.Close()) }()
+
+	const owner1, owner2 = 1001, 1002
+	wlock := &Lock{Type: LockWrite, Whence: io.SeekStart, Start: 0, Len: 100}
+
+	l, err := f1.GetLock(wlock, owner1)
+	assert.NoError(t, err)
+	assert.Equal(t, LockUnlock, l.Type)
+
+	assert.NoError(t, f1.SetLock(wlock, owner1))
+
+	// a conflicting lock is reported to the other owner
+	l, err = f2.GetLock(wlock, owner2)
+	assert.NoError(t, err)
+	assert.Equal(t, LockWrite, l.Type)
+	assert.EqualValues(t, 0, l.Start)
+	assert.EqualValues(t, 100, l.Len)
+	assert.Error(t, f2.SetLock(wlock, owner2))
+
+	// a non-overlapping range can be locked
+	other := &Lock{Type: LockRead, Whence: io.SeekStart, Start: 100, Len: 10}
+	assert.NoError(t, f2.SetLock(other, owner2))
+
+	waited := make(chan error)
+	go func() {
+		waited <- f2.SetLockWait(wlock, owner2)
+	}()
+	select {
+	case err := <-waited:
+		t.Fatalf("SetLockWait returned early: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	unlock := &Lock{Type: LockUnlock, Whence: io.SeekStart, Start: 0, Len: 100}
+	assert.NoError(t, f1.SetLock(unlock, owner1))
+	assert.NoError(t, <-waited)
+
+	assert.NoError(t, f2.SetLock(unlock, owner2))
+	assert.NoError(t, f2.SetLock(
+		&Lock{Type: LockUnlock, Whence: io.SeekStart, Start: 100, Len: 10}, owner2))
+
+	_, err = f1.GetLock(nil, owner1)
+	assert.Error(t, err)
+	assert.Error(t, f1.SetLock(nil, owner1))
+
+	// libcephfs ignores the whence of a lock
+	endLock := &Lock{Type: LockWrite, Whence: io.SeekEnd, Start: -10, Len: 10}
+	_, err = f1.GetLock(endLock, owner1)
+	assert.ErrorIs(t, err, errInvalid)
+	assert.ErrorIs(t, f1.SetLock(endLock, owner1), errInvalid)
+	assert.ErrorIs(t, f1.SetLockWait(endLock, owner1), errInvalid)
+}
@@ -0,0 +1,96 @@
+//go:build ceph_preview
+
+package ll
+
+import (
+	"os"
+	"path"
+
+	"github.com/ceph/go-ceph/cephfs"
+)
+
+// The functions in this file combine the inode based API with path lookups
+// to offer path based operations that, unlike the functions of the cephfs
+// package, use the credentials passed to each individual call. This allows
+// a single mount to be shared safely by concurrent requests made on behalf
+// of different users, without switching the credentials of the whole mount
+// using cephfs.MountInfo.SetMountPerms.
+
+// walkParent returns a reference to the parent directory of the given path as
+// well as the name of the final path component.
+func (m *Mount) walkParent(p string, perm *cephfs.UserPerm) (*Inode, string, error) {
+	dir, name := path.Split(path.Clean(p))
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return nil, "", errInvalid
+	}
+	if dir == "" {
+		dir = "."
+	}
+	parent, _, err := m.Walk(dir, 0, 0, perm)
+	if err != nil {
+		return nil, "", err
+	}
+	return parent, name, nil
+}
+
+// OpenPath opens the file at the given path using the credentials of perm.
+// The flags are the same os flags as a local open call. If flags contains
+// os.O_CREATE the file is created with the given mode if it does not exist.
+// If perm is nil the default credentials of the mount are used.
+func (m *Mount) OpenPath(p string, flags int, mode uint32,
+	perm *cephfs.UserPerm) (*File, error) {
+
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	if flags&os.O_CREATE == 0 {
+		in, _, err := m.Walk(p, 0, 0, perm)
+		if err != nil {
+			return nil, err
+		}
+		defer in.Put()
+		return in.Open(flags, perm)
+	}
+
+	parent, name, err := m.walkParent(p, perm)
+	if err != nil {
+		return nil, err
+	}
+	defer parent.Put()
+	in, f, _, err := parent.Create(name, mode, flags, 0, 0, perm)
+	if err != nil {
+		return nil, err
+	}
+	// the open file keeps its own reference to the inode
+	in.Put()
+	return f, nil
+}
+
+// StatxPath returns the stat information of the file at the given path using
+// the credentials of perm. See cephfs.MountInfo.Statx for a description of
+// the want and flags parameters. If perm is nil the default credentials of
+// the mount are used.
+func (m *Mount) StatxPath(p string, want cephfs.StatxMask, flags cephfs.AtFlags,
+	perm *cephfs.UserPerm) (*cephfs.CephStatx, error) {
+
+	in, stx, err := m.Walk(p, want, flags, perm)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Put()
+	return stx, nil
+}
+
+// UnlinkPath removes the file at the given path using the credentials of
+// perm. If perm is nil the default credentials of the mount are used.
+func (m *Mount) UnlinkPath(p string, perm *cephfs.UserPerm) error {
+	if err := m.validate(); err != nil {
+		return err
+	}
+	parent, name, err := m.walkParent(p, perm)
+	if err != nil {
+		return err
+	}
+	defer parent.Put()
+	return parent.Unlink(name, perm)
+}
@@ -0,0 +1,85 @@
+//go:build ceph_preview
+
+package ll
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/cephfs"
+)
+
+func TestPathOperations(t *testing.T) {
+	m, done := llMount(t)
+	defer done()
+
+	fname := "/ll-path-test.txt"
+	f, err := m.OpenPath(fname, os.O_CREATE|os.O_RDWR, 0644, nil)
+	require.NoError(t, err)
+	n, err := f.WriteAt([]byte("hello"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.NoError(t, f.Close())
+
+	f, err = m.OpenPath(fname, os.O_RDONLY, 0, nil)
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	n, err = f.ReadAt(buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(buf[:n]))
+	assert.NoError(t, f.Close())
+
+	st, err := m.StatxPath(fname, cephfs.StatxBasicStats, 0, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, st.Size)
+
+	assert.NoError(t, m.UnlinkPath(fname, nil))
+	_, err = m.StatxPath(fname, cephfs.StatxBasicStats, 0, nil)
+	assert.Error(t, err)
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, m.UnlinkPath("/", nil))
+		_, err := m.OpenPath("/", os.O_CREATE|os.O_RDWR, 0644, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestPathOperationsPerms(t *testing.T) {
+	m, done := llMount(t)
+	defer done()
+
+	dname := "/ll-path-perms"
+	fname := dname + "/file.txt"
+	root, _, err := m.Walk("/", 0, 0, nil)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, root.Put()) }()
+	dir, _, err := root.Mkdir("ll-path-perms", 0700, 0, 0, nil)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, dir.Put())
+		assert.NoError(t, root.Rmdir("ll-path-perms", nil))
+	}()
+
+	f, err := m.OpenPath(fname, os.O_CREATE|os.O_WRONLY, 0600, nil)
+	require.NoError(t, err)
+	assert.NoError(t, f.Close())
+	defer func() { assert.NoError(t, m.UnlinkPath(fname, nil)) }()
+
+	uperm := cephfs.NewUserPerm(1000, 1000, nil)
+	defer uperm.Destroy()
+
+	// the directory is only accessible by its owner
+	_, err = m.StatxPath(fname, cephfs.StatxBasicStats, 0, uperm)
+	assert.Error(t, err)
+	_, err = m.OpenPath(fname, os.O_RDONLY, 0, uperm)
+	assert.Error(t, err)
+	assert.Error(t, m.UnlinkPath(fname, uperm))
+
+	// the default credentials of the mount are not affected
+	st, err := m.StatxPath(fname, cephfs.StatxBasicStats, 0, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0600, st.Mode&0777)
+}
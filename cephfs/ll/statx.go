@@ -0,0 +1,76 @@
+//go:build ceph_preview
+
+package ll
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import (
+	"github.com/ceph/go-ceph/cephfs"
+	ts "github.com/ceph/go-ceph/internal/timespec"
+)
+
+// SetAttrMask values contain bit-flags indicating what fields of a
+// CephStatx are to be applied by a SetAttr call.
+type SetAttrMask uint32
+
+const (
+	// SetAttrMode sets the mode of the inode.
+	SetAttrMode = SetAttrMask(C.CEPH_SETATTR_MODE)
+	// SetAttrUid sets the owning user of the inode.
+	SetAttrUid = SetAttrMask(C.CEPH_SETATTR_UID)
+	// SetAttrGid sets the owning group of the inode.
+	SetAttrGid = SetAttrMask(C.CEPH_SETATTR_GID)
+	// SetAttrMtime sets the modification time of the inode.
+	SetAttrMtime = SetAttrMask(C.CEPH_SETATTR_MTIME)
+	// SetAttrAtime sets the access time of the inode.
+	SetAttrAtime = SetAttrMask(C.CEPH_SETATTR_ATIME)
+	// SetAttrSize sets the size of the inode, truncating or extending it.
+	SetAttrSize = SetAttrMask(C.CEPH_SETATTR_SIZE)
+	// SetAttrCtime sets the status change time of the inode.
+	SetAttrCtime = SetAttrMask(C.CEPH_SETATTR_CTIME)
+	// SetAttrMtimeNow sets the modification time of the inode to the
+	// current time.
+	SetAttrMtimeNow = SetAttrMask(C.CEPH_SETATTR_MTIME_NOW)
+	// SetAttrAtimeNow sets the access time of the inode to the current time.
+	SetAttrAtimeNow = SetAttrMask(C.CEPH_SETATTR_ATIME_NOW)
+	// SetAttrBtime sets the creation (birth) time of the inode.
+	SetAttrBtime = SetAttrMask(C.CEPH_SETATTR_BTIME)
+)
+
+func toCephStatx(s *C.struct_ceph_statx) *cephfs.CephStatx {
+	return &cephfs.CephStatx{
+		Mask:    cephfs.StatxMask(s.stx_mask),
+		Blksize: uint32(s.stx_blksize),
+		Nlink:   uint32(s.stx_nlink),
+		Uid:     uint32(s.stx_uid),
+		Gid:     uint32(s.stx_gid),
+		Mode:    uint16(s.stx_mode),
+		Inode:   cephfs.Inode(s.stx_ino),
+		Size:    uint64(s.stx_size),
+		Blocks:  uint64(s.stx_blocks),
+		Dev:     uint64(s.stx_dev),
+		Rdev:    uint64(s.stx_rdev),
+		Atime:   cephfs.Timespec(ts.CStructToTimespec(ts.CTimespecPtr(&s.stx_atime))),
+		Ctime:   cephfs.Timespec(ts.CStructToTimespec(ts.CTimespecPtr(&s.stx_ctime))),
+		Mtime:   cephfs.Timespec(ts.CStructToTimespec(ts.CTimespecPtr(&s.stx_mtime))),
+		Btime:   cephfs.Timespec(ts.CStructToTimespec(ts.CTimespecPtr(&s.stx_btime))),
+		Version: uint64(s.stx_version),
+	}
+}
+
+func fromCephStatx(c *cephfs.CephStatx, s *C.struct_ceph_statx) {
+	s.stx_mask = C.uint32_t(c.Mask)
+	s.stx_mode = C.uint16_t(c.Mode)
+	s.stx_uid = C.uint32_t(c.Uid)
+	s.stx_gid = C.uint32_t(c.Gid)
+	s.stx_size = C.uint64_t(c.Size)
+	ts.CopyToCStruct(ts.Timespec(c.Atime), ts.CTimespecPtr(&s.stx_atime))
+	ts.CopyToCStruct(ts.Timespec(c.Ctime), ts.CTimespecPtr(&s.stx_ctime))
+	ts.CopyToCStruct(ts.Timespec(c.Mtime), ts.CTimespecPtr(&s.stx_mtime))
+	ts.CopyToCStruct(ts.Timespec(c.Btime), ts.CTimespecPtr(&s.stx_btime))
+}
@@ -0,0 +1,172 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidMountOptions is returned if MountOptions fail validation.
+var ErrInvalidMountOptions = errors.New("invalid mount options")
+
+// MountOptions collect the settings that are commonly needed to mount a
+// file system, so that they can be validated and applied in one step by
+// MountWithOptions.
+type MountOptions struct {
+	// FSName is the name of the file system to mount. If empty the default
+	// file system of the cluster is mounted.
+	FSName string
+	// Root is the absolute path of the directory of the file system that
+	// becomes the root of the mount. If empty the root of the file system
+	// is mounted.
+	Root string
+	// MountTimeout is the time to wait for the mount to be established. It
+	// is rounded up to whole seconds. If zero the configured default
+	// timeout is used.
+	MountTimeout time.Duration
+	// Metadata contains entries that are added to the client metadata of
+	// the session with the MDS, for example to identify the application
+	// using the mount.
+	Metadata map[string]string
+	// Config contains additional configuration options that are set before
+	// mounting.
+	Config map[string]string
+}
+
+// Validate checks that the mount options are well formed.
+func (o *MountOptions) Validate() error {
+	if strings.ContainsAny(o.FSName, " ,=/") {
+		return fmt.Errorf("%w: invalid file system name %q",
+			ErrInvalidMountOptions, o.FSName)
+	}
+	if o.Root != "" && !path.IsAbs(o.Root) {
+		return fmt.Errorf("%w: root %q is not an absolute path",
+			ErrInvalidMountOptions, o.Root)
+	}
+	if o.MountTimeout < 0 {
+		return fmt.Errorf("%w: negative mount timeout", ErrInvalidMountOptions)
+	}
+	for k, v := range o.Metadata {
+		if k == "" || strings.ContainsAny(k, ",=") || strings.Contains(v, ",") {
+			return fmt.Errorf("%w: invalid metadata entry %q=%q",
+				ErrInvalidMountOptions, k, v)
+		}
+	}
+	for k := range o.Config {
+		if k == "" {
+			return fmt.Errorf("%w: empty configuration option name",
+				ErrInvalidMountOptions)
+		}
+	}
+	return nil
+}
+
+// configOptions returns the configuration options that implement the mount
+// options, sorted by name.
+func (o *MountOptions) configOptions() [][2]string {
+	var opts [][2]string
+	for k, v := range o.Config {
+		opts = append(opts, [2]string{k, v})
+	}
+	if o.MountTimeout > 0 {
+		opts = append(opts, [2]string{
+			"client_mount_timeout", formatSeconds(o.MountTimeout)})
+	}
+	if len(o.Metadata) > 0 {
+		entries := make([]string, 0, len(o.Metadata))
+		for k, v := range o.Metadata {
+			entries = append(entries, k+"="+v)
+		}
+		sort.Strings(entries)
+		opts = append(opts, [2]string{"client_metadata", strings.Join(entries, ",")})
+	}
+	sort.Slice(opts, func(i, j int) bool { return opts[i][0] < opts[j][0] })
+	return opts
+}
+
+// ParseMountOptions parses mount options given in the comma separated
+// "key=value" format of the options column of fstab. The supported keys
+// are:
+//
+//	fs            - the name of the file system (FSName)
+//	root          - the root directory of the mount (Root)
+//	mount_timeout - the mount timeout in seconds (MountTimeout)
+//	metadata.KEY  - a client metadata entry named KEY (Metadata)
+//	conf.OPTION   - the configuration option named OPTION (Config)
+func ParseMountOptions(s string) (*MountOptions, error) {
+	o := &MountOptions{}
+	if s == "" {
+		return o, nil
+	}
+	for _, opt := range strings.Split(s, ",") {
+		k, v, found := strings.Cut(opt, "=")
+		if !found {
+			return nil, fmt.Errorf("%w: missing value for %q",
+				ErrInvalidMountOptions, k)
+		}
+		switch {
+		case k == "fs":
+			o.FSName = v
+		case k == "root":
+			o.Root = v
+		case k == "mount_timeout":
+			secs, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid mount timeout %q: %w",
+					ErrInvalidMountOptions, v, err)
+			}
+			o.MountTimeout = time.Duration(secs) * time.Second
+		case strings.HasPrefix(k, "metadata."):
+			if o.Metadata == nil {
+				o.Metadata = map[string]string{}
+			}
+			o.Metadata[strings.TrimPrefix(k, "metadata.")] = v
+		case strings.HasPrefix(k, "conf."):
+			if o.Config == nil {
+				o.Config = map[string]string{}
+			}
+			o.Config[strings.TrimPrefix(k, "conf.")] = v
+		default:
+			return nil, fmt.Errorf("%w: unknown option %q", ErrInvalidMountOptions, k)
+		}
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// MountWithOptions mounts the file system using the given options. The
+// options are validated before any of them is applied, so that invalid
+// options do not leave the MountInfo partially configured.
+func (mount *MountInfo) MountWithOptions(opts *MountOptions) error {
+	if mount.mount == nil {
+		return ErrNotConnected
+	}
+	if opts == nil {
+		return mount.Mount()
+	}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	for _, kv := range opts.configOptions() {
+		if err := mount.SetConfigOption(kv[0], kv[1]); err != nil {
+			return fmt.Errorf("failed to set %q: %w", kv[0], err)
+		}
+	}
+	if opts.FSName != "" {
+		if err := mount.SelectFilesystem(opts.FSName); err != nil {
+			return err
+		}
+	}
+	if opts.Root != "" {
+		return mount.MountWithRoot(opts.Root)
+	}
+	return mount.Mount()
+}
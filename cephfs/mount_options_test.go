@@ -0,0 +1,91 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMountOptions(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		o, err := ParseMountOptions("")
+		assert.NoError(t, err)
+		assert.Equal(t, &MountOptions{}, o)
+	})
+	t.Run("all", func(t *testing.T) {
+		o, err := ParseMountOptions(
+			"fs=cephfs,root=/volumes/a,mount_timeout=30," +
+				"metadata.app=test,conf.client_oc=false")
+		assert.NoError(t, err)
+		assert.Equal(t, &MountOptions{
+			FSName:       "cephfs",
+			Root:         "/volumes/a",
+			MountTimeout: 30 * time.Second,
+			Metadata:     map[string]string{"app": "test"},
+			Config:       map[string]string{"client_oc": "false"},
+		}, o)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, s := range []string{
+			"ro",
+			"bogus=1",
+			"mount_timeout=abc",
+			"root=relative",
+			"fs=a/b",
+			"metadata.=x",
+			"conf.=x",
+		} {
+			_, err := ParseMountOptions(s)
+			assert.ErrorIs(t, err, ErrInvalidMountOptions, s)
+		}
+	})
+}
+
+func TestMountOptionsConfig(t *testing.T) {
+	o := &MountOptions{
+		MountTimeout: 1500 * time.Millisecond,
+		Metadata:     map[string]string{"b": "2", "a": "1"},
+		Config:       map[string]string{"debug_client": "0"},
+	}
+	assert.Equal(t, [][2]string{
+		{"client_metadata", "a=1,b=2"},
+		{"client_mount_timeout", "2"},
+		{"debug_client", "0"},
+	}, o.configOptions())
+}
+
+func TestMountWithOptions(t *testing.T) {
+	t.Run("mount", func(t *testing.T) {
+		mount, err := CreateMount()
+		require.NoError(t, err)
+		require.NoError(t, mount.ReadDefaultConfigFile())
+		defer func() { assert.NoError(t, mount.Release()) }()
+
+		err = mount.MountWithOptions(&MountOptions{
+			Root:         "/",
+			MountTimeout: 30 * time.Second,
+			Metadata:     map[string]string{"app": "go-ceph-test"},
+		})
+		require.NoError(t, err)
+		assert.True(t, mount.IsMounted())
+		defer func() { assert.NoError(t, mount.Unmount()) }()
+
+		v, err := mount.GetConfigOption("client_metadata")
+		assert.NoError(t, err)
+		assert.Equal(t, "app=go-ceph-test", v)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		mount, err := CreateMount()
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, mount.Release()) }()
+
+		err = mount.MountWithOptions(&MountOptions{Root: "relative"})
+		assert.ErrorIs(t, err, ErrInvalidMountOptions)
+		assert.False(t, mount.IsMounted())
+	})
+}
@@ -0,0 +1,232 @@
+//go:build ceph_preview
+
+package cephfs
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <errno.h>
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ceph/go-ceph/internal/log"
+)
+
+const defaultCheckInterval = 30 * time.Second
+
+// ErrBlocklisted is returned by operations of a client that has been
+// blocklisted, for example after being evicted by the MDS.
+var ErrBlocklisted = getError(-C.ESHUTDOWN)
+
+// IsEvicted returns true if err indicates that the client has lost its
+// connection to the file system, for example because it was evicted and
+// blocklisted, and the mount needs to be re-created to recover.
+func IsEvicted(err error) bool {
+	return errors.Is(err, ErrBlocklisted) || errors.Is(err, ErrNotConnected)
+}
+
+// MountEventType identifies the kind of a MountEvent.
+type MountEventType int
+
+const (
+	// MountEvicted is emitted when the supervised mount was found to be
+	// evicted.
+	MountEvicted = MountEventType(iota + 1)
+	// MountRemounted is emitted when a new mount replaced the evicted one.
+	MountRemounted
+	// MountRemountFailed is emitted when creating a new mount failed. The
+	// remount is retried on the next check.
+	MountRemountFailed
+)
+
+// MountEvent reports a change of the state of a supervised mount.
+type MountEvent struct {
+	// Type of the event.
+	Type MountEventType
+	// Err is the error that caused the event, if any.
+	Err error
+}
+
+// SupervisorOptions control the behavior of a MountSupervisor.
+type SupervisorOptions struct {
+	// CheckInterval is the time between checks of the mount. If zero the
+	// mount is checked every 30 seconds. If negative the mount is only
+	// checked when Check is called.
+	CheckInterval time.Duration
+	// Probe is called to check the mount. It should return an error for
+	// which IsEvicted returns true if the mount needs to be re-created. If
+	// nil the root directory of the mount is stat'ed.
+	Probe func(mount *MountInfo) error
+}
+
+// MountSupervisor keeps a mount usable by detecting that the client has been
+// evicted and re-creating the mount. Long running services should call Mount
+// to get the current mount for each sequence of operations rather than
+// storing the returned MountInfo.
+type MountSupervisor struct {
+	newMount func() (*MountInfo, error)
+	probe    func(*MountInfo) error
+	events   chan MountEvent
+
+	// checkLock serializes the checks and remounts
+	checkLock sync.Mutex
+	mu        sync.RWMutex
+	mount     *MountInfo
+	aborted   bool
+	retired   []*MountInfo
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewMountSupervisor creates a mount using newMount and supervises it. The
+// newMount function must return a new, mounted, MountInfo each time it is
+// called, for example by calling MountWithOptions with the same options.
+func NewMountSupervisor(
+	newMount func() (*MountInfo, error), opts *SupervisorOptions) (*MountSupervisor, error) {
+
+	if newMount == nil {
+		return nil, errInvalid
+	}
+	mount, err := newMount()
+	if err != nil {
+		return nil, err
+	}
+	s := &MountSupervisor{
+		newMount: newMount,
+		probe:    probeRoot,
+		events:   make(chan MountEvent, 16),
+		mount:    mount,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	interval := defaultCheckInterval
+	if opts != nil {
+		if opts.CheckInterval != 0 {
+			interval = opts.CheckInterval
+		}
+		if opts.Probe != nil {
+			s.probe = opts.Probe
+		}
+	}
+	go s.run(interval)
+	return s, nil
+}
+
+func probeRoot(mount *MountInfo) error {
+	_, err := mount.Statx("/", StatxBasicStats, 0)
+	return err
+}
+
+// Mount returns the current mount.
+func (s *MountSupervisor) Mount() *MountInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mount
+}
+
+// Events returns a channel the events of the supervisor are delivered on.
+// Events are dropped if the channel is not drained.
+func (s *MountSupervisor) Events() <-chan MountEvent {
+	return s.events
+}
+
+func (s *MountSupervisor) emit(ev MountEvent) {
+	select {
+	case s.events <- ev:
+	default:
+	}
+}
+
+func (s *MountSupervisor) run(interval time.Duration) {
+	defer close(s.done)
+	if interval < 0 {
+		<-s.stop
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			_ = s.Check()
+		}
+	}
+}
+
+// Check probes the current mount and, if the client has been evicted,
+// replaces it with a new mount. The evicted mount is aborted, so that
+// operations still using it fail with ErrNotConnected, and released when
+// the supervisor is closed. Check returns the error of the probe, or of the
+// remount, or nil if the mount is usable.
+func (s *MountSupervisor) Check() error {
+	s.checkLock.Lock()
+	defer s.checkLock.Unlock()
+
+	mount := s.Mount()
+	err := s.probe(mount)
+	if err == nil || !IsEvicted(err) {
+		return err
+	}
+	if !s.aborted {
+		s.emit(MountEvent{Type: MountEvicted, Err: err})
+		// the client is fenced already, tear down what is left of it
+		if aerr := getError(C.ceph_abort_conn(mount.mount)); aerr != nil {
+			log.Warnf("failed to abort evicted mount: %v", aerr)
+		}
+		s.mu.Lock()
+		s.aborted = true
+		s.retired = append(s.retired, mount)
+		s.mu.Unlock()
+	}
+
+	newMount, err := s.newMount()
+	if err != nil {
+		s.emit(MountEvent{Type: MountRemountFailed, Err: err})
+		return err
+	}
+	s.mu.Lock()
+	s.mount = newMount
+	s.aborted = false
+	s.mu.Unlock()
+	s.emit(MountEvent{Type: MountRemounted})
+	return nil
+}
+
+// Close stops supervising, unmounts and releases the current mount and
+// releases the evicted mounts.
+func (s *MountSupervisor) Close() error {
+	select {
+	case <-s.stop:
+		return nil
+	default:
+	}
+	close(s.stop)
+	<-s.done
+
+	s.checkLock.Lock()
+	defer s.checkLock.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	if !s.aborted && s.mount.IsMounted() {
+		errs = append(errs, s.mount.Unmount())
+	}
+	if !s.aborted {
+		errs = append(errs, s.mount.Release())
+	}
+	for _, m := range s.retired {
+		errs = append(errs, m.Release())
+	}
+	s.retired = nil
+	return errors.Join(errs...)
+}
@@ -0,0 +1,82 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsEvicted(t *testing.T) {
+	assert.True(t, IsEvicted(ErrBlocklisted))
+	assert.True(t, IsEvicted(ErrNotConnected))
+	assert.False(t, IsEvicted(ErrNotExist))
+	assert.False(t, IsEvicted(nil))
+}
+
+func TestMountSupervisor(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewMountSupervisor(nil, nil)
+		assert.Error(t, err)
+		_, err = NewMountSupervisor(func() (*MountInfo, error) {
+			return nil, errors.New("no mount for you")
+		}, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("healthy", func(t *testing.T) {
+		s, err := NewMountSupervisor(func() (*MountInfo, error) {
+			return fsConnect(t), nil
+		}, &SupervisorOptions{CheckInterval: -1})
+		require.NoError(t, err)
+		m := s.Mount()
+		assert.NoError(t, s.Check())
+		assert.Equal(t, m, s.Mount())
+		assert.NoError(t, s.Close())
+		assert.NoError(t, s.Close())
+	})
+
+	t.Run("remount", func(t *testing.T) {
+		var (
+			evict    atomic.Bool
+			failNext atomic.Bool
+		)
+		s, err := NewMountSupervisor(func() (*MountInfo, error) {
+			if failNext.Swap(false) {
+				return nil, errors.New("mds unavailable")
+			}
+			return fsConnect(t), nil
+		}, &SupervisorOptions{
+			CheckInterval: -1,
+			Probe: func(m *MountInfo) error {
+				if evict.Swap(false) {
+					return ErrBlocklisted
+				}
+				return probeRoot(m)
+			},
+		})
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, s.Close()) }()
+
+		m1 := s.Mount()
+		evict.Store(true)
+		failNext.Store(true)
+		assert.Error(t, s.Check())
+		assert.Equal(t, MountEvicted, (<-s.Events()).Type)
+		assert.Equal(t, MountRemountFailed, (<-s.Events()).Type)
+		assert.False(t, m1.IsMounted())
+
+		// the aborted mount fails the probe, triggering another remount
+		assert.NoError(t, s.Check())
+		assert.Equal(t, MountRemounted, (<-s.Events()).Type)
+		m2 := s.Mount()
+		assert.NotEqual(t, m1, m2)
+		assert.True(t, m2.IsMounted())
+		_, err = m2.Statx("/", StatxBasicStats, 0)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,47 @@
+//go:build ceph_preview
+
+package cephfs
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <stdlib.h>
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	ts "github.com/ceph/go-ceph/internal/timespec"
+)
+
+// Utimens changes the last access and modification times of the file at the
+// given path with nanosecond precision. The times param is an array of
+// Timespec struct having length 2, where times[0] represents the access time
+// and times[1] represents the modification time. If flags contains
+// AtSymlinkNofollow and path refers to a symbolic link, the times of the
+// link itself are changed.
+//
+// Implements:
+//
+//	int ceph_setattrx(struct ceph_mount_info *cmount, const char *relpath,
+//	                  struct ceph_statx *stx, int mask, int flags);
+func (mount *MountInfo) Utimens(path string, times []Timespec, flags AtFlags) error {
+	if err := mount.validate(); err != nil {
+		return err
+	}
+	if len(times) != 2 {
+		return errInvalid
+	}
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	var stx C.struct_ceph_statx
+	ts.CopyToCStruct(ts.Timespec(times[0]), ts.CTimespecPtr(&stx.stx_atime))
+	ts.CopyToCStruct(ts.Timespec(times[1]), ts.CTimespecPtr(&stx.stx_mtime))
+	mask := C.CEPH_SETATTR_ATIME | C.CEPH_SETATTR_MTIME
+
+	ret := C.ceph_setattrx(mount.mount, cPath, &stx, C.int(mask), C.int(flags))
+	return getError(ret)
+}
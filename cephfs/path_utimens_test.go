@@ -0,0 +1,54 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUtimens(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	fname := "/utimens_file.txt"
+	writeFile(t, mount, fname, []byte("tick"))
+	defer func() { assert.NoError(t, mount.Unlink(fname)) }()
+
+	times := []Timespec{
+		{Sec: 1600000000, Nsec: 123456789},
+		{Sec: 1600000001, Nsec: 987654321},
+	}
+	require.NoError(t, mount.Utimens(fname, times, 0))
+	sx, err := mount.Statx(fname, StatxAtime|StatxMtime, 0)
+	require.NoError(t, err)
+	assert.Equal(t, times[0], sx.Atime)
+	assert.Equal(t, times[1], sx.Mtime)
+
+	t.Run("symlink", func(t *testing.T) {
+		lname := "/utimens_link"
+		require.NoError(t, mount.Symlink(fname, lname))
+		defer func() { assert.NoError(t, mount.Unlink(lname)) }()
+
+		ltimes := []Timespec{
+			{Sec: 1500000000, Nsec: 1},
+			{Sec: 1500000000, Nsec: 2},
+		}
+		require.NoError(t, mount.Utimens(lname, ltimes, AtSymlinkNofollow))
+		sx, err := mount.Statx(lname, StatxMtime, AtSymlinkNofollow)
+		require.NoError(t, err)
+		assert.Equal(t, ltimes[1], sx.Mtime)
+
+		// the target is unchanged
+		sx, err = mount.Statx(fname, StatxMtime, 0)
+		require.NoError(t, err)
+		assert.Equal(t, times[1], sx.Mtime)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, mount.Utimens(fname, times[:1], 0))
+		assert.ErrorIs(t, mount.Utimens("/no-such-file", times, 0), ErrNotExist)
+	})
+}
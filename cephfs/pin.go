@@ -0,0 +1,102 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	dirPinXattr            = "ceph.dir.pin"
+	dirPinDistributedXattr = "ceph.dir.pin.distributed"
+	dirPinRandomXattr      = "ceph.dir.pin.random"
+)
+
+// NoDirPin can be passed to SetDirPin to remove the export pin of a
+// directory. It is returned by GetDirPin if the directory is not pinned.
+const NoDirPin = -1
+
+// SetDirPin pins the directory at path, and the subtree below it, to the
+// MDS with the given rank. Passing NoDirPin removes the pin.
+func (mount *MountInfo) SetDirPin(path string, rank int) error {
+	if rank < NoDirPin {
+		return errInvalid
+	}
+	return mount.SetXattr(
+		path, dirPinXattr, []byte(strconv.Itoa(rank)), XattrDefault)
+}
+
+// GetDirPin returns the rank of the MDS the directory at path is pinned to
+// or NoDirPin if the directory is not pinned.
+func (mount *MountInfo) GetDirPin(path string) (int, error) {
+	value, err := mount.GetXattr(path, dirPinXattr)
+	if err != nil {
+		return NoDirPin, err
+	}
+	rank, err := strconv.Atoi(trimXattrValue(value))
+	if err != nil {
+		return NoDirPin, fmt.Errorf("invalid %s value: %w", dirPinXattr, err)
+	}
+	return rank, nil
+}
+
+// SetDistributedEphemeralPin enables or disables distributed ephemeral
+// pinning of the directory at path. When enabled, the immediate children of
+// the directory are pinned to MDS ranks based on a consistent hash of their
+// inode numbers.
+func (mount *MountInfo) SetDistributedEphemeralPin(path string, enable bool) error {
+	value := "0"
+	if enable {
+		value = "1"
+	}
+	return mount.SetXattr(
+		path, dirPinDistributedXattr, []byte(value), XattrDefault)
+}
+
+// GetDistributedEphemeralPin returns true if distributed ephemeral pinning
+// is enabled for the directory at path.
+func (mount *MountInfo) GetDistributedEphemeralPin(path string) (bool, error) {
+	value, err := mount.GetXattr(path, dirPinDistributedXattr)
+	if err != nil {
+		return false, err
+	}
+	enabled, err := strconv.Atoi(trimXattrValue(value))
+	if err != nil {
+		return false, fmt.Errorf(
+			"invalid %s value: %w", dirPinDistributedXattr, err)
+	}
+	return enabled != 0, nil
+}
+
+// SetRandomEphemeralPin sets the probability, between 0.0 and 1.0, with
+// which the directories below the directory at path are randomly pinned to
+// an MDS rank. A probability of 0.0 disables random ephemeral pinning. The
+// MDS rejects values above the mds_export_ephemeral_random_max setting.
+func (mount *MountInfo) SetRandomEphemeralPin(path string, probability float64) error {
+	if probability < 0.0 || probability > 1.0 {
+		return errInvalid
+	}
+	value := strconv.FormatFloat(probability, 'f', -1, 64)
+	return mount.SetXattr(
+		path, dirPinRandomXattr, []byte(value), XattrDefault)
+}
+
+// GetRandomEphemeralPin returns the probability with which the directories
+// below the directory at path are randomly pinned to an MDS rank.
+func (mount *MountInfo) GetRandomEphemeralPin(path string) (float64, error) {
+	value, err := mount.GetXattr(path, dirPinRandomXattr)
+	if err != nil {
+		return 0, err
+	}
+	probability, err := strconv.ParseFloat(trimXattrValue(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value: %w", dirPinRandomXattr, err)
+	}
+	return probability, nil
+}
+
+func trimXattrValue(value []byte) string {
+	return strings.TrimSpace(strings.TrimRight(string(value), "\x00"))
+}
@@ -0,0 +1,64 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirPin(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	dir := "/pin-test"
+	require.NoError(t, mount.MakeDir(dir, 0755))
+	defer func() { assert.NoError(t, mount.RemoveDir(dir)) }()
+
+	t.Run("exportPin", func(t *testing.T) {
+		rank, err := mount.GetDirPin(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, NoDirPin, rank)
+
+		assert.NoError(t, mount.SetDirPin(dir, 0))
+		rank, err = mount.GetDirPin(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, rank)
+
+		assert.NoError(t, mount.SetDirPin(dir, NoDirPin))
+		rank, err = mount.GetDirPin(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, NoDirPin, rank)
+
+		assert.Error(t, mount.SetDirPin(dir, -2))
+	})
+
+	t.Run("distributed", func(t *testing.T) {
+		assert.NoError(t, mount.SetDistributedEphemeralPin(dir, true))
+		enabled, err := mount.GetDistributedEphemeralPin(dir)
+		assert.NoError(t, err)
+		assert.True(t, enabled)
+
+		assert.NoError(t, mount.SetDistributedEphemeralPin(dir, false))
+		enabled, err = mount.GetDistributedEphemeralPin(dir)
+		assert.NoError(t, err)
+		assert.False(t, enabled)
+	})
+
+	t.Run("random", func(t *testing.T) {
+		assert.NoError(t, mount.SetRandomEphemeralPin(dir, 0.01))
+		p, err := mount.GetRandomEphemeralPin(dir)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.01, p, 0.0001)
+
+		assert.NoError(t, mount.SetRandomEphemeralPin(dir, 0))
+		p, err = mount.GetRandomEphemeralPin(dir)
+		assert.NoError(t, err)
+		assert.Zero(t, p)
+
+		assert.Error(t, mount.SetRandomEphemeralPin(dir, -0.5))
+		assert.Error(t, mount.SetRandomEphemeralPin(dir, 1.5))
+	})
+}
@@ -0,0 +1,19 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"unsafe"
+)
+
+// Pointer returns a pointer reference to an internal structure.
+// This function should NOT be used outside of go-ceph itself.
+func (mount *MountInfo) Pointer() unsafe.Pointer {
+	return unsafe.Pointer(mount.mount)
+}
+
+// Pointer returns a pointer reference to an internal structure.
+// This function should NOT be used outside of go-ceph itself.
+func (p *UserPerm) Pointer() unsafe.Pointer {
+	return unsafe.Pointer(p.userPerm)
+}
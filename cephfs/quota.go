@@ -0,0 +1,84 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	quotaMaxBytesXattr = "ceph.quota.max_bytes"
+	quotaMaxFilesXattr = "ceph.quota.max_files"
+)
+
+// Quota contains the quota limits of a directory. A limit with a value of
+// zero means that the limit is not set.
+type Quota struct {
+	// MaxBytes limits the number of bytes stored below the directory.
+	MaxBytes uint64
+	// MaxFiles limits the number of files and directories below the
+	// directory.
+	MaxFiles uint64
+}
+
+// GetQuota returns the quota limits of the directory at path. Limits that
+// are not set are returned as zero.
+func (mount *MountInfo) GetQuota(path string) (*Quota, error) {
+	maxBytes, err := mount.getQuotaLimit(path, quotaMaxBytesXattr)
+	if err != nil {
+		return nil, err
+	}
+	maxFiles, err := mount.getQuotaLimit(path, quotaMaxFilesXattr)
+	if err != nil {
+		return nil, err
+	}
+	return &Quota{MaxBytes: maxBytes, MaxFiles: maxFiles}, nil
+}
+
+// SetQuota sets the quota limits of the directory at path. Limits with a
+// value of zero are cleared.
+func (mount *MountInfo) SetQuota(path string, quota Quota) error {
+	if err := mount.setQuotaLimit(path, quotaMaxBytesXattr, quota.MaxBytes); err != nil {
+		return err
+	}
+	return mount.setQuotaLimit(path, quotaMaxFilesXattr, quota.MaxFiles)
+}
+
+// ClearQuota removes all quota limits of the directory at path.
+func (mount *MountInfo) ClearQuota(path string) error {
+	return mount.SetQuota(path, Quota{})
+}
+
+func (mount *MountInfo) getQuotaLimit(path, name string) (uint64, error) {
+	value, err := mount.GetXattr(path, name)
+	if errors.Is(err, errNoData) {
+		// newer versions of ceph report unset limits as missing xattrs
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return parseQuotaLimit(string(value))
+}
+
+func (mount *MountInfo) setQuotaLimit(path, name string, limit uint64) error {
+	// a limit of zero removes the limit. Setting an empty value is not
+	// reliable, see SetXattr.
+	value := strconv.FormatUint(limit, 10)
+	return mount.SetXattr(path, name, []byte(value), XattrDefault)
+}
+
+func parseQuotaLimit(s string) (uint64, error) {
+	s = strings.TrimRight(s, "\x00")
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quota limit: %q: %w", s, err)
+	}
+	return v, nil
+}
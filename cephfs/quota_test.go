@@ -0,0 +1,65 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuotaLimit(t *testing.T) {
+	v, err := parseQuotaLimit("1073741824")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1073741824, v)
+
+	v, err = parseQuotaLimit("100\x00")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 100, v)
+
+	v, err = parseQuotaLimit("")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, v)
+
+	_, err = parseQuotaLimit("-1")
+	assert.Error(t, err)
+	_, err = parseQuotaLimit("lots")
+	assert.Error(t, err)
+}
+
+func TestQuota(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	dir := "/quota-test"
+	require.NoError(t, mount.MakeDir(dir, 0755))
+	defer func() { assert.NoError(t, mount.RemoveDir(dir)) }()
+
+	q, err := mount.GetQuota(dir)
+	require.NoError(t, err)
+	assert.Equal(t, Quota{}, *q)
+
+	err = mount.SetQuota(dir, Quota{MaxBytes: 10 * 1024 * 1024, MaxFiles: 100})
+	assert.NoError(t, err)
+	q, err = mount.GetQuota(dir)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10*1024*1024, q.MaxBytes)
+	assert.EqualValues(t, 100, q.MaxFiles)
+
+	// clear only one of the limits
+	err = mount.SetQuota(dir, Quota{MaxFiles: 50})
+	assert.NoError(t, err)
+	q, err = mount.GetQuota(dir)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, q.MaxBytes)
+	assert.EqualValues(t, 50, q.MaxFiles)
+
+	assert.NoError(t, mount.ClearQuota(dir))
+	q, err = mount.GetQuota(dir)
+	require.NoError(t, err)
+	assert.Equal(t, Quota{}, *q)
+
+	_, err = mount.GetQuota("/quota-test-missing")
+	assert.ErrorIs(t, err, ErrNotExist)
+}
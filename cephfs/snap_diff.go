@@ -147,15 +147,26 @@ func OpenSnapDiff(config SnapDiffConfig) (*SnapDiffInfo, error) {
 		return nil, fmt.Errorf("%w: %w", ErrNotImplemented, cephOpenSnapDiffErr)
 	}
 
+	cRootPath := C.CString(config.RootPath)
+	cRelPath := C.CString(config.RelPath)
+	cSnap1 := C.CString(config.Snap1)
+	cSnap2 := C.CString(config.Snap2)
+	defer func() {
+		C.free(unsafe.Pointer(cRootPath))
+		C.free(unsafe.Pointer(cRelPath))
+		C.free(unsafe.Pointer(cSnap1))
+		C.free(unsafe.Pointer(cSnap2))
+	}()
+
 	rawCephSnapDiffInfo := &C._ceph_snapdiff_info{}
 
 	ret := C.open_snapdiff_dlsym(
 		cephOpenSnapDiff,
 		config.CMount.mount,
-		C.CString(config.RootPath),
-		C.CString(config.RelPath),
-		C.CString(config.Snap1),
-		C.CString(config.Snap2),
+		cRootPath,
+		cRelPath,
+		cSnap1,
+		cSnap2,
 		rawCephSnapDiffInfo)
 
 	if ret != 0 {
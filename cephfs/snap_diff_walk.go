@@ -0,0 +1,98 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+)
+
+// SnapDiffWalkFunc is the type of the function called by WalkSnapDiff for
+// each entry that differs between the two snapshots. The relPath argument is
+// the path of the entry relative to the RelPath of the SnapDiffConfig that
+// was passed to WalkSnapDiff.
+//
+// If the function returns fs.SkipDir for a directory entry, WalkSnapDiff
+// does not descend into that directory. If it returns fs.SkipDir for any
+// other entry, WalkSnapDiff skips the remaining entries of the directory
+// containing it. Any other error stops the walk and is returned by
+// WalkSnapDiff.
+type SnapDiffWalkFunc func(relPath string, entry *SnapDiffEntry) error
+
+// WalkSnapDiff walks the differences between the two snapshots given in the
+// config, starting at its RelPath and descending into every directory that
+// differs between the snapshots. This allows the changes of a whole tree to
+// be found without having to walk the unchanged parts of the tree.
+//
+// A directory removed between the snapshots is reported as an entry of its
+// parent, but its contents are never listed.
+func WalkSnapDiff(config SnapDiffConfig, fn SnapDiffWalkFunc) error {
+	if fn == nil {
+		return errInvalid
+	}
+	return walkSnapDiff(config, "", fn)
+}
+
+func walkSnapDiff(config SnapDiffConfig, relDir string, fn SnapDiffWalkFunc) error {
+	dirConfig := config
+	dirConfig.RelPath = path.Join(config.RelPath, relDir)
+	diff, err := OpenSnapDiff(dirConfig)
+	if relDir != "" && errors.Is(err, ErrNotExist) {
+		// the directory was removed, it has already been reported as an
+		// entry of its parent
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// collect the subdirectories first so that only a single snapdiff
+	// handle is open at any time
+	var (
+		subdirs []string
+		seen    = map[string]bool{}
+	)
+readdir:
+	for {
+		entry, err := diff.Readdir()
+		if err != nil {
+			_ = diff.Close()
+			return err
+		}
+		if entry == nil {
+			break
+		}
+		name := entry.DirEntry.Name()
+		if name == "." || name == ".." {
+			continue
+		}
+		relPath := path.Join(relDir, name)
+		err = fn(relPath, entry)
+		isDir := entry.DirEntry.DType() == DTypeDir
+		switch {
+		case isDir && errors.Is(err, fs.SkipDir):
+			continue
+		case errors.Is(err, fs.SkipDir):
+			// like filepath.WalkDir, skip the rest of the directory
+			break readdir
+		case err != nil:
+			_ = diff.Close()
+			return err
+		case isDir && !seen[relPath]:
+			// a directory can be reported once for each snapshot
+			seen[relPath] = true
+			subdirs = append(subdirs, relPath)
+		}
+	}
+	if err := diff.Close(); err != nil {
+		return err
+	}
+
+	for _, subdir := range subdirs {
+		if err := walkSnapDiff(config, subdir, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
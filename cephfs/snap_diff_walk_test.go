@@ -0,0 +1,158 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fsadmin "github.com/ceph/go-ceph/cephfs/admin"
+	"github.com/ceph/go-ceph/internal/dlsym"
+)
+
+func TestWalkSnapDiff(t *testing.T) {
+	_, cephOpenSnapDiffErr := dlsym.LookupSymbol("ceph_open_snapdiff")
+	if cephOpenSnapDiffErr != nil {
+		t.Skipf("ceph_open_snapdiff not found: %v", cephOpenSnapDiffErr)
+	}
+
+	fsa := fsadmin.NewFromConn(radosConnector.Get(t))
+	volume := "cephfs"
+
+	subname := "SubVolWalk"
+	err := fsa.CreateSubVolume(volume, NoGroup, subname, nil)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolume(volume, NoGroup, subname))
+	}()
+
+	subVolPath, err := fsa.SubVolumePath(volume, NoGroup, subname)
+	require.NoError(t, err)
+	subVolRootPath := "/volumes/_nogroup/" + subname
+	relPath := strings.TrimPrefix(subVolPath, subVolRootPath)
+
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	touch := func(p string) {
+		f, err := mount.Open(p, os.O_RDWR|os.O_CREATE, 0644)
+		require.NoError(t, err)
+		assert.NoError(t, f.Close())
+	}
+
+	// d4 is removed between the snapshots
+	d4path := path.Join(subVolPath, "d4")
+	require.NoError(t, mount.MakeDir(d4path, 0755))
+	f4path := path.Join(d4path, "f4")
+	touch(f4path)
+
+	snap1 := "WalkSnap1"
+	require.NoError(t, fsa.CreateSubVolumeSnapshot(volume, NoGroup, subname, snap1))
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolumeSnapshot(volume, NoGroup, subname, snap1))
+	}()
+
+	require.NoError(t, mount.Unlink(f4path))
+	require.NoError(t, mount.RemoveDir(d4path))
+	dpath := path.Join(subVolPath, "d1")
+	require.NoError(t, mount.MakeDir(dpath, 0755))
+	defer func() { assert.NoError(t, mount.RemoveDir(dpath)) }()
+	for _, name := range []string{"f1", "f2"} {
+		fpath := path.Join(dpath, name)
+		touch(fpath)
+		defer func() { assert.NoError(t, mount.Unlink(fpath)) }()
+	}
+	d3path := path.Join(subVolPath, "d3")
+	require.NoError(t, mount.MakeDir(d3path, 0755))
+	defer func() { assert.NoError(t, mount.RemoveDir(d3path)) }()
+	f3path := path.Join(d3path, "f3")
+	touch(f3path)
+	defer func() { assert.NoError(t, mount.Unlink(f3path)) }()
+
+	snap2 := "WalkSnap2"
+	require.NoError(t, fsa.CreateSubVolumeSnapshot(volume, NoGroup, subname, snap2))
+	defer func() {
+		assert.NoError(t, fsa.RemoveSubVolumeSnapshot(volume, NoGroup, subname, snap2))
+	}()
+
+	config := SnapDiffConfig{
+		CMount:   mount,
+		RootPath: subVolRootPath,
+		RelPath:  relPath,
+		Snap1:    snap1,
+		Snap2:    snap2,
+	}
+
+	t.Run("all", func(t *testing.T) {
+		found := []string{}
+		err := WalkSnapDiff(config, func(p string, _ *SnapDiffEntry) error {
+			found = append(found, p)
+			return nil
+		})
+		assert.NoError(t, err)
+		sort.Strings(found)
+		assert.Equal(t, []string{"d1", "d1/f1", "d1/f2", "d3", "d3/f3", "d4"}, found)
+	})
+
+	t.Run("removedDir", func(t *testing.T) {
+		found := map[string]bool{}
+		err := WalkSnapDiff(config, func(p string, _ *SnapDiffEntry) error {
+			found[p] = true
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.True(t, found["d4"])
+		assert.False(t, found["d4/f4"])
+	})
+
+	t.Run("skipDir", func(t *testing.T) {
+		found := []string{}
+		err := WalkSnapDiff(config, func(p string, e *SnapDiffEntry) error {
+			found = append(found, p)
+			if e.DirEntry.DType() == DTypeDir {
+				return fs.SkipDir
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		sort.Strings(found)
+		assert.Equal(t, []string{"d1", "d3", "d4"}, found)
+	})
+
+	t.Run("skipFile", func(t *testing.T) {
+		found := []string{}
+		err := WalkSnapDiff(config, func(p string, e *SnapDiffEntry) error {
+			found = append(found, p)
+			if e.DirEntry.DType() != DTypeDir {
+				return fs.SkipDir
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		// only the first file of d1 is reported, but the walk continues
+		// with d3
+		sort.Strings(found)
+		require.Len(t, found, 5)
+		assert.Equal(t, "d1", found[0])
+		assert.Contains(t, []string{"d1/f1", "d1/f2"}, found[1])
+		assert.Equal(t, []string{"d3", "d3/f3", "d4"}, found[2:])
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		err := WalkSnapDiff(config, func(string, *SnapDiffEntry) error {
+			return fs.ErrInvalid
+		})
+		assert.ErrorIs(t, err, fs.ErrInvalid)
+	})
+
+	t.Run("nilFunc", func(t *testing.T) {
+		assert.Error(t, WalkSnapDiff(config, nil))
+	})
+}
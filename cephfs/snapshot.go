@@ -0,0 +1,227 @@
+//go:build ceph_preview
+
+package cephfs
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <stdlib.h>
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+const snapBtimeXattr = "ceph.snap.btime"
+
+// SnapshotInfo contains information about a snapshot of a directory.
+type SnapshotInfo struct {
+	// Name of the snapshot.
+	Name string
+	// ID of the snapshot.
+	ID uint64
+	// Metadata is the custom metadata that was supplied when the snapshot
+	// was created.
+	Metadata map[string]string
+	// Created is the time the snapshot was created.
+	Created time.Time
+}
+
+// MakeSnapshot creates a snapshot with the given name of the directory at
+// path. The optional metadata is stored with the snapshot and can be
+// retrieved using GetSnapshotInfo.
+//
+// Implements:
+//
+//	int ceph_mksnap(struct ceph_mount_info *cmount, const char *path, const char *name,
+//	                mode_t mode, struct snap_metadata *snap_metadata,
+//	                size_t nr_snap_metadata);
+func (mount *MountInfo) MakeSnapshot(
+	path, name string, mode uint32, metadata map[string]string) error {
+
+	if err := mount.validate(); err != nil {
+		return err
+	}
+	if name == "" {
+		return errInvalid
+	}
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var (
+		cMeta *C.struct_snap_metadata
+		n     = len(metadata)
+	)
+	if n > 0 {
+		cMeta = (*C.struct_snap_metadata)(
+			C.malloc(C.size_t(n) * C.sizeof_struct_snap_metadata))
+		defer C.free(unsafe.Pointer(cMeta))
+		entries := unsafe.Slice(cMeta, n)
+		i := 0
+		for k, v := range metadata {
+			cKey := C.CString(k)
+			defer C.free(unsafe.Pointer(cKey))
+			cValue := C.CString(v)
+			defer C.free(unsafe.Pointer(cValue))
+			entries[i].key = cKey
+			entries[i].value = cValue
+			i++
+		}
+	}
+
+	ret := C.ceph_mksnap(
+		mount.mount, cPath, cName, C.mode_t(mode), cMeta, C.size_t(n))
+	return getError(ret)
+}
+
+// RemoveSnapshot removes the snapshot with the given name of the directory
+// at path.
+//
+// Implements:
+//
+//	int ceph_rmsnap(struct ceph_mount_info *cmount, const char *path, const char *name);
+func (mount *MountInfo) RemoveSnapshot(path, name string) error {
+	if err := mount.validate(); err != nil {
+		return err
+	}
+	if name == "" {
+		return errInvalid
+	}
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	ret := C.ceph_rmsnap(mount.mount, cPath, cName)
+	return getError(ret)
+}
+
+// GetSnapshotInfo returns information about the snapshot with the given name
+// of the directory at path.
+//
+// Implements:
+//
+//	int ceph_get_snap_info(struct ceph_mount_info *cmount, const char *path,
+//	                       struct snap_info *snap_info);
+func (mount *MountInfo) GetSnapshotInfo(path, name string) (*SnapshotInfo, error) {
+	if err := mount.validate(); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, errInvalid
+	}
+	snapDir, err := mount.snapDir()
+	if err != nil {
+		return nil, err
+	}
+	return mount.snapshotInfo(path, snapDir, name)
+}
+
+// ListSnapshots returns information about all the snapshots of the
+// directory at path. This includes snapshots taken of parent directories.
+func (mount *MountInfo) ListSnapshots(path string) ([]SnapshotInfo, error) {
+	if err := mount.validate(); err != nil {
+		return nil, err
+	}
+	snapDir, err := mount.snapDir()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := mount.OpenDir(joinSnapPath(path, snapDir))
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+	entries, err := dir.list()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]SnapshotInfo, 0, len(entries))
+	for _, name := range entries.names() {
+		if name == "." || name == ".." {
+			continue
+		}
+		info, err := mount.snapshotInfo(path, snapDir, name)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, *info)
+	}
+	return snapshots, nil
+}
+
+func (mount *MountInfo) snapshotInfo(
+	dirPath, snapDir, name string) (*SnapshotInfo, error) {
+
+	snapPath := joinSnapPath(dirPath, snapDir, name)
+	cPath := C.CString(snapPath)
+	defer C.free(unsafe.Pointer(cPath))
+
+	var cInfo C.struct_snap_info
+	ret := C.ceph_get_snap_info(mount.mount, cPath, &cInfo)
+	if ret < 0 {
+		return nil, getError(ret)
+	}
+	defer C.ceph_free_snap_info_buffer(&cInfo)
+
+	info := &SnapshotInfo{
+		Name:     name,
+		ID:       uint64(cInfo.id),
+		Metadata: map[string]string{},
+	}
+	if cInfo.nr_snap_metadata > 0 {
+		entries := unsafe.Slice(cInfo.snap_metadata, int(cInfo.nr_snap_metadata))
+		for _, e := range entries {
+			info.Metadata[C.GoString(e.key)] = C.GoString(e.value)
+		}
+	}
+
+	btime, err := mount.GetXattr(snapPath, snapBtimeXattr)
+	if err != nil {
+		return nil, err
+	}
+	info.Created, err = parseVxattrTime(string(btime))
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// snapDir returns the name of the hidden directory used to access the
+// snapshots of a directory.
+func (mount *MountInfo) snapDir() (string, error) {
+	return mount.GetConfigOption("client_snapdir")
+}
+
+func joinSnapPath(dirPath string, elems ...string) string {
+	return path.Join(append([]string{dirPath}, elems...)...)
+}
+
+// parseVxattrTime parses the value of time based vxattrs, like ceph.snap.btime,
+// which are formatted as "<seconds>.<nanoseconds>".
+func parseVxattrTime(s string) (time.Time, error) {
+	s = strings.TrimRight(s, "\x00")
+	secs, nsecs, found := strings.Cut(s, ".")
+	if !found {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %q", s)
+	}
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %q: %w", s, err)
+	}
+	nsec, err := strconv.ParseInt(nsecs, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %q: %w", s, err)
+	}
+	return time.Unix(sec, nsec), nil
+}
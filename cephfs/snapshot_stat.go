@@ -0,0 +1,59 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"errors"
+	"time"
+)
+
+// NoSnapID is the snapshot ID of files that are not part of a snapshot.
+const NoSnapID = ^uint64(1)
+
+// SnapshotStat contains the stat information of a file along with the
+// identity of the snapshot the file belongs to.
+type SnapshotStat struct {
+	// Statx is the stat information of the file.
+	Statx *CephStatx
+	// SnapID is the ID of the snapshot the file belongs to, or NoSnapID if
+	// the file is not part of a snapshot.
+	SnapID uint64
+	// Created is the time the snapshot was created. It is the zero time if
+	// the file is not part of a snapshot.
+	Created time.Time
+}
+
+// InSnapshot returns true if the file is part of a snapshot.
+func (s *SnapshotStat) InSnapshot() bool {
+	return s.SnapID != NoSnapID
+}
+
+// SnapshotStatx returns the stat information of the file at the given path
+// along with the ID and creation time of the snapshot the file belongs to.
+// This allows, for example, backup catalogs to record the precise identity
+// of the snapshot a file was copied from, rather than just its name. See
+// Statx for a description of the want and flags parameters.
+func (mount *MountInfo) SnapshotStatx(
+	path string, want StatxMask, flags AtFlags) (*SnapshotStat, error) {
+
+	stx, err := mount.Statx(path, want|StatxIno, flags)
+	if err != nil {
+		return nil, err
+	}
+	// libcephfs reports the snapshot id of the file as the device
+	s := &SnapshotStat{Statx: stx, SnapID: stx.Dev}
+	if !s.InSnapshot() {
+		return s, nil
+	}
+	btime, err := mount.GetXattr(path, snapBtimeXattr)
+	if errors.Is(err, errNoData) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	s.Created, err = parseVxattrTime(string(btime))
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,55 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotStatx(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	dir := "/snapshot-stat-test"
+	fname := path.Join(dir, "file.txt")
+	require.NoError(t, mount.MakeDir(dir, 0755))
+	defer func() { assert.NoError(t, mount.RemoveDir(dir)) }()
+	writeFile(t, mount, fname, []byte("snapshot me"))
+	defer func() { assert.NoError(t, mount.Unlink(fname)) }()
+
+	require.NoError(t, mount.MakeSnapshot(dir, "snap1", 0755, nil))
+	defer func() { assert.NoError(t, mount.RemoveSnapshot(dir, "snap1")) }()
+
+	t.Run("live", func(t *testing.T) {
+		s, err := mount.SnapshotStatx(fname, StatxBasicStats, 0)
+		require.NoError(t, err)
+		assert.False(t, s.InSnapshot())
+		assert.Equal(t, NoSnapID, s.SnapID)
+		assert.True(t, s.Created.IsZero())
+		assert.EqualValues(t, 11, s.Statx.Size)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		info, err := mount.GetSnapshotInfo(dir, "snap1")
+		require.NoError(t, err)
+		snapDir, err := mount.snapDir()
+		require.NoError(t, err)
+
+		s, err := mount.SnapshotStatx(
+			path.Join(dir, snapDir, "snap1", "file.txt"), StatxBasicStats, 0)
+		require.NoError(t, err)
+		assert.True(t, s.InSnapshot())
+		assert.Equal(t, info.ID, s.SnapID)
+		assert.Equal(t, info.Created, s.Created)
+		assert.EqualValues(t, 11, s.Statx.Size)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := mount.SnapshotStatx("/no-such-file", StatxBasicStats, 0)
+		assert.ErrorIs(t, err, ErrNotExist)
+	})
+}
@@ -0,0 +1,96 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVxattrTime(t *testing.T) {
+	ts, err := parseVxattrTime("1600000000.000000123")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1600000000, 123), ts)
+
+	ts, err = parseVxattrTime("1600000000.500000000\x00")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1600000000, 500000000), ts)
+
+	for _, s := range []string{"", "1600000000", "x.1", "1.x"} {
+		_, err = parseVxattrTime(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	dir := "/snapshot-test"
+	require.NoError(t, mount.MakeDir(dir, 0755))
+	defer func() { assert.NoError(t, mount.RemoveDir(dir)) }()
+
+	before := time.Now().Add(-time.Minute)
+	err := mount.MakeSnapshot(dir, "snap1", 0755, nil)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, mount.RemoveSnapshot(dir, "snap1")) }()
+
+	meta := map[string]string{"owner": "backup", "policy": "daily"}
+	err = mount.MakeSnapshot(dir, "snap2", 0755, meta)
+	require.NoError(t, err)
+	snap2Removed := false
+	defer func() {
+		if !snap2Removed {
+			assert.NoError(t, mount.RemoveSnapshot(dir, "snap2"))
+		}
+	}()
+
+	t.Run("info", func(t *testing.T) {
+		info, err := mount.GetSnapshotInfo(dir, "snap2")
+		require.NoError(t, err)
+		assert.Equal(t, "snap2", info.Name)
+		assert.NotZero(t, info.ID)
+		assert.Equal(t, meta, info.Metadata)
+		assert.True(t, info.Created.After(before))
+
+		info, err = mount.GetSnapshotInfo(dir, "snap1")
+		require.NoError(t, err)
+		assert.Len(t, info.Metadata, 0)
+
+		_, err = mount.GetSnapshotInfo(dir, "nope")
+		assert.ErrorIs(t, err, ErrNotExist)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		snaps, err := mount.ListSnapshots(dir)
+		require.NoError(t, err)
+		names := map[string]SnapshotInfo{}
+		for _, s := range snaps {
+			names[s.Name] = s
+		}
+		assert.Contains(t, names, "snap1")
+		assert.Contains(t, names, "snap2")
+		assert.NotEqual(t, names["snap1"].ID, names["snap2"].ID)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		assert.NoError(t, mount.RemoveSnapshot(dir, "snap2"))
+		snap2Removed = true
+		snaps, err := mount.ListSnapshots(dir)
+		assert.NoError(t, err)
+		for _, s := range snaps {
+			assert.NotEqual(t, "snap2", s.Name)
+		}
+		assert.Error(t, mount.RemoveSnapshot(dir, "snap2"))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, mount.MakeSnapshot(dir, "", 0755, nil))
+		assert.Error(t, mount.RemoveSnapshot(dir, ""))
+		_, err := mount.GetSnapshotInfo(dir, "")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,35 @@
+//go:build ceph_preview
+
+package cephfs
+
+/*
+#cgo LDFLAGS: -lcephfs
+#cgo CPPFLAGS: -D_FILE_OFFSET_BITS=64
+#include <cephfs/libcephfs.h>
+*/
+import "C"
+
+const (
+	// StatxGid requests the gid value be filled in.
+	StatxGid = StatxMask(C.CEPH_STATX_GID)
+	// StatxCtime requests the status change time value be filled in.
+	StatxCtime = StatxMask(C.CEPH_STATX_CTIME)
+)
+
+// Has returns true if all the fields selected by mask have been filled in.
+// Fields that were not requested, or that could not be provided, for
+// example because AtStatxDontSync was used, are not part of the Mask of
+// the CephStatx.
+func (c *CephStatx) Has(mask StatxMask) bool {
+	return c.Mask&mask == mask
+}
+
+// RdevMajor returns the major device number of Rdev.
+func (c *CephStatx) RdevMajor() uint32 {
+	return uint32(((c.Rdev >> 32) & 0xfffff000) | ((c.Rdev >> 8) & 0x00000fff))
+}
+
+// RdevMinor returns the minor device number of Rdev.
+func (c *CephStatx) RdevMinor() uint32 {
+	return uint32(((c.Rdev >> 12) & 0xffffff00) | (c.Rdev & 0x000000ff))
+}
@@ -0,0 +1,45 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatxHas(t *testing.T) {
+	st := &CephStatx{Mask: StatxMode | StatxUid | StatxGid}
+	assert.True(t, st.Has(StatxMode))
+	assert.True(t, st.Has(StatxUid|StatxGid))
+	assert.False(t, st.Has(StatxMode|StatxSize))
+	assert.True(t, st.Has(0))
+}
+
+func TestStatxRdev(t *testing.T) {
+	// makedev(8, 1), e.g. /dev/sda1
+	st := &CephStatx{Rdev: 0x801}
+	assert.EqualValues(t, 8, st.RdevMajor())
+	assert.EqualValues(t, 1, st.RdevMinor())
+
+	// makedev(259, 65536) uses the extended encoding
+	st = &CephStatx{Rdev: 0x10010300}
+	assert.EqualValues(t, 259, st.RdevMajor())
+	assert.EqualValues(t, 65536, st.RdevMinor())
+}
+
+func TestStatxMaskFields(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	st, err := mount.Statx("/", StatxGid|StatxCtime|StatxBtime, 0)
+	require.NoError(t, err)
+	assert.True(t, st.Has(StatxGid|StatxCtime|StatxBtime))
+	assert.NotZero(t, st.Ctime.Sec)
+	assert.NotZero(t, st.Btime.Sec)
+
+	st, err = mount.Statx("/", StatxBasicStats, AtStatxDontSync)
+	require.NoError(t, err)
+	assert.NotZero(t, st.Inode)
+}
@@ -0,0 +1,206 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path"
+	"sync"
+)
+
+// defaultWalkWorkers is the number of directories WalkDir reads in parallel
+// if not set in the WalkOptions.
+const defaultWalkWorkers = 8
+
+// WalkFunc is the type of the function called by WalkDir for each entry
+// below the root directory. The path argument is the path of the entry,
+// consisting of the root directory joined with the path of the entry
+// relative to the root. The entry contains the stat information requested
+// in the WalkOptions.
+//
+// If the function returns fs.SkipDir for a directory entry, WalkDir does not
+// descend into that directory. If it returns fs.SkipDir for any other entry,
+// the remaining entries of the containing directory are skipped. Any other
+// error stops the walk and is returned by WalkDir.
+type WalkFunc func(path string, entry *DirEntryPlus) error
+
+// WalkOptions control the behavior of WalkDir.
+type WalkOptions struct {
+	// Workers is the number of directories that are read in parallel. If
+	// zero a default number of workers is used.
+	Workers int
+	// Want is the stat information that is requested for each entry. If
+	// zero StatxBasicStats is requested.
+	Want StatxMask
+	// Flags are passed to the stat requests of the entries. See Statx for
+	// a description of the flags.
+	Flags AtFlags
+}
+
+// WalkDir walks the directory tree below root, calling fn for every entry of
+// the tree. The root directory itself is not passed to fn.
+//
+// The directories are read in parallel by a pool of workers, using
+// ReadDirPlus so that the stat information of each entry is fetched as
+// part of reading the directory. As a consequence fn is called concurrently
+// from multiple goroutines and the order the entries are visited in is not
+// defined.
+//
+// The walk stops when ctx is canceled, in which case the error of the
+// context is returned.
+func (mount *MountInfo) WalkDir(
+	ctx context.Context, root string, opts *WalkOptions, fn WalkFunc) error {
+
+	if err := mount.validate(); err != nil {
+		return err
+	}
+	if fn == nil {
+		return errInvalid
+	}
+	w := &walker{
+		mount:   mount,
+		fn:      fn,
+		workers: defaultWalkWorkers,
+		want:    StatxBasicStats,
+		queue:   []string{root},
+	}
+	if opts != nil {
+		if opts.Workers > 0 {
+			w.workers = opts.Workers
+		}
+		if opts.Want != 0 {
+			w.want = opts.Want
+		}
+		w.flags = opts.Flags
+	}
+	return w.run(ctx)
+}
+
+type walker struct {
+	mount   *MountInfo
+	fn      WalkFunc
+	workers int
+	want    StatxMask
+	flags   AtFlags
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  []string
+	active int
+	err    error
+}
+
+func (w *walker) run(ctx context.Context) error {
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	defer w.cancel()
+	w.cond = sync.NewCond(&w.mu)
+	// wake up idle workers when the walk is canceled
+	stop := context.AfterFunc(w.ctx, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.cond.Broadcast()
+	})
+	defer stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.work()
+		}()
+	}
+	wg.Wait()
+
+	if w.err != nil {
+		return w.err
+	}
+	return ctx.Err()
+}
+
+// next returns the next directory to read. It returns false if the walk is
+// done or has been canceled.
+func (w *walker) next() (string, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for len(w.queue) == 0 && w.active > 0 && w.ctx.Err() == nil {
+		w.cond.Wait()
+	}
+	if len(w.queue) == 0 || w.ctx.Err() != nil {
+		// nothing left to do, make sure the other workers notice
+		w.cond.Broadcast()
+		return "", false
+	}
+	dir := w.queue[len(w.queue)-1]
+	w.queue = w.queue[:len(w.queue)-1]
+	w.active++
+	return dir, true
+}
+
+// done records the result of reading a directory.
+func (w *walker) done(subdirs []string, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.active--
+	w.queue = append(w.queue, subdirs...)
+	if err != nil && w.err == nil && w.ctx.Err() == nil {
+		w.err = err
+		w.cancel()
+	}
+	w.cond.Broadcast()
+}
+
+func (w *walker) work() {
+	for {
+		dir, ok := w.next()
+		if !ok {
+			return
+		}
+		w.done(w.readDir(dir))
+	}
+}
+
+// readDir calls the walk function for all the entries of dir and returns
+// the sub-directories that need to be walked.
+func (w *walker) readDir(dir string) ([]string, error) {
+	d, err := w.mount.OpenDir(dir)
+	if err != nil {
+		return nil, &fs.PathError{Op: "opendir", Path: dir, Err: err}
+	}
+	defer d.Close()
+
+	var subdirs []string
+	for w.ctx.Err() == nil {
+		entry, err := d.ReadDirPlus(w.want, w.flags)
+		if err != nil {
+			return subdirs, &fs.PathError{Op: "readdir", Path: dir, Err: err}
+		}
+		if entry == nil {
+			break
+		}
+		name := entry.Name()
+		if name == "." || name == ".." {
+			continue
+		}
+		p := path.Join(dir, name)
+		err = w.fn(p, entry)
+		isDir := entry.DType() == DTypeDir
+		switch {
+		case isDir && errors.Is(err, fs.SkipDir):
+			// do not descend into the directory
+		case errors.Is(err, fs.SkipDir):
+			// skip the remaining entries of the directory
+			return subdirs, nil
+		case err != nil:
+			return subdirs, err
+		case isDir:
+			subdirs = append(subdirs, p)
+		}
+	}
+	return subdirs, nil
+}
@@ -0,0 +1,135 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkDir(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	root := "/walk-test"
+	expected := []string{}
+	var cleanup []func()
+	defer func() {
+		for i := len(cleanup) - 1; i >= 0; i-- {
+			cleanup[i]()
+		}
+	}()
+	mkdir := func(p string) {
+		require.NoError(t, mount.MakeDir(p, 0755))
+		cleanup = append(cleanup, func() { assert.NoError(t, mount.RemoveDir(p)) })
+	}
+	mkfile := func(p string) {
+		writeFile(t, mount, p, []byte("x"))
+		cleanup = append(cleanup, func() { assert.NoError(t, mount.Unlink(p)) })
+	}
+	mkdir(root)
+	for i := 0; i < 3; i++ {
+		d := path.Join(root, fmt.Sprintf("d%d", i))
+		mkdir(d)
+		expected = append(expected, d)
+		for j := 0; j < 2; j++ {
+			sd := path.Join(d, fmt.Sprintf("s%d", j))
+			mkdir(sd)
+			expected = append(expected, sd)
+			for k := 0; k < 3; k++ {
+				f := path.Join(sd, fmt.Sprintf("f%d", k))
+				mkfile(f)
+				expected = append(expected, f)
+			}
+		}
+	}
+	sort.Strings(expected)
+
+	walk := func(opts *WalkOptions, fn WalkFunc) ([]string, error) {
+		var (
+			mu    sync.Mutex
+			found []string
+		)
+		err := mount.WalkDir(context.Background(), root, opts,
+			func(p string, e *DirEntryPlus) error {
+				mu.Lock()
+				found = append(found, p)
+				mu.Unlock()
+				if fn != nil {
+					return fn(p, e)
+				}
+				return nil
+			})
+		sort.Strings(found)
+		return found, err
+	}
+
+	t.Run("all", func(t *testing.T) {
+		for _, workers := range []int{0, 1, 4} {
+			found, err := walk(&WalkOptions{Workers: workers}, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, found)
+		}
+	})
+
+	t.Run("statx", func(t *testing.T) {
+		_, err := walk(&WalkOptions{Want: StatxSize}, func(p string, e *DirEntryPlus) error {
+			if e.DType() == DTypeReg {
+				assert.EqualValues(t, 1, e.Statx().Size, p)
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("skipDir", func(t *testing.T) {
+		found, err := walk(nil, func(p string, e *DirEntryPlus) error {
+			if e.DType() == DTypeDir && path.Base(p) == "s0" {
+				return fs.SkipDir
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		for _, p := range found {
+			assert.NotContains(t, p, "/s0/")
+		}
+		assert.Len(t, found, len(expected)-9)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		_, err := walk(nil, func(p string, e *DirEntryPlus) error {
+			if e.DType() == DTypeReg {
+				return errStop
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, errStop)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := mount.WalkDir(ctx, root, nil, func(string, *DirEntryPlus) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := mount.WalkDir(context.Background(), root, nil, nil)
+		assert.Error(t, err)
+		err = mount.WalkDir(context.Background(), "/walk-test-missing", nil,
+			func(string, *DirEntryPlus) error { return nil })
+		assert.ErrorIs(t, err, ErrNotExist)
+	})
+}
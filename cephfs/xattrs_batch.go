@@ -0,0 +1,107 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"fmt"
+	"sort"
+)
+
+// GetXattrs returns the names and values of all the extended attributes of
+// the file at the supplied path.
+func (mount *MountInfo) GetXattrs(path string) (map[string][]byte, error) {
+	names, err := mount.ListXattr(path)
+	if err != nil {
+		return nil, err
+	}
+	return getXattrs(names, func(name string) ([]byte, error) {
+		return mount.GetXattr(path, name)
+	})
+}
+
+// SetXattrs sets all the given extended attributes on the file at the
+// supplied path. The attributes are set in the order of their names and
+// setting the attributes stops at the first failure.
+func (mount *MountInfo) SetXattrs(
+	path string, xattrs map[string][]byte, flags XattrFlags) error {
+
+	return setXattrs(xattrs, func(name string, value []byte) error {
+		return mount.SetXattr(path, name, value, flags)
+	})
+}
+
+// RemoveXattrs removes all the named extended attributes from the file at
+// the supplied path. Removing the attributes stops at the first failure.
+func (mount *MountInfo) RemoveXattrs(path string, names []string) error {
+	return removeXattrs(names, func(name string) error {
+		return mount.RemoveXattr(path, name)
+	})
+}
+
+// GetXattrs returns the names and values of all the extended attributes of
+// the open file.
+func (f *File) GetXattrs() (map[string][]byte, error) {
+	names, err := f.ListXattr()
+	if err != nil {
+		return nil, err
+	}
+	return getXattrs(names, f.GetXattr)
+}
+
+// SetXattrs sets all the given extended attributes on the open file. The
+// attributes are set in the order of their names and setting the attributes
+// stops at the first failure.
+func (f *File) SetXattrs(xattrs map[string][]byte, flags XattrFlags) error {
+	return setXattrs(xattrs, func(name string, value []byte) error {
+		return f.SetXattr(name, value, flags)
+	})
+}
+
+// RemoveXattrs removes all the named extended attributes from the open file.
+// Removing the attributes stops at the first failure.
+func (f *File) RemoveXattrs(names []string) error {
+	return removeXattrs(names, f.RemoveXattr)
+}
+
+func getXattrs(
+	names []string, get func(string) ([]byte, error)) (map[string][]byte, error) {
+
+	xattrs := make(map[string][]byte, len(names))
+	for _, name := range names {
+		value, err := get(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		xattrs[name] = value
+	}
+	return xattrs, nil
+}
+
+func setXattrs(xattrs map[string][]byte, set func(string, []byte) error) error {
+	if len(xattrs) == 0 {
+		return ErrEmptyArgument
+	}
+	names := make([]string, 0, len(xattrs))
+	for name := range xattrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := set(name, xattrs[name]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func removeXattrs(names []string, remove func(string) error) error {
+	if len(names) == 0 {
+		return ErrEmptyArgument
+	}
+	for _, name := range names {
+		if err := remove(name); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
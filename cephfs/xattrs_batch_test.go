@@ -0,0 +1,72 @@
+//go:build ceph_preview
+
+package cephfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXattrsBatch(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+
+	fname := "xattrs-batch.txt"
+	f, err := mount.Open(fname, os.O_RDWR|os.O_CREATE, 0644)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, f.Close())
+		assert.NoError(t, mount.Unlink(fname))
+	}()
+
+	xattrs := map[string][]byte{
+		"user.batch.one":   []byte("1"),
+		"user.batch.two":   []byte("two"),
+		"user.batch.three": []byte("three3"),
+	}
+
+	t.Run("path", func(t *testing.T) {
+		assert.NoError(t, mount.SetXattrs(fname, xattrs, XattrDefault))
+		got, err := mount.GetXattrs(fname)
+		assert.NoError(t, err)
+		for k, v := range xattrs {
+			assert.Equal(t, v, got[k], k)
+		}
+
+		// creating existing xattrs fails and names the failing xattr
+		err = mount.SetXattrs(fname, xattrs, XattrCreate)
+		assert.ErrorContains(t, err, "user.batch.one")
+
+		assert.NoError(t, mount.RemoveXattrs(fname,
+			[]string{"user.batch.one", "user.batch.two", "user.batch.three"}))
+		got, err = mount.GetXattrs(fname)
+		assert.NoError(t, err)
+		for k := range xattrs {
+			assert.NotContains(t, got, k)
+		}
+
+		assert.ErrorIs(t, mount.RemoveXattrs(fname, []string{"user.batch.one"}),
+			errNoData)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		assert.NoError(t, f.SetXattrs(xattrs, XattrDefault))
+		got, err := f.GetXattrs()
+		assert.NoError(t, err)
+		for k, v := range xattrs {
+			assert.Equal(t, v, got[k], k)
+		}
+		assert.NoError(t, f.RemoveXattrs(
+			[]string{"user.batch.one", "user.batch.two", "user.batch.three"}))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.ErrorIs(t, mount.SetXattrs(fname, nil, XattrDefault), ErrEmptyArgument)
+		assert.ErrorIs(t, mount.RemoveXattrs(fname, nil), ErrEmptyArgument)
+		assert.ErrorIs(t, f.SetXattrs(nil, XattrDefault), ErrEmptyArgument)
+		assert.ErrorIs(t, f.RemoveXattrs(nil), ErrEmptyArgument)
+	})
+}
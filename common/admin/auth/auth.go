@@ -0,0 +1,146 @@
+//go:build ceph_preview
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// ErrNoEntry may be returned if ceph does not return the keyring entry
+// of an entity.
+var ErrNoEntry = errors.New("no keyring entry returned")
+
+// Caps maps the daemon types ("mon", "mgr", "osd", "mds") to the
+// capabilities an entity has on those daemons, for example "profile rbd".
+type Caps map[string]string
+
+// args returns the caps as the alternating daemon type and capability
+// arguments of the auth commands.
+func (c Caps) args() []string {
+	types := make([]string, 0, len(c))
+	for t := range c {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	a := make([]string, 0, 2*len(c))
+	for _, t := range types {
+		a = append(a, t, c[t])
+	}
+	return a
+}
+
+// KeyringEntry is the key and capabilities of an entity.
+type KeyringEntry struct {
+	// Entity is the name of the entity, for example "client.admin".
+	Entity string `json:"entity"`
+	Key    string `json:"key"`
+	Caps   Caps   `json:"caps"`
+}
+
+// Keyring returns the entry in the format of a keyring file.
+func (e *KeyringEntry) Keyring() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s]\n\tkey = %s\n", e.Entity, e.Key)
+	c := e.Caps.args()
+	for i := 0; i < len(c); i += 2 {
+		fmt.Fprintf(&b, "\tcaps %s = %q\n", c[i], c[i+1])
+	}
+	return b.String()
+}
+
+func parseKeyring(res commands.Response) ([]KeyringEntry, error) {
+	// the status reports what was exported, it is not an error
+	l := []KeyringEntry{}
+	if err := res.Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func parseKeyringEntry(res commands.Response) (*KeyringEntry, error) {
+	l, err := parseKeyring(res)
+	if err != nil {
+		return nil, err
+	}
+	if len(l) == 0 {
+		return nil, ErrNoEntry
+	}
+	return &l[0], nil
+}
+
+// Get returns the keyring entry of an entity.
+//
+// Similar To:
+//
+//	ceph auth get <entity>
+func (aa *Admin) Get(entity string) (*KeyringEntry, error) {
+	m := map[string]string{
+		"prefix": "auth get",
+		"entity": entity,
+		"format": "json",
+	}
+	return parseKeyringEntry(commands.MarshalMonCommand(aa.conn, m))
+}
+
+// GetOrCreate returns the keyring entry of an entity, creating the entity
+// with a new key and the given caps if it does not exist. An error is
+// returned if the entity exists with different caps.
+//
+// Similar To:
+//
+//	ceph auth get-or-create <entity> [<type> <caps>...]
+func (aa *Admin) GetOrCreate(entity string, caps Caps) (*KeyringEntry, error) {
+	m := map[string]interface{}{
+		"prefix": "auth get-or-create",
+		"entity": entity,
+		"caps":   caps.args(),
+		"format": "json",
+	}
+	return parseKeyringEntry(commands.MarshalMonCommand(aa.conn, m))
+}
+
+// UpdateCaps will replace the caps of an entity. Caps of daemon types that
+// are not given are removed.
+//
+// Similar To:
+//
+//	ceph auth caps <entity> <type> <caps> [<type> <caps>...]
+func (aa *Admin) UpdateCaps(entity string, caps Caps) error {
+	m := map[string]interface{}{
+		"prefix": "auth caps",
+		"entity": entity,
+		"caps":   caps.args(),
+	}
+	return commands.MarshalMonCommand(aa.conn, m).NoBody().End()
+}
+
+// Export returns the keyring entries of all entities.
+//
+// Similar To:
+//
+//	ceph auth export
+func (aa *Admin) Export() ([]KeyringEntry, error) {
+	m := map[string]string{
+		"prefix": "auth export",
+		"format": "json",
+	}
+	return parseKeyring(commands.MarshalMonCommand(aa.conn, m))
+}
+
+// Remove will delete an entity and its key.
+//
+// Similar To:
+//
+//	ceph auth rm <entity>
+func (aa *Admin) Remove(entity string) error {
+	m := map[string]string{
+		"prefix": "auth rm",
+		"entity": entity,
+	}
+	return commands.MarshalMonCommand(aa.conn, m).NoBody().End()
+}
@@ -0,0 +1,23 @@
+//go:build !(octopus || pacific || quincy) && ceph_preview
+
+package auth
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// RotateKey will replace the key of an entity with a new one, keeping its
+// caps, and return the updated keyring entry. Clients using the old key
+// can no longer authenticate.
+//
+// Similar To:
+//
+//	ceph auth rotate <entity>
+func (aa *Admin) RotateKey(entity string) (*KeyringEntry, error) {
+	m := map[string]string{
+		"prefix": "auth rotate",
+		"entity": entity,
+		"format": "json",
+	}
+	return parseKeyringEntry(commands.MarshalMonCommand(aa.conn, m))
+}
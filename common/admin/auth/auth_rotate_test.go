@@ -0,0 +1,29 @@
+//go:build !(octopus || pacific || quincy) && ceph_preview
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateKey(t *testing.T) {
+	ra := radosConnector.Get(t)
+	aa := NewFromConn(ra)
+
+	entity := "client.go-ceph-rotate-test"
+	caps := Caps{"mon": "allow r"}
+	e, err := aa.GetOrCreate(entity, caps)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, aa.Remove(entity))
+	}()
+
+	e2, err := aa.RotateKey(entity)
+	require.NoError(t, err)
+	assert.Equal(t, entity, e2.Entity)
+	assert.Equal(t, caps, e2.Caps)
+	assert.NotEqual(t, e.Key, e2.Key)
+}
@@ -0,0 +1,111 @@
+//go:build ceph_preview
+
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func TestCapsArgs(t *testing.T) {
+	c := Caps{"osd": "profile rbd", "mon": "profile rbd", "mgr": "profile rbd pool=x"}
+	assert.Equal(t, []string{
+		"mgr", "profile rbd pool=x",
+		"mon", "profile rbd",
+		"osd", "profile rbd",
+	}, c.args())
+	assert.Empty(t, Caps(nil).args())
+}
+
+func TestParseKeyring(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(authGetJSON1), "exported keyring for client.foo", nil)
+		e, err := parseKeyringEntry(r)
+		require.NoError(t, err)
+		assert.Equal(t, "client.foo", e.Entity)
+		assert.Equal(t, "AQBkN2dmAAAAABAAc2lXq0yD1nlJbq9qVh+Xkg==", e.Key)
+		assert.Equal(t, Caps{"mon": "profile rbd", "osd": "profile rbd pool=rbd"}, e.Caps)
+		assert.Equal(t, `[client.foo]
+	key = AQBkN2dmAAAAABAAc2lXq0yD1nlJbq9qVh+Xkg==
+	caps mon = "profile rbd"
+	caps osd = "profile rbd pool=rbd"
+`, e.Keyring())
+	})
+	t.Run("empty", func(t *testing.T) {
+		r := commands.NewResponse([]byte(`[]`), "", nil)
+		_, err := parseKeyringEntry(r)
+		assert.ErrorIs(t, err, ErrNoEntry)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		l, err := parseKeyring(r)
+		assert.Error(t, err)
+		assert.Nil(t, l)
+	})
+}
+
+func TestAuthEntity(t *testing.T) {
+	ra := radosConnector.Get(t)
+	aa := NewFromConn(ra)
+
+	entity := "client.go-ceph-auth-test"
+	caps := Caps{"mon": "allow r", "osd": "allow rw pool=foo"}
+	e, err := aa.GetOrCreate(entity, caps)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, aa.Remove(entity))
+		_, err := aa.Get(entity)
+		assert.Error(t, err)
+	}()
+	assert.Equal(t, entity, e.Entity)
+	assert.NotEmpty(t, e.Key)
+	assert.Equal(t, caps, e.Caps)
+
+	// the existing entry is returned
+	e2, err := aa.GetOrCreate(entity, caps)
+	require.NoError(t, err)
+	assert.Equal(t, e, e2)
+
+	// but not with other caps
+	_, err = aa.GetOrCreate(entity, Caps{"mon": "allow *"})
+	assert.Error(t, err)
+
+	caps = Caps{"mon": "profile rbd"}
+	err = aa.UpdateCaps(entity, caps)
+	require.NoError(t, err)
+	e2, err = aa.Get(entity)
+	require.NoError(t, err)
+	assert.Equal(t, caps, e2.Caps)
+	assert.Equal(t, e.Key, e2.Key)
+
+	l, err := aa.Export()
+	require.NoError(t, err)
+	found := false
+	for _, x := range l {
+		if x.Entity == entity {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+var authGetJSON1 = `
+[
+    {
+        "entity": "client.foo",
+        "key": "AQBkN2dmAAAAABAAc2lXq0yD1nlJbq9qVh+Xkg==",
+        "caps": {
+            "mon": "profile rbd",
+            "osd": "profile rbd pool=rbd"
+        }
+    }
+]
+`
@@ -0,0 +1,6 @@
+/*
+Package auth from common/admin contains a set of APIs used to manage the
+cephx authentication entities, their keys and capabilities, of a Ceph
+cluster.
+*/
+package auth
@@ -0,0 +1,111 @@
+//go:build ceph_preview
+
+package cluster
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// ClassUsage is the raw capacity and usage of the OSDs of a device class.
+type ClassUsage struct {
+	TotalBytes        uint64  `json:"total_bytes"`
+	TotalAvailBytes   uint64  `json:"total_avail_bytes"`
+	TotalUsedBytes    uint64  `json:"total_used_bytes"`
+	TotalUsedRawBytes uint64  `json:"total_used_raw_bytes"`
+	TotalUsedRawRatio float64 `json:"total_used_raw_ratio"`
+}
+
+// Usage is the raw capacity and usage of all the OSDs of the cluster.
+type Usage struct {
+	ClassUsage
+	NumOSDs            int `json:"num_osds"`
+	NumPerPoolOSDs     int `json:"num_per_pool_osds"`
+	NumPerPoolOmapOSDs int `json:"num_per_pool_omap_osds"`
+}
+
+// PoolUsage is the usage of a pool.
+type PoolUsage struct {
+	// Stored is the number of bytes stored by clients, before replication
+	// or erasure coding.
+	Stored     uint64 `json:"stored"`
+	StoredData uint64 `json:"stored_data"`
+	StoredOmap uint64 `json:"stored_omap"`
+	Objects    uint64 `json:"objects"`
+	// BytesUsed is the raw capacity used, after replication or erasure
+	// coding.
+	BytesUsed     uint64 `json:"bytes_used"`
+	DataBytesUsed uint64 `json:"data_bytes_used"`
+	OmapBytesUsed uint64 `json:"omap_bytes_used"`
+	KBUsed        uint64 `json:"kb_used"`
+	// PercentUsed is the fraction, from 0 to 1, of the capacity available to
+	// the pool that is used.
+	PercentUsed float64 `json:"percent_used"`
+	// MaxAvail is the number of bytes clients can still store in the pool.
+	MaxAvail uint64 `json:"max_avail"`
+
+	// the fields below are only set with details
+
+	QuotaObjects       uint64 `json:"quota_objects"`
+	QuotaBytes         uint64 `json:"quota_bytes"`
+	Dirty              uint64 `json:"dirty"`
+	Rd                 uint64 `json:"rd"`
+	RdBytes            uint64 `json:"rd_bytes"`
+	Wr                 uint64 `json:"wr"`
+	WrBytes            uint64 `json:"wr_bytes"`
+	CompressBytesUsed  uint64 `json:"compress_bytes_used"`
+	CompressUnderBytes uint64 `json:"compress_under_bytes"`
+	StoredRaw          uint64 `json:"stored_raw"`
+	AvailRaw           uint64 `json:"avail_raw"`
+}
+
+// Pool is a pool and its usage.
+type Pool struct {
+	Name  string    `json:"name"`
+	ID    int64     `json:"id"`
+	Stats PoolUsage `json:"stats"`
+}
+
+// Df is the capacity and usage of the cluster.
+type Df struct {
+	Stats Usage `json:"stats"`
+	// StatsByClass maps the device classes, like "hdd" or "ssd", to their
+	// usage.
+	StatsByClass map[string]ClassUsage `json:"stats_by_class"`
+	Pools        []Pool                `json:"pools"`
+}
+
+// Pool returns the named pool, or nil if there is no such pool.
+func (d *Df) Pool(name string) *Pool {
+	for i := range d.Pools {
+		if d.Pools[i].Name == name {
+			return &d.Pools[i]
+		}
+	}
+	return nil
+}
+
+func parseDf(res commands.Response) (*Df, error) {
+	d := &Df{}
+	if err := res.NoStatus().Unmarshal(d).End(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Df returns the capacity and usage of the cluster, per device class and
+// per pool. If detail is true, the quotas and I/O counters of the pools are
+// returned too.
+//
+// Similar To:
+//
+//	ceph df [detail]
+func (ca *Admin) Df(detail bool) (*Df, error) {
+	m := map[string]string{
+		"prefix": "df",
+		"format": "json",
+	}
+	if detail {
+		m["detail"] = "detail"
+	}
+	return parseDf(commands.MarshalMonCommand(ca.conn, m))
+}
@@ -0,0 +1,143 @@
+//go:build ceph_preview
+
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func TestParseDf(t *testing.T) {
+	t.Run("detail", func(t *testing.T) {
+		r := commands.NewResponse([]byte(dfDetailJSON1), "", nil)
+		d, err := parseDf(r)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(107369988096), d.Stats.TotalBytes)
+		assert.Equal(t, uint64(106263408640), d.Stats.TotalAvailBytes)
+		assert.Equal(t, 3, d.Stats.NumOSDs)
+		assert.InDelta(t, 0.0103, d.Stats.TotalUsedRawRatio, 0.0001)
+		if assert.Contains(t, d.StatsByClass, "hdd") {
+			assert.Equal(t, uint64(107369988096), d.StatsByClass["hdd"].TotalBytes)
+		}
+		require.Len(t, d.Pools, 2)
+		p := d.Pool("rbd")
+		if assert.NotNil(t, p) {
+			assert.Equal(t, int64(2), p.ID)
+			assert.Equal(t, uint64(4194304), p.Stats.Stored)
+			assert.Equal(t, uint64(12582912), p.Stats.BytesUsed)
+			assert.Equal(t, uint64(1), p.Stats.Objects)
+			assert.Equal(t, uint64(33626013696), p.Stats.MaxAvail)
+			assert.Equal(t, uint64(1073741824), p.Stats.QuotaBytes)
+			assert.Equal(t, uint64(4096), p.Stats.WrBytes)
+		}
+		assert.Nil(t, d.Pool("nope"))
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		d, err := parseDf(r)
+		assert.Error(t, err)
+		assert.Nil(t, d)
+	})
+}
+
+func TestDf(t *testing.T) {
+	ra := radosConnector.Get(t)
+	ca := NewFromConn(ra)
+
+	d, err := ca.Df(false)
+	require.NoError(t, err)
+	assert.NotZero(t, d.Stats.TotalBytes)
+	assert.NotEmpty(t, d.Pools)
+
+	d, err = ca.Df(true)
+	require.NoError(t, err)
+	assert.NotZero(t, d.Stats.TotalBytes)
+}
+
+var dfDetailJSON1 = `
+{
+    "stats": {
+        "total_bytes": 107369988096,
+        "total_avail_bytes": 106263408640,
+        "total_used_bytes": 32505856,
+        "total_used_raw_bytes": 1106579456,
+        "total_used_raw_ratio": 0.010306227,
+        "num_osds": 3,
+        "num_per_pool_osds": 3,
+        "num_per_pool_omap_osds": 3
+    },
+    "stats_by_class": {
+        "hdd": {
+            "total_bytes": 107369988096,
+            "total_avail_bytes": 106263408640,
+            "total_used_bytes": 32505856,
+            "total_used_raw_bytes": 1106579456,
+            "total_used_raw_ratio": 0.010306227
+        }
+    },
+    "pools": [
+        {
+            "name": ".mgr",
+            "id": 1,
+            "stats": {
+                "stored": 459280,
+                "stored_data": 459280,
+                "stored_omap": 0,
+                "objects": 2,
+                "kb_used": 1348,
+                "bytes_used": 1377840,
+                "data_bytes_used": 1377840,
+                "omap_bytes_used": 0,
+                "percent_used": 1.3658e-05,
+                "max_avail": 33626013696,
+                "quota_objects": 0,
+                "quota_bytes": 0,
+                "dirty": 0,
+                "rd": 126,
+                "rd_bytes": 217088,
+                "wr": 137,
+                "wr_bytes": 2289664,
+                "compress_bytes_used": 0,
+                "compress_under_bytes": 0,
+                "stored_raw": 1377840,
+                "avail_raw": 100878041088
+            }
+        },
+        {
+            "name": "rbd",
+            "id": 2,
+            "stats": {
+                "stored": 4194304,
+                "stored_data": 4194304,
+                "stored_omap": 0,
+                "objects": 1,
+                "kb_used": 12288,
+                "bytes_used": 12582912,
+                "data_bytes_used": 12582912,
+                "omap_bytes_used": 0,
+                "percent_used": 0.00012472,
+                "max_avail": 33626013696,
+                "quota_objects": 0,
+                "quota_bytes": 1073741824,
+                "dirty": 0,
+                "rd": 0,
+                "rd_bytes": 0,
+                "wr": 1,
+                "wr_bytes": 4096,
+                "compress_bytes_used": 0,
+                "compress_under_bytes": 0,
+                "stored_raw": 12582912,
+                "avail_raw": 100878041088
+            }
+        }
+    ]
+}
+`
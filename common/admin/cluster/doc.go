@@ -0,0 +1,5 @@
+/*
+Package cluster from common/admin contains a set of APIs used to query
+cluster wide information, like the capacity and usage, of a Ceph cluster.
+*/
+package cluster
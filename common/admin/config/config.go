@@ -0,0 +1,166 @@
+//go:build ceph_preview
+
+package config
+
+import (
+	"strings"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// The who argument of the functions below names the section of the
+// configuration an option applies to. It is "global", a daemon type like
+// "osd", or a daemon name like "osd.1". It may be followed by a mask
+// limiting the option to some daemons, as in "osd/host:node1" or
+// "osd/class:ssd".
+
+// Option is an option set in the configuration database.
+type Option struct {
+	// Section is the section the option applies to, for example "global",
+	// "osd" or "osd.1".
+	Section string `json:"section"`
+	// Mask limits the option to some daemons of the section, for example
+	// "host:node1" or "class:ssd".
+	Mask  string `json:"mask"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Level is "basic", "advanced" or "dev".
+	Level              string `json:"level"`
+	CanUpdateAtRuntime bool   `json:"can_update_at_runtime"`
+	LocationType       string `json:"location_type"`
+	LocationValue      string `json:"location_value"`
+}
+
+// Override is a value of an option that is overridden by a source of higher
+// priority.
+type Override struct {
+	Source string `json:"source"`
+	Value  string `json:"value"`
+}
+
+// DaemonOption is an option of the configuration of a running daemon.
+type DaemonOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Source is where the value comes from, for example "default", "file",
+	// "mon" or "override".
+	Source    string     `json:"source"`
+	Overrides []Override `json:"overrides"`
+	Ignores   []Override `json:"ignores"`
+}
+
+func parseValue(res commands.Response) (string, error) {
+	if err := res.NoStatus().End(); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(res.Body())), nil
+}
+
+func parseOptions(res commands.Response) ([]Option, error) {
+	l := []Option{}
+	if err := res.NoStatus().Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func parseDaemonOptions(res commands.Response) ([]DaemonOption, error) {
+	l := []DaemonOption{}
+	if err := res.NoStatus().Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Get returns the value of an option that applies to who, taking into
+// account the defaults and the sections that who inherits from.
+//
+// Similar To:
+//
+//	ceph config get <who> <name>
+func (ca *Admin) Get(who, name string) (string, error) {
+	m := map[string]string{
+		"prefix": "config get",
+		"who":    who,
+		"key":    name,
+	}
+	return parseValue(commands.MarshalMonCommand(ca.conn, m))
+}
+
+// Set will set an option in the configuration database. Unless force is
+// true, unknown options and options that can not be set in the configuration
+// database are rejected.
+//
+// Similar To:
+//
+//	ceph config set <who> <name> <value> [--force]
+func (ca *Admin) Set(who, name, value string, force bool) error {
+	m := map[string]interface{}{
+		"prefix": "config set",
+		"who":    who,
+		"name":   name,
+		"value":  value,
+	}
+	if force {
+		m["force"] = true
+	}
+	return commands.MarshalMonCommand(ca.conn, m).NoData().End()
+}
+
+// Remove will remove an option from the configuration database, restoring
+// the value inherited from other sections or the default.
+//
+// Similar To:
+//
+//	ceph config rm <who> <name>
+func (ca *Admin) Remove(who, name string) error {
+	m := map[string]string{
+		"prefix": "config rm",
+		"who":    who,
+		"name":   name,
+	}
+	return commands.MarshalMonCommand(ca.conn, m).NoData().End()
+}
+
+// Dump returns all the options set in the configuration database.
+//
+// Similar To:
+//
+//	ceph config dump
+func (ca *Admin) Dump() ([]Option, error) {
+	m := map[string]string{
+		"prefix": "config dump",
+		"format": "json",
+	}
+	return parseOptions(commands.MarshalMonCommand(ca.conn, m))
+}
+
+// Show returns the options of the running daemon with the given name, for
+// example "osd.1", that are not set to their defaults.
+//
+// Similar To:
+//
+//	ceph config show <who>
+func (ca *Admin) Show(who string) ([]DaemonOption, error) {
+	m := map[string]string{
+		"prefix": "config show",
+		"who":    who,
+		"format": "json",
+	}
+	return parseDaemonOptions(commands.MarshalMgrCommand(ca.conn, m))
+}
+
+// ShowValue returns the value of an option of the running daemon with the
+// given name.
+//
+// Similar To:
+//
+//	ceph config show <who> <name>
+func (ca *Admin) ShowValue(who, name string) (string, error) {
+	m := map[string]string{
+		"prefix": "config show",
+		"who":    who,
+		"key":    name,
+	}
+	return parseValue(commands.MarshalMgrCommand(ca.conn, m))
+}
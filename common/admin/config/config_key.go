@@ -0,0 +1,126 @@
+//go:build ceph_preview
+
+package config
+
+import (
+	"errors"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+const enoent = -2
+
+// isNotFound returns true if err is the ENOENT error of a ceph command.
+func isNotFound(err error) bool {
+	var ce interface{ ErrorCode() int }
+	return errors.As(err, &ce) && ce.ErrorCode() == enoent
+}
+
+func parseKeys(res commands.Response) ([]string, error) {
+	l := []string{}
+	if err := res.NoStatus().Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func parseKeyDump(res commands.Response) (map[string]string, error) {
+	d := map[string]string{}
+	if err := res.NoStatus().Unmarshal(&d).End(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// GetKey returns the value stored under key in the config-key store. The
+// value is returned as stored, it need not be text.
+//
+// Similar To:
+//
+//	ceph config-key get <key>
+func (ca *Admin) GetKey(key string) ([]byte, error) {
+	m := map[string]string{
+		"prefix": "config-key get",
+		"key":    key,
+	}
+	res := commands.MarshalMonCommand(ca.conn, m)
+	if err := res.End(); err != nil {
+		return nil, err
+	}
+	return res.Body(), nil
+}
+
+// SetKey will store value under key in the config-key store, replacing any
+// existing value. The value is passed as input data and need not be text.
+//
+// Similar To:
+//
+//	ceph config-key set <key> -i <file>
+func (ca *Admin) SetKey(key string, value []byte) error {
+	m := map[string]string{
+		"prefix": "config-key set",
+		"key":    key,
+	}
+	return commands.MarshalMonCommandWithBuffer(ca.conn, m, value).NoBody().End()
+}
+
+// RemoveKey will remove key from the config-key store.
+//
+// Similar To:
+//
+//	ceph config-key rm <key>
+func (ca *Admin) RemoveKey(key string) error {
+	m := map[string]string{
+		"prefix": "config-key rm",
+		"key":    key,
+	}
+	return commands.MarshalMonCommand(ca.conn, m).NoBody().End()
+}
+
+// KeyExists returns true if key is present in the config-key store.
+//
+// Similar To:
+//
+//	ceph config-key exists <key>
+func (ca *Admin) KeyExists(key string) (bool, error) {
+	m := map[string]string{
+		"prefix": "config-key exists",
+		"key":    key,
+	}
+	err := commands.MarshalMonCommand(ca.conn, m).NoBody().End()
+	if isNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ListKeys returns the keys of the config-key store.
+//
+// Similar To:
+//
+//	ceph config-key ls
+func (ca *Admin) ListKeys() ([]string, error) {
+	m := map[string]string{
+		"prefix": "config-key ls",
+		"format": "json",
+	}
+	return parseKeys(commands.MarshalMonCommand(ca.conn, m))
+}
+
+// DumpKeys returns the keys and values of the config-key store, limited to
+// the keys starting with prefix if it is not empty. Values that are not
+// valid UTF-8 text are not returned as stored, use GetKey for those.
+//
+// Similar To:
+//
+//	ceph config-key dump [<prefix>]
+func (ca *Admin) DumpKeys(prefix string) (map[string]string, error) {
+	m := map[string]string{
+		"prefix": "config-key dump",
+		"format": "json",
+	}
+	if prefix != "" {
+		m["key"] = prefix
+	}
+	return parseKeyDump(commands.MarshalMonCommand(ca.conn, m))
+}
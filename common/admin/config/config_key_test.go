@@ -0,0 +1,88 @@
+//go:build ceph_preview
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+type errorCode int
+
+func (e errorCode) Error() string {
+	return fmt.Sprintf("error %d", int(e))
+}
+
+func (e errorCode) ErrorCode() int {
+	return int(e)
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, isNotFound(errorCode(-2)))
+	assert.True(t, isNotFound(commands.NewResponse(nil, "doesn't exist", errorCode(-2))))
+	assert.False(t, isNotFound(errorCode(-22)))
+	assert.False(t, isNotFound(errors.New("foo")))
+	assert.False(t, isNotFound(nil))
+}
+
+func TestParseKeys(t *testing.T) {
+	r := commands.NewResponse([]byte(`["mgr/dashboard/ssl", "rbd/mirror/site_name"]`), "", nil)
+	l, err := parseKeys(r)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mgr/dashboard/ssl", "rbd/mirror/site_name"}, l)
+
+	r = commands.NewResponse([]byte(`{"mgr/dashboard/ssl": "false"}`), "", nil)
+	d, err := parseKeyDump(r)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"mgr/dashboard/ssl": "false"}, d)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	l, err = parseKeys(r)
+	assert.Error(t, err)
+	assert.Nil(t, l)
+}
+
+func TestConfigKeys(t *testing.T) {
+	ra := radosConnector.Get(t)
+	ca := NewFromConn(ra)
+
+	key := "go-ceph/test/config-key"
+	value := []byte{0, 1, 2, 0xff, 0xfe, '\n', 'x'}
+
+	ok, err := ca.KeyExists(key)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	_, err = ca.GetKey(key)
+	assert.Error(t, err)
+
+	err = ca.SetKey(key, value)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, ca.RemoveKey(key))
+	}()
+
+	ok, err = ca.KeyExists(key)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	v, err := ca.GetKey(key)
+	require.NoError(t, err)
+	assert.Equal(t, value, v)
+
+	l, err := ca.ListKeys()
+	require.NoError(t, err)
+	assert.Contains(t, l, key)
+
+	err = ca.SetKey(key+"2", []byte("text"))
+	require.NoError(t, err)
+	d, err := ca.DumpKeys(key + "2")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{key + "2": "text"}, d)
+	err = ca.RemoveKey(key + "2")
+	assert.NoError(t, err)
+}
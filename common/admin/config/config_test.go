@@ -0,0 +1,155 @@
+//go:build ceph_preview
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func TestParseOptions(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(configDumpJSON1), "", nil)
+		l, err := parseOptions(r)
+		require.NoError(t, err)
+		if assert.Len(t, l, 2) {
+			assert.Equal(t, Option{
+				Section:            "global",
+				Name:               "mon_allow_pool_delete",
+				Value:              "true",
+				Level:              "advanced",
+				CanUpdateAtRuntime: true,
+			}, l[0])
+			assert.Equal(t, "osd", l[1].Section)
+			assert.Equal(t, "class:ssd", l[1].Mask)
+			assert.Equal(t, "class", l[1].LocationType)
+			assert.Equal(t, "ssd", l[1].LocationValue)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		l, err := parseOptions(r)
+		assert.Error(t, err)
+		assert.Nil(t, l)
+	})
+}
+
+func TestParseDaemonOptions(t *testing.T) {
+	r := commands.NewResponse([]byte(configShowJSON1), "", nil)
+	l, err := parseDaemonOptions(r)
+	require.NoError(t, err)
+	if assert.Len(t, l, 2) {
+		assert.Equal(t, "osd_max_backfills", l[1].Name)
+		assert.Equal(t, "mon", l[1].Source)
+		assert.Equal(t, []Override{{Source: "default", Value: "1"}}, l[1].Overrides)
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	v, err := parseValue(commands.NewResponse([]byte("3\n"), "", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "3", v)
+
+	_, err = parseValue(commands.NewResponse(nil, "Error ENOENT: unrecognized key 'x'", errors.New("-2")))
+	assert.Error(t, err)
+}
+
+func TestSetGetRemove(t *testing.T) {
+	ra := radosConnector.Get(t)
+	ca := NewFromConn(ra)
+
+	err := ca.Set("osd/class:ssd", "osd_max_backfills", "7", false)
+	require.NoError(t, err)
+
+	v, err := ca.Get("osd", "osd_max_backfills")
+	require.NoError(t, err)
+	assert.NotEqual(t, "7", v)
+
+	l, err := ca.Dump()
+	require.NoError(t, err)
+	found := false
+	for _, o := range l {
+		if o.Name == "osd_max_backfills" && o.Section == "osd" {
+			assert.Equal(t, "class:ssd", o.Mask)
+			assert.Equal(t, "7", o.Value)
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	err = ca.Remove("osd/class:ssd", "osd_max_backfills")
+	assert.NoError(t, err)
+
+	err = ca.Set("client", "no_such_option_really", "1", false)
+	assert.Error(t, err)
+}
+
+func TestShow(t *testing.T) {
+	ra := radosConnector.Get(t)
+	ca := NewFromConn(ra)
+
+	l, err := ca.Show("mon.a")
+	require.NoError(t, err)
+	assert.NotEmpty(t, l)
+
+	v, err := ca.ShowValue("mon.a", "mon_allow_pool_delete")
+	require.NoError(t, err)
+	assert.Equal(t, "true", v)
+}
+
+var configDumpJSON1 = `
+[
+    {
+        "section": "global",
+        "name": "mon_allow_pool_delete",
+        "value": "true",
+        "level": "advanced",
+        "can_update_at_runtime": true,
+        "mask": "",
+        "location_type": "",
+        "location_value": ""
+    },
+    {
+        "section": "osd",
+        "name": "osd_max_backfills",
+        "value": "4",
+        "level": "advanced",
+        "can_update_at_runtime": true,
+        "mask": "class:ssd",
+        "location_type": "class",
+        "location_value": "ssd"
+    }
+]
+`
+
+var configShowJSON1 = `
+[
+    {
+        "name": "fsid",
+        "value": "0c6e2f6e-7e3a-4f7b-9f36-2c1b4b4f0b1a",
+        "source": "file",
+        "overrides": [],
+        "ignores": []
+    },
+    {
+        "name": "osd_max_backfills",
+        "value": "4",
+        "source": "mon",
+        "overrides": [
+            {
+                "source": "default",
+                "value": "1"
+            }
+        ],
+        "ignores": []
+    }
+]
+`
@@ -0,0 +1,6 @@
+/*
+Package config from common/admin contains a set of APIs used to manage the
+central configuration database and the config-key store of the Ceph
+monitors (mon), and the configuration of running Ceph daemons.
+*/
+package config
@@ -0,0 +1,194 @@
+//go:build ceph_preview
+
+package crash
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// timeLayouts are the layouts of the times of a crash report. Older
+// versions of ceph separate the date and the time with a space. The
+// archive time is the string form of a python datetime in UTC, which has
+// no Z suffix.
+var timeLayouts = []string{
+	"2006-01-02T15:04:05.999999Z",
+	"2006-01-02 15:04:05.999999Z",
+	"2006-01-02 15:04:05.999999",
+}
+
+type crashTime time.Time
+
+func (ct *crashTime) UnmarshalText(data []byte) error {
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, strings.TrimSpace(string(data))); err == nil {
+			*ct = crashTime(t)
+			return nil
+		}
+	}
+	return err
+}
+
+// Report describes a crash of a Ceph daemon.
+type Report struct {
+	ID          string `json:"crash_id"`
+	EntityName  string `json:"entity_name"`
+	ProcessName string `json:"process_name"`
+	CephVersion string `json:"ceph_version"`
+	// Timestamp is the time of the crash.
+	Timestamp time.Time `json:"-"`
+	// Archived is the time the crash was archived. It is zero for new
+	// crashes.
+	Archived time.Time `json:"-"`
+
+	Hostname      string `json:"utsname_hostname"`
+	OSName        string `json:"os_name"`
+	OSVersion     string `json:"os_version"`
+	OSVersionID   string `json:"os_version_id"`
+	KernelName    string `json:"utsname_sysname"`
+	KernelRelease string `json:"utsname_release"`
+
+	// the fields below are only set by Info
+
+	Backtrace        []string `json:"backtrace"`
+	AssertCondition  string   `json:"assert_condition"`
+	AssertFunc       string   `json:"assert_func"`
+	AssertFile       string   `json:"assert_file"`
+	AssertLine       int      `json:"assert_line"`
+	AssertMsg        string   `json:"assert_msg"`
+	AssertThreadName string   `json:"assert_thread_name"`
+}
+
+// IsArchived returns true if the crash has been archived.
+func (r *Report) IsArchived() bool {
+	return !r.Archived.IsZero()
+}
+
+// UnmarshalJSON decodes a crash report, converting its times.
+func (r *Report) UnmarshalJSON(data []byte) error {
+	type report Report
+	v := struct {
+		*report
+		Timestamp *crashTime `json:"timestamp"`
+		Archived  *crashTime `json:"archived"`
+	}{report: (*report)(r)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Timestamp != nil {
+		r.Timestamp = time.Time(*v.Timestamp)
+	}
+	if v.Archived != nil {
+		r.Archived = time.Time(*v.Archived)
+	}
+	return nil
+}
+
+func parseReports(res commands.Response) ([]Report, error) {
+	l := []Report{}
+	if err := res.NoStatus().Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func parseReport(res commands.Response) (*Report, error) {
+	r := &Report{}
+	if err := res.NoStatus().Unmarshal(r).End(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// List returns the crash reports known to the cluster. Unless all is true,
+// only new crashes, those that are not archived, are returned.
+//
+// Similar To:
+//
+//	ceph crash ls
+//	ceph crash ls-new
+func (ca *Admin) List(all bool) ([]Report, error) {
+	m := map[string]string{
+		"prefix": "crash ls-new",
+		"format": "json",
+	}
+	if all {
+		m["prefix"] = "crash ls"
+	}
+	return parseReports(commands.MarshalMgrCommand(ca.conn, m))
+}
+
+// Info returns the full crash report with the given ID.
+//
+// Similar To:
+//
+//	ceph crash info <id>
+func (ca *Admin) Info(id string) (*Report, error) {
+	m := map[string]string{
+		"prefix": "crash info",
+		"id":     id,
+		"format": "json",
+	}
+	return parseReport(commands.MarshalMgrCommand(ca.conn, m))
+}
+
+// Archive will acknowledge the crash with the given ID. Archived crashes
+// no longer raise the RECENT_CRASH health warning.
+//
+// Similar To:
+//
+//	ceph crash archive <id>
+func (ca *Admin) Archive(id string) error {
+	m := map[string]string{
+		"prefix": "crash archive",
+		"id":     id,
+		"format": "json",
+	}
+	return commands.MarshalMgrCommand(ca.conn, m).NoData().End()
+}
+
+// ArchiveAll will acknowledge all new crashes.
+//
+// Similar To:
+//
+//	ceph crash archive-all
+func (ca *Admin) ArchiveAll() error {
+	m := map[string]string{
+		"prefix": "crash archive-all",
+		"format": "json",
+	}
+	return commands.MarshalMgrCommand(ca.conn, m).NoData().End()
+}
+
+// Remove will delete the crash with the given ID.
+//
+// Similar To:
+//
+//	ceph crash rm <id>
+func (ca *Admin) Remove(id string) error {
+	m := map[string]string{
+		"prefix": "crash rm",
+		"id":     id,
+		"format": "json",
+	}
+	return commands.MarshalMgrCommand(ca.conn, m).NoData().End()
+}
+
+// Prune will delete the crashes that are older than keep days.
+//
+// Similar To:
+//
+//	ceph crash prune <keep>
+func (ca *Admin) Prune(keep int) error {
+	m := map[string]interface{}{
+		"prefix": "crash prune",
+		"keep":   keep,
+		"format": "json",
+	}
+	return commands.MarshalMgrCommand(ca.conn, m).NoData().End()
+}
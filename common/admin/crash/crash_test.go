@@ -0,0 +1,157 @@
+//go:build ceph_preview
+
+package crash
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func TestParseReports(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(crashLsJSON1), "", nil)
+		l, err := parseReports(r)
+		require.NoError(t, err)
+		if assert.Len(t, l, 2) {
+			assert.Equal(t,
+				"2024-06-10T12:00:00.123456Z_2b6bd0de-57c9-4c7d-9a46-6e31e4a0d7a3",
+				l[0].ID)
+			assert.Equal(t, "osd.1", l[0].EntityName)
+			assert.Equal(t, "ceph-osd", l[0].ProcessName)
+			assert.Equal(t,
+				time.Date(2024, 6, 10, 12, 0, 0, 123456000, time.UTC),
+				l[0].Timestamp)
+			assert.False(t, l[0].IsArchived())
+
+			assert.Equal(t, "mgr.x", l[1].EntityName)
+			assert.Equal(t,
+				time.Date(2019, 4, 24, 20, 21, 0, 0, time.UTC),
+				l[1].Timestamp)
+			assert.True(t, l[1].IsArchived())
+			assert.Equal(t,
+				time.Date(2019, 4, 25, 8, 0, 0, 500000000, time.UTC),
+				l[1].Archived)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		r := commands.NewResponse([]byte(`[]`), "", nil)
+		l, err := parseReports(r)
+		require.NoError(t, err)
+		assert.Empty(t, l)
+	})
+	t.Run("badTime", func(t *testing.T) {
+		r := commands.NewResponse([]byte(`[{"timestamp": "yesterday"}]`), "", nil)
+		_, err := parseReports(r)
+		assert.Error(t, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		l, err := parseReports(r)
+		assert.Error(t, err)
+		assert.Nil(t, l)
+	})
+}
+
+func TestParseReport(t *testing.T) {
+	r := commands.NewResponse([]byte(crashInfoJSON1), "", nil)
+	c, err := parseReport(r)
+	require.NoError(t, err)
+	assert.Equal(t, "osd.1", c.EntityName)
+	assert.Equal(t, "c8s-host", c.Hostname)
+	assert.Equal(t, "Linux", c.KernelName)
+	assert.Len(t, c.Backtrace, 3)
+	assert.Equal(t, "0 == \"oops\"", c.AssertCondition)
+	assert.Equal(t, 123, c.AssertLine)
+	assert.Equal(t,
+		time.Date(2024, 6, 11, 9, 30, 0, 0, time.UTC),
+		c.Archived)
+}
+
+func TestListCrashes(t *testing.T) {
+	ra := radosConnector.Get(t)
+	ca := NewFromConn(ra)
+
+	l, err := ca.List(true)
+	require.NoError(t, err)
+	assert.NotNil(t, l)
+
+	_, err = ca.List(false)
+	require.NoError(t, err)
+
+	_, err = ca.Info("no-such-crash")
+	assert.Error(t, err)
+
+	err = ca.ArchiveAll()
+	assert.NoError(t, err)
+	l, err = ca.List(false)
+	require.NoError(t, err)
+	assert.Empty(t, l)
+
+	err = ca.Prune(365 * 100)
+	assert.NoError(t, err)
+}
+
+var crashLsJSON1 = `
+[
+    {
+        "crash_id": "2024-06-10T12:00:00.123456Z_2b6bd0de-57c9-4c7d-9a46-6e31e4a0d7a3",
+        "timestamp": "2024-06-10T12:00:00.123456Z",
+        "process_name": "ceph-osd",
+        "entity_name": "osd.1",
+        "ceph_version": "18.2.2",
+        "utsname_hostname": "c8s-host",
+        "utsname_sysname": "Linux",
+        "utsname_release": "5.14.0",
+        "os_name": "CentOS Stream",
+        "os_version_id": "9"
+    },
+    {
+        "crash_id": "2019-04-24_20:21:00.000000Z_a9a6d6c2-0c1c-4c92-b3a1-4f1de03f2f83",
+        "timestamp": "2019-04-24 20:21:00.000000Z",
+        "process_name": "ceph-mgr",
+        "entity_name": "mgr.x",
+        "ceph_version": "14.2.0",
+        "archived": "2019-04-25 08:00:00.500000"
+    }
+]
+`
+
+var crashInfoJSON1 = `
+{
+    "crash_id": "2024-06-10T12:00:00.123456Z_2b6bd0de-57c9-4c7d-9a46-6e31e4a0d7a3",
+    "timestamp": "2024-06-10T12:00:00.123456Z",
+    "process_name": "ceph-osd",
+    "entity_name": "osd.1",
+    "ceph_version": "18.2.2",
+    "archived": "2024-06-11 09:30:00",
+    "utsname_hostname": "c8s-host",
+    "utsname_sysname": "Linux",
+    "utsname_release": "5.14.0",
+    "utsname_version": "#1 SMP PREEMPT_DYNAMIC",
+    "utsname_machine": "x86_64",
+    "os_name": "CentOS Stream",
+    "os_id": "centos",
+    "os_version_id": "9",
+    "os_version": "9",
+    "assert_condition": "0 == \"oops\"",
+    "assert_func": "void OSD::handle_osd_map()",
+    "assert_file": "/ceph/src/osd/OSD.cc",
+    "assert_line": 123,
+    "assert_thread_name": "ms_dispatch",
+    "assert_msg": "failed assert",
+    "backtrace": [
+        "/lib64/libc.so.6(+0x3e6f0) [0x7f1f3c43e6f0]",
+        "abort()",
+        "ceph-osd(+0x3c1b2a) [0x55d9d5a41b2a]"
+    ]
+}
+`
@@ -0,0 +1,6 @@
+/*
+Package crash from common/admin contains a set of APIs used to interact
+with the crash module of the Ceph manager (mgr), collecting and
+acknowledging the crash reports of Ceph daemons.
+*/
+package crash
@@ -0,0 +1,181 @@
+//go:build ceph_preview
+
+package devicehealth
+
+import (
+	"encoding/json"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// DeviceLocation is a host and path where a device is attached.
+type DeviceLocation struct {
+	Host string `json:"host"`
+	Dev  string `json:"dev"`
+	Path string `json:"path"`
+}
+
+// Device describes a storage device used by Ceph daemons.
+type Device struct {
+	// ID identifies the device by its vendor, model and serial number.
+	ID       string           `json:"devid"`
+	Location []DeviceLocation `json:"location"`
+	// Daemons lists the daemons using the device, for example "osd.1".
+	Daemons []string `json:"daemons"`
+	// LifeExpectancyMin and LifeExpectancyMax are the range of the time
+	// the device is predicted to fail. They are empty if no prediction was
+	// made.
+	LifeExpectancyMin   string `json:"life_expectancy_min"`
+	LifeExpectancyMax   string `json:"life_expectancy_max"`
+	LifeExpectancyStamp string `json:"life_expectancy_stamp"`
+	// WearLevel is the fraction of the rated life of the device that is
+	// used, if the device reports it.
+	WearLevel *float64 `json:"wear_level"`
+}
+
+// SMARTMetrics is a sample of the health metrics of a device, as reported by
+// smartctl. Only commonly used fields are decoded, Raw contains the whole
+// sample.
+type SMARTMetrics struct {
+	ModelName    string `json:"model_name"`
+	SerialNumber string `json:"serial_number"`
+	SMARTStatus  struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	PowerOnTime struct {
+		Hours int `json:"hours"`
+	} `json:"power_on_time"`
+	Temperature struct {
+		Current int `json:"current"`
+	} `json:"temperature"`
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a metrics sample, keeping the raw sample.
+func (s *SMARTMetrics) UnmarshalJSON(data []byte) error {
+	type metrics SMARTMetrics
+	if err := json.Unmarshal(data, (*metrics)(s)); err != nil {
+		return err
+	}
+	s.Raw = append(json.RawMessage{}, data...)
+	return nil
+}
+
+func parseDevices(res commands.Response) ([]Device, error) {
+	l := []Device{}
+	if err := res.NoStatus().Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func parseDevice(res commands.Response) (*Device, error) {
+	d := struct {
+		Device Device `json:"device"`
+	}{}
+	if err := res.NoStatus().Unmarshal(&d).End(); err != nil {
+		return nil, err
+	}
+	return &d.Device, nil
+}
+
+func parseMetrics(res commands.Response) (map[string]SMARTMetrics, error) {
+	m := map[string]SMARTMetrics{}
+	if err := res.NoStatus().Unmarshal(&m).End(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ListDevices returns the storage devices used by the daemons of the
+// cluster.
+//
+// Similar To:
+//
+//	ceph device ls
+func (da *Admin) ListDevices() ([]Device, error) {
+	m := map[string]string{
+		"prefix": "device ls",
+		"format": "json",
+	}
+	return parseDevices(commands.MarshalMgrCommand(da.conn, m))
+}
+
+// ListDaemonDevices returns the storage devices used by the named daemon,
+// for example "osd.1".
+//
+// Similar To:
+//
+//	ceph device ls-by-daemon <who>
+func (da *Admin) ListDaemonDevices(who string) ([]Device, error) {
+	m := map[string]string{
+		"prefix": "device ls-by-daemon",
+		"who":    who,
+		"format": "json",
+	}
+	return parseDevices(commands.MarshalMgrCommand(da.conn, m))
+}
+
+// ListHostDevices returns the storage devices used by daemons on the named
+// host.
+//
+// Similar To:
+//
+//	ceph device ls-by-host <host>
+func (da *Admin) ListHostDevices(host string) ([]Device, error) {
+	m := map[string]string{
+		"prefix": "device ls-by-host",
+		"host":   host,
+		"format": "json",
+	}
+	return parseDevices(commands.MarshalMgrCommand(da.conn, m))
+}
+
+// DeviceInfo returns the device with the given ID.
+//
+// Similar To:
+//
+//	ceph device info <devid>
+func (da *Admin) DeviceInfo(devid string) (*Device, error) {
+	m := map[string]string{
+		"prefix": "device info",
+		"devid":  devid,
+		"format": "json",
+	}
+	return parseDevice(commands.MarshalMgrCommand(da.conn, m))
+}
+
+// GetHealthMetrics returns the health metrics samples stored for the device,
+// keyed by the time they were taken as in "20240610-120000". If sample is
+// not empty only the sample taken at that time is returned.
+//
+// Similar To:
+//
+//	ceph device get-health-metrics <devid> [<sample>]
+func (da *Admin) GetHealthMetrics(devid, sample string) (map[string]SMARTMetrics, error) {
+	m := map[string]string{
+		"prefix": "device get-health-metrics",
+		"devid":  devid,
+		"format": "json",
+	}
+	if sample != "" {
+		m["sample"] = sample
+	}
+	return parseMetrics(commands.MarshalMgrCommand(da.conn, m))
+}
+
+// ScrapeHealthMetrics will make the devicehealth module collect the health
+// metrics of the device now, or of all devices if devid is empty.
+//
+// Similar To:
+//
+//	ceph device scrape-health-metrics [<devid>]
+func (da *Admin) ScrapeHealthMetrics(devid string) error {
+	m := map[string]string{
+		"prefix": "device scrape-health-metrics",
+	}
+	if devid != "" {
+		m["devid"] = devid
+	}
+	return commands.MarshalMgrCommand(da.conn, m).NoData().End()
+}
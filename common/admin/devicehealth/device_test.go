@@ -0,0 +1,187 @@
+//go:build ceph_preview
+
+package devicehealth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func TestParseDevices(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		r := commands.NewResponse([]byte(deviceLsJSON1), "", nil)
+		l, err := parseDevices(r)
+		require.NoError(t, err)
+		require.Len(t, l, 2)
+		assert.Equal(t, "ATA_QEMU_HARDDISK_QM00001", l[0].ID)
+		assert.Equal(t, []DeviceLocation{
+			{Host: "node1", Dev: "sda", Path: "/dev/disk/by-path/pci-0000:00:01.1-ata-1"},
+		}, l[0].Location)
+		assert.Equal(t, []string{"mon.node1", "osd.0"}, l[0].Daemons)
+		assert.Equal(t, "2025-01-05T00:00:00.000000Z", l[0].LifeExpectancyMin)
+		assert.Equal(t, "2025-03-05T00:00:00.000000Z", l[0].LifeExpectancyMax)
+		if assert.NotNil(t, l[0].WearLevel) {
+			assert.InDelta(t, 0.12, *l[0].WearLevel, 0.0001)
+		}
+		assert.Empty(t, l[1].LifeExpectancyMin)
+		assert.Nil(t, l[1].WearLevel)
+	})
+	t.Run("empty", func(t *testing.T) {
+		r := commands.NewResponse([]byte("[]"), "", nil)
+		l, err := parseDevices(r)
+		require.NoError(t, err)
+		assert.Len(t, l, 0)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		l, err := parseDevices(r)
+		assert.Error(t, err)
+		assert.Nil(t, l)
+	})
+}
+
+func TestParseDevice(t *testing.T) {
+	r := commands.NewResponse([]byte(deviceInfoJSON1), "", nil)
+	d, err := parseDevice(r)
+	require.NoError(t, err)
+	assert.Equal(t, "ATA_QEMU_HARDDISK_QM00002", d.ID)
+	assert.Equal(t, []string{"osd.1"}, d.Daemons)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	d, err = parseDevice(r)
+	assert.Error(t, err)
+	assert.Nil(t, d)
+}
+
+func TestParseMetrics(t *testing.T) {
+	r := commands.NewResponse([]byte(healthMetricsJSON1), "", nil)
+	m, err := parseMetrics(r)
+	require.NoError(t, err)
+	require.Contains(t, m, "20240610-120000")
+	s := m["20240610-120000"]
+	assert.Equal(t, "QEMU HARDDISK", s.ModelName)
+	assert.Equal(t, "QM00001", s.SerialNumber)
+	assert.True(t, s.SMARTStatus.Passed)
+	assert.Equal(t, 1234, s.PowerOnTime.Hours)
+	assert.Equal(t, 35, s.Temperature.Current)
+	assert.Contains(t, string(s.Raw), "ata_smart_attributes")
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	m, err = parseMetrics(r)
+	assert.Error(t, err)
+	assert.Nil(t, m)
+}
+
+func TestDevices(t *testing.T) {
+	ra := radosConnector.Get(t)
+	da := NewFromConn(ra)
+
+	l, err := da.ListDevices()
+	require.NoError(t, err)
+	if len(l) == 0 {
+		t.Skip("no devices found")
+	}
+
+	d, err := da.DeviceInfo(l[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, l[0].ID, d.ID)
+
+	if len(l[0].Daemons) > 0 {
+		dl, err := da.ListDaemonDevices(l[0].Daemons[0])
+		assert.NoError(t, err)
+		assert.NotEmpty(t, dl)
+	}
+
+	_, err = da.GetHealthMetrics(l[0].ID, "")
+	assert.NoError(t, err)
+}
+
+var deviceLsJSON1 = `
+[
+    {
+        "devid": "ATA_QEMU_HARDDISK_QM00001",
+        "location": [
+            {
+                "host": "node1",
+                "dev": "sda",
+                "path": "/dev/disk/by-path/pci-0000:00:01.1-ata-1"
+            }
+        ],
+        "daemons": [
+            "mon.node1",
+            "osd.0"
+        ],
+        "life_expectancy_min": "2025-01-05T00:00:00.000000Z",
+        "life_expectancy_max": "2025-03-05T00:00:00.000000Z",
+        "life_expectancy_stamp": "2024-06-10T12:00:00.000000Z",
+        "wear_level": 0.12
+    },
+    {
+        "devid": "ATA_QEMU_HARDDISK_QM00002",
+        "location": [
+            {
+                "host": "node1",
+                "dev": "sdb",
+                "path": "/dev/disk/by-path/pci-0000:00:01.1-ata-2"
+            }
+        ],
+        "daemons": [
+            "osd.1"
+        ]
+    }
+]
+`
+
+var deviceInfoJSON1 = `
+{
+    "device": {
+        "devid": "ATA_QEMU_HARDDISK_QM00002",
+        "location": [
+            {
+                "host": "node1",
+                "dev": "sdb",
+                "path": "/dev/disk/by-path/pci-0000:00:01.1-ata-2"
+            }
+        ],
+        "daemons": [
+            "osd.1"
+        ]
+    }
+}
+`
+
+var healthMetricsJSON1 = `
+{
+    "20240610-120000": {
+        "device": {
+            "name": "/dev/sda",
+            "info_name": "/dev/sda [SAT]",
+            "type": "sat",
+            "protocol": "ATA"
+        },
+        "model_name": "QEMU HARDDISK",
+        "serial_number": "QM00001",
+        "smart_status": {
+            "passed": true
+        },
+        "ata_smart_attributes": {
+            "revision": 1,
+            "table": []
+        },
+        "power_on_time": {
+            "hours": 1234
+        },
+        "temperature": {
+            "current": 35
+        }
+    }
+}
+`
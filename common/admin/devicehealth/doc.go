@@ -0,0 +1,6 @@
+/*
+Package devicehealth from common/admin contains a set of APIs used to query
+the storage devices of a Ceph cluster and the health metrics collected by
+the devicehealth module of the Ceph manager (mgr).
+*/
+package devicehealth
@@ -0,0 +1,5 @@
+/*
+Package health from common/admin contains a set of APIs used to query the
+health checks of a Ceph cluster and to mute or unmute them.
+*/
+package health
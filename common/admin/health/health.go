@@ -0,0 +1,205 @@
+//go:build ceph_preview
+
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// Status is the overall health of the cluster or the severity of a check.
+type Status string
+
+const (
+	// HealthOK indicates there are no problems.
+	HealthOK = Status("HEALTH_OK")
+	// HealthWarn indicates a problem that needs attention.
+	HealthWarn = Status("HEALTH_WARN")
+	// HealthErr indicates a problem that needs immediate attention.
+	HealthErr = Status("HEALTH_ERR")
+)
+
+// Check is a health check that is failing.
+type Check struct {
+	// Code identifies the check, for example "OSD_DOWN".
+	Code     string
+	Severity Status
+	// Summary is a short description of the problem.
+	Summary string
+	// Count is the number of items affected, if the check counts any.
+	Count int
+	// Muted is set if the check is muted.
+	Muted bool
+	// Detail lists the messages describing the problem in detail. It is
+	// only set by HealthDetail.
+	Detail []string
+}
+
+// Mute is a health check that is muted.
+type Mute struct {
+	Code string `json:"code"`
+	// TTL is the time the mute expires, it is empty for mutes that do not
+	// expire.
+	TTL string `json:"ttl"`
+	// Sticky mutes persist after the check is cleared.
+	Sticky  bool   `json:"sticky"`
+	Summary string `json:"summary"`
+	Count   int    `json:"count"`
+}
+
+// Health is the health of the cluster.
+type Health struct {
+	Status Status
+	// Checks lists the failing health checks, ordered by their code.
+	Checks []Check
+	Mutes  []Mute
+}
+
+type checkMessage struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+type healthCheck struct {
+	Severity Status         `json:"severity"`
+	Summary  checkMessage   `json:"summary"`
+	Detail   []checkMessage `json:"detail"`
+	Muted    bool           `json:"muted"`
+}
+
+type healthReport struct {
+	Status Status                 `json:"status"`
+	Checks map[string]healthCheck `json:"checks"`
+	Mutes  []Mute                 `json:"mutes"`
+}
+
+// UnmarshalJSON decodes the health report, converting the checks keyed by
+// their code to a list.
+func (h *Health) UnmarshalJSON(data []byte) error {
+	r := healthReport{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	h.Status = r.Status
+	h.Mutes = r.Mutes
+	h.Checks = make([]Check, 0, len(r.Checks))
+	for code, hc := range r.Checks {
+		c := Check{
+			Code:     code,
+			Severity: hc.Severity,
+			Summary:  hc.Summary.Message,
+			Count:    hc.Summary.Count,
+			Muted:    hc.Muted,
+		}
+		for _, d := range hc.Detail {
+			c.Detail = append(c.Detail, d.Message)
+		}
+		h.Checks = append(h.Checks, c)
+	}
+	sort.Slice(h.Checks, func(i, j int) bool {
+		return h.Checks[i].Code < h.Checks[j].Code
+	})
+	return nil
+}
+
+// Check returns the failing check with the given code, or nil if the check
+// is not failing.
+func (h *Health) Check(code string) *Check {
+	for i := range h.Checks {
+		if h.Checks[i].Code == code {
+			return &h.Checks[i]
+		}
+	}
+	return nil
+}
+
+func parseHealth(res commands.Response) (*Health, error) {
+	h := &Health{}
+	if err := res.NoStatus().Unmarshal(h).End(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Health returns the health of the cluster and its failing checks.
+//
+// Similar To:
+//
+//	ceph health
+func (ha *Admin) Health() (*Health, error) {
+	m := map[string]string{
+		"prefix": "health",
+		"format": "json",
+	}
+	return parseHealth(commands.MarshalMonCommand(ha.conn, m))
+}
+
+// HealthDetail returns the health of the cluster and its failing checks,
+// including the detailed messages of the checks.
+//
+// Similar To:
+//
+//	ceph health detail
+func (ha *Admin) HealthDetail() (*Health, error) {
+	m := map[string]string{
+		"prefix": "health",
+		"detail": "detail",
+		"format": "json",
+	}
+	return parseHealth(commands.MarshalMonCommand(ha.conn, m))
+}
+
+// MuteOptions controls how a health check is muted.
+type MuteOptions struct {
+	// TTL is the duration after which the mute expires. The mute does not
+	// expire if it is zero.
+	TTL time.Duration
+	// Sticky mutes persist after the check is cleared. Otherwise the mute
+	// is removed when the check clears, or when the number of affected
+	// items increases.
+	Sticky bool
+}
+
+// Mute will mute the health check with the given code, so that it no longer
+// affects the health status. The options may be nil.
+//
+// Similar To:
+//
+//	ceph health mute <code> [<ttl>] [--sticky]
+func (ha *Admin) Mute(code string, o *MuteOptions) error {
+	m := map[string]interface{}{
+		"prefix": "health mute",
+		"code":   code,
+	}
+	if o != nil {
+		if o.TTL > 0 {
+			m["ttl"] = formatTTL(o.TTL)
+		}
+		if o.Sticky {
+			m["sticky"] = true
+		}
+	}
+	return commands.MarshalMonCommand(ha.conn, m).NoData().End()
+}
+
+// Unmute will unmute the health check with the given code.
+//
+// Similar To:
+//
+//	ceph health unmute <code>
+func (ha *Admin) Unmute(code string) error {
+	m := map[string]string{
+		"prefix": "health unmute",
+		"code":   code,
+	}
+	return commands.MarshalMonCommand(ha.conn, m).NoData().End()
+}
+
+// formatTTL returns the duration in whole seconds, rounding up.
+func formatTTL(d time.Duration) string {
+	return fmt.Sprintf("%ds", int64((d+time.Second-1)/time.Second))
+}
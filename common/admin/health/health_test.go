@@ -0,0 +1,139 @@
+//go:build ceph_preview
+
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func TestParseHealth(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		r := commands.NewResponse([]byte(`{"status":"HEALTH_OK","checks":{},"mutes":[]}`), "", nil)
+		h, err := parseHealth(r)
+		require.NoError(t, err)
+		assert.Equal(t, HealthOK, h.Status)
+		assert.Empty(t, h.Checks)
+		assert.Empty(t, h.Mutes)
+	})
+	t.Run("detail", func(t *testing.T) {
+		r := commands.NewResponse([]byte(healthDetailJSON1), "", nil)
+		h, err := parseHealth(r)
+		require.NoError(t, err)
+		assert.Equal(t, HealthWarn, h.Status)
+		require.Len(t, h.Checks, 2)
+		assert.Equal(t, Check{
+			Code:     "OSD_DOWN",
+			Severity: HealthWarn,
+			Summary:  "1 osds down",
+			Count:    1,
+			Detail:   []string{"osd.1 (root=default,host=node1) is down"},
+		}, h.Checks[0])
+		assert.Equal(t, "POOL_NO_REDUNDANCY", h.Checks[1].Code)
+		assert.True(t, h.Checks[1].Muted)
+		assert.Len(t, h.Checks[1].Detail, 2)
+
+		assert.Nil(t, h.Check("MON_DOWN"))
+		if c := h.Check("OSD_DOWN"); assert.NotNil(t, c) {
+			assert.Equal(t, 1, c.Count)
+		}
+
+		assert.Equal(t, []Mute{{
+			Code:    "POOL_NO_REDUNDANCY",
+			TTL:     "2024-06-10T13:00:00.123456+0000",
+			Summary: "2 pool(s) have no replicas configured",
+			Count:   2,
+		}}, h.Mutes)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		h, err := parseHealth(r)
+		assert.Error(t, err)
+		assert.Nil(t, h)
+	})
+}
+
+func TestFormatTTL(t *testing.T) {
+	assert.Equal(t, "3600s", formatTTL(time.Hour))
+	assert.Equal(t, "2s", formatTTL(1500*time.Millisecond))
+}
+
+func TestHealthMute(t *testing.T) {
+	ra := radosConnector.Get(t)
+	ha := NewFromConn(ra)
+
+	h, err := ha.HealthDetail()
+	require.NoError(t, err)
+	assert.NotEmpty(t, h.Status)
+
+	err = ha.Mute("GO_CEPH_TEST", &MuteOptions{TTL: time.Hour, Sticky: true})
+	require.NoError(t, err)
+	h, err = ha.Health()
+	require.NoError(t, err)
+	found := false
+	for _, m := range h.Mutes {
+		if m.Code == "GO_CEPH_TEST" {
+			found = true
+			assert.True(t, m.Sticky)
+			assert.NotEmpty(t, m.TTL)
+		}
+	}
+	assert.True(t, found)
+
+	err = ha.Unmute("GO_CEPH_TEST")
+	require.NoError(t, err)
+	h, err = ha.Health()
+	require.NoError(t, err)
+	for _, m := range h.Mutes {
+		assert.NotEqual(t, "GO_CEPH_TEST", m.Code)
+	}
+}
+
+var healthDetailJSON1 = `
+{
+    "status": "HEALTH_WARN",
+    "checks": {
+        "POOL_NO_REDUNDANCY": {
+            "severity": "HEALTH_WARN",
+            "summary": {
+                "message": "2 pool(s) have no replicas configured",
+                "count": 2
+            },
+            "detail": [
+                {"message": "pool 'rbd' has no replicas configured"},
+                {"message": "pool '.mgr' has no replicas configured"}
+            ],
+            "muted": true
+        },
+        "OSD_DOWN": {
+            "severity": "HEALTH_WARN",
+            "summary": {
+                "message": "1 osds down",
+                "count": 1
+            },
+            "detail": [
+                {"message": "osd.1 (root=default,host=node1) is down"}
+            ],
+            "muted": false
+        }
+    },
+    "mutes": [
+        {
+            "code": "POOL_NO_REDUNDANCY",
+            "ttl": "2024-06-10T13:00:00.123456+0000",
+            "sticky": false,
+            "summary": "2 pool(s) have no replicas configured",
+            "count": 2
+        }
+    ]
+}
+`
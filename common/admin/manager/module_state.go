@@ -0,0 +1,153 @@
+//go:build ceph_preview
+
+package manager
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ModuleState indicates if a manager module is enabled or not.
+type ModuleState string
+
+const (
+	// ModuleAlwaysOn indicates the module is always enabled and can not be
+	// disabled.
+	ModuleAlwaysOn = ModuleState("always-on")
+	// ModuleEnabled indicates the module has been enabled.
+	ModuleEnabled = ModuleState("enabled")
+	// ModuleDisabled indicates the module is available but not enabled.
+	ModuleDisabled = ModuleState("disabled")
+	// ModuleUnknown indicates the manager does not know of the module.
+	ModuleUnknown = ModuleState("unknown")
+)
+
+var (
+	// ErrModuleCannotRun may be returned if a module reports that it can
+	// not run and enabling it was not forced.
+	ErrModuleCannotRun = errors.New("module can not run")
+	// ErrModuleAlwaysOn may be returned if an always-on module is to be
+	// disabled.
+	ErrModuleAlwaysOn = errors.New("module is always on")
+)
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// State returns the state of the named module.
+func (mi *ModuleInfo) State(module string) ModuleState {
+	switch {
+	case contains(mi.AlwaysOnModules, module):
+		return ModuleAlwaysOn
+	case contains(mi.EnabledModules, module):
+		return ModuleEnabled
+	case mi.Disabled(module) != nil:
+		return ModuleDisabled
+	}
+	return ModuleUnknown
+}
+
+// IsEnabled returns true if the named module is enabled or always-on.
+func (mi *ModuleInfo) IsEnabled(module string) bool {
+	s := mi.State(module)
+	return s == ModuleAlwaysOn || s == ModuleEnabled
+}
+
+// Disabled returns the description of the named module if it is disabled,
+// or nil if it is not.
+func (mi *ModuleInfo) Disabled(module string) *DisabledModule {
+	for i := range mi.DisabledModules {
+		if mi.DisabledModules[i].Name == module {
+			return &mi.DisabledModules[i]
+		}
+	}
+	return nil
+}
+
+// ModuleState returns the state of the named manager module.
+//
+// Similar To:
+//
+//	ceph mgr module ls
+func (fsa *MgrAdmin) ModuleState(module string) (ModuleState, error) {
+	mi, err := fsa.ListModules()
+	if err != nil {
+		return ModuleUnknown, err
+	}
+	return mi.State(module), nil
+}
+
+// checkEnable returns an error if the module can not be enabled without
+// forcing it. It returns true if the module needs to be enabled.
+func checkEnable(mi *ModuleInfo, module string, force bool) (bool, error) {
+	if mi.IsEnabled(module) {
+		return false, nil
+	}
+	if force {
+		return true, nil
+	}
+	dm := mi.Disabled(module)
+	if dm == nil {
+		return false, fmt.Errorf("%w: %s: unknown module", ErrModuleCannotRun, module)
+	}
+	if !dm.CanRun {
+		return false, fmt.Errorf("%w: %s: %s", ErrModuleCannotRun, module, dm.ErrorString)
+	}
+	return true, nil
+}
+
+// EnsureModuleEnabled will enable the specified manager module unless it is
+// already enabled or always-on. Unless force is true, a module that reports
+// that it can not run, or that the manager does not know of, is not enabled
+// and an error wrapping ErrModuleCannotRun is returned.
+//
+// Similar To:
+//
+//	ceph mgr module enable <module> [--force]
+func (fsa *MgrAdmin) EnsureModuleEnabled(module string, force bool) error {
+	mi, err := fsa.ListModules()
+	if err != nil {
+		return err
+	}
+	enable, err := checkEnable(mi, module, force)
+	if err != nil || !enable {
+		return err
+	}
+	return fsa.EnableModule(module, force)
+}
+
+// ForceEnableModule will enable the specified manager module even if it
+// reports that it can not run or the manager does not know of it.
+//
+// Similar To:
+//
+//	ceph mgr module enable <module> --force
+func (fsa *MgrAdmin) ForceEnableModule(module string) error {
+	return fsa.EnableModule(module, true)
+}
+
+// EnsureModuleDisabled will disable the specified manager module unless it
+// is not enabled. ErrModuleAlwaysOn is returned for always-on modules.
+//
+// Similar To:
+//
+//	ceph mgr module disable <module>
+func (fsa *MgrAdmin) EnsureModuleDisabled(module string) error {
+	mi, err := fsa.ListModules()
+	if err != nil {
+		return err
+	}
+	switch mi.State(module) {
+	case ModuleAlwaysOn:
+		return fmt.Errorf("%w: %s", ErrModuleAlwaysOn, module)
+	case ModuleEnabled:
+		return fsa.DisableModule(module)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+//go:build ceph_preview
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func TestModuleInfoState(t *testing.T) {
+	r := commands.NewResponse([]byte(cephMgrModuleLsJSON1), "", nil)
+	mi, err := parseModuleInfo(r)
+	require.NoError(t, err)
+
+	assert.Equal(t, ModuleAlwaysOn, mi.State("rbd_support"))
+	assert.Equal(t, ModuleEnabled, mi.State("nfs"))
+	assert.Equal(t, ModuleDisabled, mi.State("alerts"))
+	assert.Equal(t, ModuleUnknown, mi.State("nope"))
+
+	assert.True(t, mi.IsEnabled("volumes"))
+	assert.True(t, mi.IsEnabled("mirroring"))
+	assert.False(t, mi.IsEnabled("influx"))
+	assert.False(t, mi.IsEnabled("nope"))
+
+	if dm := mi.Disabled("influx"); assert.NotNil(t, dm) {
+		assert.False(t, dm.CanRun)
+	}
+	assert.Nil(t, mi.Disabled("nfs"))
+
+	t.Run("checkEnable", func(t *testing.T) {
+		enable, err := checkEnable(mi, "progress", false)
+		assert.NoError(t, err)
+		assert.False(t, enable)
+		enable, err = checkEnable(mi, "nfs", true)
+		assert.NoError(t, err)
+		assert.False(t, enable)
+		enable, err = checkEnable(mi, "hello", false)
+		assert.NoError(t, err)
+		assert.True(t, enable)
+
+		_, err = checkEnable(mi, "influx", false)
+		assert.ErrorIs(t, err, ErrModuleCannotRun)
+		assert.Contains(t, err.Error(), "influxdb python module not found")
+		_, err = checkEnable(mi, "nope", false)
+		assert.ErrorIs(t, err, ErrModuleCannotRun)
+
+		enable, err = checkEnable(mi, "influx", true)
+		assert.NoError(t, err)
+		assert.True(t, enable)
+		enable, err = checkEnable(mi, "nope", true)
+		assert.NoError(t, err)
+		assert.True(t, enable)
+	})
+}
+
+func TestEnsureModuleEnabled(t *testing.T) {
+	ra := radosConnector.Get(t)
+	mgrAdmin := NewFromConn(ra)
+
+	s, err := mgrAdmin.ModuleState("hello")
+	require.NoError(t, err)
+	require.Equal(t, ModuleDisabled, s)
+
+	err = mgrAdmin.EnsureModuleEnabled("hello", false)
+	require.NoError(t, err)
+	waitFor(t, mgrAdmin, "hello")
+	// enabling it again is a no-op
+	err = mgrAdmin.EnsureModuleEnabled("hello", false)
+	assert.NoError(t, err)
+
+	err = mgrAdmin.EnsureModuleDisabled("hello")
+	require.NoError(t, err)
+	err = mgrAdmin.EnsureModuleDisabled("hello")
+	assert.NoError(t, err)
+
+	err = mgrAdmin.EnsureModuleDisabled("progress")
+	assert.ErrorIs(t, err, ErrModuleAlwaysOn)
+	err = mgrAdmin.EnsureModuleEnabled("progress", false)
+	assert.NoError(t, err)
+}
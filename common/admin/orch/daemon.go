@@ -0,0 +1,143 @@
+//go:build ceph_preview
+
+package orch
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// DaemonStatus is the state of a daemon as known by the orchestrator.
+type DaemonStatus int
+
+const (
+	// DaemonUnknown indicates the state of the daemon is not known.
+	DaemonUnknown = DaemonStatus(-2)
+	// DaemonError indicates the daemon failed.
+	DaemonError = DaemonStatus(-1)
+	// DaemonStopped indicates the daemon is not running.
+	DaemonStopped = DaemonStatus(0)
+	// DaemonRunning indicates the daemon is running.
+	DaemonRunning = DaemonStatus(1)
+	// DaemonStarting indicates the daemon is being started.
+	DaemonStarting = DaemonStatus(2)
+)
+
+// Daemon describes a daemon deployed by the orchestrator.
+type Daemon struct {
+	Name               string       `json:"daemon_name"`
+	Type               string       `json:"daemon_type"`
+	ID                 string       `json:"daemon_id"`
+	Hostname           string       `json:"hostname"`
+	ServiceName        string       `json:"service_name"`
+	ContainerID        string       `json:"container_id"`
+	ContainerImageName string       `json:"container_image_name"`
+	Version            string       `json:"version"`
+	Status             DaemonStatus `json:"status"`
+	StatusDesc         string       `json:"status_desc"`
+	IsActive           bool         `json:"is_active"`
+	Ports              []int        `json:"ports"`
+	MemoryUsage        uint64       `json:"memory_usage"`
+	Created            time.Time    `json:"created"`
+	Started            time.Time    `json:"started"`
+	LastRefresh        time.Time    `json:"last_refresh"`
+}
+
+// DaemonFilter limits the daemons that are listed. Empty fields match all
+// daemons.
+type DaemonFilter struct {
+	Hostname    string
+	ServiceName string
+	DaemonType  string
+	DaemonID    string
+	// Refresh makes the orchestrator query the daemons again instead of
+	// returning cached data.
+	Refresh bool
+}
+
+// DaemonAction is an action the orchestrator can take on a daemon.
+type DaemonAction string
+
+const (
+	// DaemonActionStart starts a daemon.
+	DaemonActionStart = DaemonAction("start")
+	// DaemonActionStop stops a daemon.
+	DaemonActionStop = DaemonAction("stop")
+	// DaemonActionRestart restarts a daemon.
+	DaemonActionRestart = DaemonAction("restart")
+	// DaemonActionRedeploy redeploys a daemon, recreating its container.
+	DaemonActionRedeploy = DaemonAction("redeploy")
+	// DaemonActionReconfig regenerates and applies the configuration of a
+	// daemon.
+	DaemonActionReconfig = DaemonAction("reconfig")
+)
+
+func parseDaemons(res commands.Response) ([]Daemon, error) {
+	l := []Daemon{}
+	if err := res.NoStatus().Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ListDaemons returns the daemons deployed by the orchestrator. The filter
+// may be nil to list all daemons.
+//
+// Similar To:
+//
+//	ceph orch ps [<hostname>] [--service_name <name>] [--daemon_type <type>]
+//	  [--daemon_id <id>] [--refresh]
+func (oa *Admin) ListDaemons(f *DaemonFilter) ([]Daemon, error) {
+	m := map[string]interface{}{
+		"prefix": "orch ps",
+		"format": "json",
+	}
+	if f != nil {
+		if f.Hostname != "" {
+			m["hostname"] = f.Hostname
+		}
+		if f.ServiceName != "" {
+			m["service_name"] = f.ServiceName
+		}
+		if f.DaemonType != "" {
+			m["daemon_type"] = f.DaemonType
+		}
+		if f.DaemonID != "" {
+			m["daemon_id"] = f.DaemonID
+		}
+		if f.Refresh {
+			m["refresh"] = true
+		}
+	}
+	return parseDaemons(commands.MarshalMgrCommand(oa.conn, m))
+}
+
+// DaemonDoAction will schedule an action on the daemon with the given name,
+// for example "osd.1". The message of the orchestrator is returned.
+//
+// Similar To:
+//
+//	ceph orch daemon <action> <name>
+func (oa *Admin) DaemonDoAction(action DaemonAction, name string) (string, error) {
+	m := map[string]string{
+		"prefix": "orch daemon",
+		"action": string(action),
+		"name":   name,
+	}
+	res := commands.MarshalMgrCommand(oa.conn, m)
+	if err := res.NoStatus().End(); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(res.Body())), nil
+}
+
+// RestartDaemon will schedule a restart of the daemon with the given name.
+//
+// Similar To:
+//
+//	ceph orch daemon restart <name>
+func (oa *Admin) RestartDaemon(name string) (string, error) {
+	return oa.DaemonDoAction(DaemonActionRestart, name)
+}
@@ -0,0 +1,75 @@
+//go:build ceph_preview
+
+package orch
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// DeviceSysAPI contains the properties of a device reported by the kernel.
+type DeviceSysAPI struct {
+	Size              uint64 `json:"size"`
+	HumanReadableSize string `json:"human_readable_size"`
+	// Rotational is "1" for spinning disks and "0" otherwise.
+	Rotational string `json:"rotational"`
+	Vendor     string `json:"vendor"`
+	Model      string `json:"model"`
+}
+
+// Device describes a storage device of a host.
+type Device struct {
+	Path              string       `json:"path"`
+	DeviceID          string       `json:"device_id"`
+	HumanReadableType string       `json:"human_readable_type"`
+	Available         bool         `json:"available"`
+	RejectedReasons   []string     `json:"rejected_reasons"`
+	SysAPI            DeviceSysAPI `json:"sys_api"`
+}
+
+// HostDevices lists the storage devices of a host.
+type HostDevices struct {
+	Name    string   `json:"name"`
+	Addr    string   `json:"addr"`
+	Labels  []string `json:"labels"`
+	Devices []Device `json:"devices"`
+}
+
+// ListDevicesOptions controls which devices are listed.
+type ListDevicesOptions struct {
+	// Hostnames limits the listing to the given hosts.
+	Hostnames []string
+	// Refresh makes the orchestrator scan the devices again instead of
+	// returning cached data.
+	Refresh bool
+}
+
+func parseHostDevices(res commands.Response) ([]HostDevices, error) {
+	l := []HostDevices{}
+	if err := res.NoStatus().Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ListDevices returns the storage devices of the hosts managed by the
+// orchestrator. The options may be nil to list the cached devices of all
+// hosts.
+//
+// Similar To:
+//
+//	ceph orch device ls [<hostname>...] [--refresh]
+func (oa *Admin) ListDevices(o *ListDevicesOptions) ([]HostDevices, error) {
+	m := map[string]interface{}{
+		"prefix": "orch device ls",
+		"format": "json",
+	}
+	if o != nil {
+		if len(o.Hostnames) > 0 {
+			m["hostname"] = o.Hostnames
+		}
+		if o.Refresh {
+			m["refresh"] = true
+		}
+	}
+	return parseHostDevices(commands.MarshalMgrCommand(oa.conn, m))
+}
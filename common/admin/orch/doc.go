@@ -0,0 +1,7 @@
+/*
+Package orch from common/admin contains a set of APIs used to interact
+with the orchestrator module of the Ceph manager (mgr), managing the hosts,
+devices, services and daemons of clusters deployed by an orchestrator like
+cephadm.
+*/
+package orch
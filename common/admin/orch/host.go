@@ -0,0 +1,38 @@
+//go:build ceph_preview
+
+package orch
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// Host describes a host managed by the orchestrator.
+type Host struct {
+	Hostname string   `json:"hostname"`
+	Addr     string   `json:"addr"`
+	Labels   []string `json:"labels"`
+	// Status is empty for hosts that are online, "Offline" or
+	// "Maintenance" otherwise.
+	Status string `json:"status"`
+}
+
+func parseHosts(res commands.Response) ([]Host, error) {
+	l := []Host{}
+	if err := res.NoStatus().Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ListHosts returns the hosts managed by the orchestrator.
+//
+// Similar To:
+//
+//	ceph orch host ls
+func (oa *Admin) ListHosts() ([]Host, error) {
+	m := map[string]string{
+		"prefix": "orch host ls",
+		"format": "json",
+	}
+	return parseHosts(commands.MarshalMgrCommand(oa.conn, m))
+}
@@ -0,0 +1,255 @@
+//go:build ceph_preview
+
+package orch
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// The orchestrator module requires a backend like cephadm that is not
+// available in the test container, so these tests use a phoney connection.
+
+type phoneyConn struct {
+	mgrCommand                func(buf [][]byte) ([]byte, string, error)
+	mgrCommandWithInputBuffer func([][]byte, []byte) ([]byte, string, error)
+}
+
+func (p *phoneyConn) MgrCommand(buf [][]byte) ([]byte, string, error) {
+	return p.mgrCommand(buf)
+}
+func (p *phoneyConn) MgrCommandWithInputBuffer(cbuf [][]byte, dbuf []byte) ([]byte, string, error) {
+	return p.mgrCommandWithInputBuffer(cbuf, dbuf)
+}
+func (*phoneyConn) MonCommand(_ []byte) ([]byte, string, error) {
+	return nil, "", errors.New("unexpected mon command")
+}
+func (*phoneyConn) MonCommandWithInputBuffer(_, _ []byte) ([]byte, string, error) {
+	return nil, "", errors.New("unexpected mon command")
+}
+
+// replyTo returns a phoney connection recording the command sent and
+// replying with the given body
+func replyTo(cmd map[string]interface{}, body string) *phoneyConn {
+	return &phoneyConn{
+		mgrCommand: func(buf [][]byte) ([]byte, string, error) {
+			if err := json.Unmarshal(buf[0], &cmd); err != nil {
+				return nil, "", err
+			}
+			return []byte(body), "", nil
+		},
+	}
+}
+
+func TestParseHosts(t *testing.T) {
+	r := commands.NewResponse([]byte(orchHostLsJSON1), "", nil)
+	l, err := parseHosts(r)
+	require.NoError(t, err)
+	if assert.Len(t, l, 2) {
+		assert.Equal(t, Host{
+			Hostname: "node1",
+			Addr:     "192.168.122.11",
+			Labels:   []string{"_admin", "mon"},
+			Status:   "",
+		}, l[0])
+		assert.Equal(t, "Offline", l[1].Status)
+	}
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	l, err = parseHosts(r)
+	assert.Error(t, err)
+	assert.Nil(t, l)
+}
+
+func TestListDevices(t *testing.T) {
+	cmd := map[string]interface{}{}
+	oa := NewFromConn(replyTo(cmd, orchDeviceLsJSON1))
+	l, err := oa.ListDevices(&ListDevicesOptions{
+		Hostnames: []string{"node1"},
+		Refresh:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "orch device ls", cmd["prefix"])
+	assert.Equal(t, []interface{}{"node1"}, cmd["hostname"])
+	assert.Equal(t, true, cmd["refresh"])
+
+	if assert.Len(t, l, 1) && assert.Len(t, l[0].Devices, 2) {
+		d := l[0].Devices[0]
+		assert.Equal(t, "/dev/vdb", d.Path)
+		assert.True(t, d.Available)
+		assert.Equal(t, uint64(21474836480), d.SysAPI.Size)
+		assert.Equal(t, "1", d.SysAPI.Rotational)
+		d = l[0].Devices[1]
+		assert.False(t, d.Available)
+		assert.Equal(t, []string{"Insufficient space (<5GB)", "Has a FileSystem"},
+			d.RejectedReasons)
+	}
+
+	cmd = map[string]interface{}{}
+	oa = NewFromConn(replyTo(cmd, "[]"))
+	l, err = oa.ListDevices(nil)
+	require.NoError(t, err)
+	assert.Empty(t, l)
+	assert.NotContains(t, cmd, "hostname")
+	assert.NotContains(t, cmd, "refresh")
+}
+
+func TestListDaemons(t *testing.T) {
+	cmd := map[string]interface{}{}
+	oa := NewFromConn(replyTo(cmd, orchPsJSON1))
+	l, err := oa.ListDaemons(&DaemonFilter{DaemonType: "osd", Hostname: "node1"})
+	require.NoError(t, err)
+	assert.Equal(t, "orch ps", cmd["prefix"])
+	assert.Equal(t, "osd", cmd["daemon_type"])
+	assert.Equal(t, "node1", cmd["hostname"])
+	assert.NotContains(t, cmd, "daemon_id")
+
+	if assert.Len(t, l, 2) {
+		assert.Equal(t, "osd.0", l[0].Name)
+		assert.Equal(t, "osd", l[0].Type)
+		assert.Equal(t, "0", l[0].ID)
+		assert.Equal(t, DaemonRunning, l[0].Status)
+		assert.Equal(t, []int{6800}, l[0].Ports)
+		assert.Equal(t,
+			time.Date(2024, 6, 10, 12, 0, 0, 123456000, time.UTC),
+			l[0].LastRefresh)
+		assert.Equal(t, DaemonError, l[1].Status)
+		assert.Equal(t, "error", l[1].StatusDesc)
+	}
+}
+
+func TestDaemonDoAction(t *testing.T) {
+	cmd := map[string]interface{}{}
+	oa := NewFromConn(replyTo(cmd, "Scheduled to restart osd.1 on host 'node1'\n"))
+	msg, err := oa.RestartDaemon("osd.1")
+	require.NoError(t, err)
+	assert.Equal(t, "Scheduled to restart osd.1 on host 'node1'", msg)
+	assert.Equal(t, map[string]interface{}{
+		"prefix": "orch daemon",
+		"action": "restart",
+		"name":   "osd.1",
+	}, cmd)
+
+	oa = NewFromConn(&phoneyConn{
+		mgrCommand: func(_ [][]byte) ([]byte, string, error) {
+			return nil, "Error EINVAL: No orchestrator configured", errors.New("-22")
+		},
+	})
+	_, err = oa.DaemonDoAction(DaemonActionStop, "osd.1")
+	assert.Error(t, err)
+}
+
+func TestApplyService(t *testing.T) {
+	var spec ServiceSpec
+	oa := NewFromConn(&phoneyConn{
+		mgrCommandWithInputBuffer: func(cbuf [][]byte, dbuf []byte) ([]byte, string, error) {
+			cmd := map[string]interface{}{}
+			if err := json.Unmarshal(cbuf[0], &cmd); err != nil {
+				return nil, "", err
+			}
+			if cmd["prefix"] != "orch apply" {
+				return nil, "", errors.New("unexpected command")
+			}
+			if err := json.Unmarshal(dbuf, &spec); err != nil {
+				return nil, "", err
+			}
+			return []byte("Scheduled rgw.foo update...\n"), "", nil
+		},
+	})
+	s := ServiceSpec{
+		ServiceType: "rgw",
+		ServiceID:   "foo",
+		Placement:   &Placement{Count: 2, Label: "rgw"},
+		Spec:        map[string]interface{}{"rgw_frontend_port": float64(8080)},
+	}
+	msg, err := oa.ApplyService(s)
+	require.NoError(t, err)
+	assert.Equal(t, "Scheduled rgw.foo update...", msg)
+	assert.Equal(t, s, spec)
+
+	buf, err := json.Marshal(ServiceSpec{ServiceType: "crash"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"service_type": "crash"}`, string(buf))
+}
+
+var orchHostLsJSON1 = `
+[
+  {"addr": "192.168.122.11", "hostname": "node1", "labels": ["_admin", "mon"], "status": ""},
+  {"addr": "192.168.122.12", "hostname": "node2", "labels": [], "status": "Offline"}
+]
+`
+
+var orchDeviceLsJSON1 = `
+[
+  {
+    "addr": "192.168.122.11",
+    "devices": [
+      {
+        "available": true,
+        "device_id": "0x1af4_vdb",
+        "human_readable_type": "hdd",
+        "lvs": [],
+        "path": "/dev/vdb",
+        "rejected_reasons": [],
+        "sys_api": {
+          "human_readable_size": "20.00 GB",
+          "model": "",
+          "rotational": "1",
+          "size": 21474836480,
+          "vendor": "0x1af4"
+        }
+      },
+      {
+        "available": false,
+        "device_id": "",
+        "human_readable_type": "hdd",
+        "path": "/dev/vda",
+        "rejected_reasons": ["Insufficient space (<5GB)", "Has a FileSystem"],
+        "sys_api": {"rotational": "1", "size": 1073741824}
+      }
+    ],
+    "labels": ["_admin"],
+    "name": "node1"
+  }
+]
+`
+
+var orchPsJSON1 = `
+[
+  {
+    "container_id": "4e2b0d6f1a3c",
+    "container_image_name": "quay.io/ceph/ceph:v18",
+    "created": "2024-06-01T09:00:00.000000Z",
+    "daemon_id": "0",
+    "daemon_name": "osd.0",
+    "daemon_type": "osd",
+    "hostname": "node1",
+    "is_active": false,
+    "last_refresh": "2024-06-10T12:00:00.123456Z",
+    "memory_usage": 104857600,
+    "ports": [6800],
+    "service_name": "osd.default",
+    "started": "2024-06-01T09:00:05.000000Z",
+    "status": 1,
+    "status_desc": "running",
+    "version": "18.2.2"
+  },
+  {
+    "daemon_id": "1",
+    "daemon_name": "osd.1",
+    "daemon_type": "osd",
+    "hostname": "node1",
+    "is_active": false,
+    "service_name": "osd.default",
+    "status": -1,
+    "status_desc": "error"
+  }
+]
+`
@@ -0,0 +1,58 @@
+//go:build ceph_preview
+
+package orch
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// Placement describes on which hosts the daemons of a service are deployed.
+type Placement struct {
+	// Count is the number of daemons to deploy.
+	Count       int      `json:"count,omitempty"`
+	Hosts       []string `json:"hosts,omitempty"`
+	Label       string   `json:"label,omitempty"`
+	HostPattern string   `json:"host_pattern,omitempty"`
+}
+
+// ServiceSpec describes a service to be deployed by the orchestrator.
+type ServiceSpec struct {
+	// ServiceType is the type of the service, for example "mon", "rgw" or
+	// "nfs".
+	ServiceType string `json:"service_type"`
+	// ServiceID names the service for service types that may be deployed
+	// more than once.
+	ServiceID string     `json:"service_id,omitempty"`
+	Placement *Placement `json:"placement,omitempty"`
+	// Unmanaged makes the orchestrator stop deploying and removing the
+	// daemons of the service.
+	Unmanaged bool `json:"unmanaged,omitempty"`
+	// Spec holds the settings specific to the service type.
+	Spec map[string]interface{} `json:"spec,omitempty"`
+}
+
+// ApplyService will make the orchestrator deploy the service described by
+// the spec, creating or updating it. The message of the orchestrator is
+// returned.
+//
+// Similar To:
+//
+//	ceph orch apply -i <spec file>
+func (oa *Admin) ApplyService(spec ServiceSpec) (string, error) {
+	// the orchestrator reads YAML, which JSON is a subset of
+	buf, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	m := map[string]string{
+		"prefix": "orch apply",
+	}
+	res := commands.MarshalMgrCommandWithBuffer(oa.conn, m, buf)
+	if err := res.NoStatus().End(); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(res.Body())), nil
+}
@@ -0,0 +1,254 @@
+//go:build ceph_preview
+
+package osd
+
+import (
+	"sort"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// CrushLocation maps CRUSH bucket types to bucket names, for example
+// {"root": "default", "host": "node1"}, locating an item in the CRUSH
+// hierarchy.
+type CrushLocation map[string]string
+
+// args returns the location as "type=name" arguments.
+func (l CrushLocation) args() []string {
+	a := make([]string, 0, len(l))
+	for t, n := range l {
+		a = append(a, t+"="+n)
+	}
+	sort.Strings(a)
+	return a
+}
+
+// CrushRuleStep is a step of a CRUSH rule.
+type CrushRuleStep struct {
+	// Op is the operation of the step, for example "take",
+	// "chooseleaf_firstn" or "emit".
+	Op       string `json:"op"`
+	Item     int    `json:"item"`
+	ItemName string `json:"item_name"`
+	Num      int    `json:"num"`
+	Type     string `json:"type"`
+}
+
+// CrushRule describes a CRUSH rule.
+type CrushRule struct {
+	ID   int    `json:"rule_id"`
+	Name string `json:"rule_name"`
+	// Type is 1 for replicated and 3 for erasure coded pools.
+	Type  int             `json:"type"`
+	Steps []CrushRuleStep `json:"steps"`
+}
+
+func parseCrushRules(res response) ([]CrushRule, error) {
+	l := []CrushRule{}
+	if err := res.NoStatus().Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// CrushRules returns the CRUSH rules of the cluster.
+//
+// Similar To:
+//
+//	ceph osd crush rule dump
+func (osda *Admin) CrushRules() ([]CrushRule, error) {
+	cmd := map[string]any{
+		"prefix": "osd crush rule dump",
+		"format": "json",
+	}
+	return parseCrushRules(commands.MarshalMonCommand(osda.conn, cmd))
+}
+
+// CrushRuleCreateReplicated creates a CRUSH rule for replicated pools,
+// placing each replica in a different bucket of the failure domain type
+// below the root. The device class may be empty to use all devices.
+//
+// Similar To:
+//
+//	ceph osd crush rule create-replicated <name> <root> <type> [<class>]
+func (osda *Admin) CrushRuleCreateReplicated(name, root, failureDomain, deviceClass string) error {
+	if name == "" || root == "" || failureDomain == "" {
+		return ErrEmptyArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd crush rule create-replicated",
+		"name":   name,
+		"root":   root,
+		"type":   failureDomain,
+	}
+	if deviceClass != "" {
+		cmd["class"] = deviceClass
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// CrushRuleCreateErasure creates a CRUSH rule for erasure coded pools using
+// the given erasure code profile, or the default profile if it is empty.
+//
+// Similar To:
+//
+//	ceph osd crush rule create-erasure <name> [<profile>]
+func (osda *Admin) CrushRuleCreateErasure(name, profile string) error {
+	if name == "" {
+		return ErrEmptyArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd crush rule create-erasure",
+		"name":   name,
+	}
+	if profile != "" {
+		cmd["profile"] = profile
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// CrushRuleRemove removes a CRUSH rule that is not used by any pool.
+//
+// Similar To:
+//
+//	ceph osd crush rule rm <name>
+func (osda *Admin) CrushRuleRemove(name string) error {
+	if name == "" {
+		return ErrEmptyArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd crush rule rm",
+		"name":   name,
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// CrushAddBucket adds a bucket of the given type, for example "host" or
+// "rack", to the CRUSH map. The bucket is placed at the location if it is
+// not empty.
+//
+// Similar To:
+//
+//	ceph osd crush add-bucket <name> <type> [<type>=<name>...]
+func (osda *Admin) CrushAddBucket(name, bucketType string, loc CrushLocation) error {
+	if name == "" || bucketType == "" {
+		return ErrEmptyArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd crush add-bucket",
+		"name":   name,
+		"type":   bucketType,
+	}
+	if len(loc) > 0 {
+		cmd["args"] = loc.args()
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// CrushMove moves a bucket, with everything below it, to the location.
+//
+// Similar To:
+//
+//	ceph osd crush move <name> <type>=<name>...
+func (osda *Admin) CrushMove(name string, loc CrushLocation) error {
+	if name == "" || len(loc) == 0 {
+		return ErrEmptyArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd crush move",
+		"name":   name,
+		"args":   loc.args(),
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// CrushRemove removes an OSD or an empty bucket from the CRUSH map.
+//
+// Similar To:
+//
+//	ceph osd crush rm <name>
+func (osda *Admin) CrushRemove(name string) error {
+	if name == "" {
+		return ErrEmptyArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd crush rm",
+		"name":   name,
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// CrushSetDeviceClass sets the device class, for example "ssd", of the
+// given OSDs. OSDs that already have a class must have it removed first.
+//
+// Similar To:
+//
+//	ceph osd crush set-device-class <class> <id>...
+func (osda *Admin) CrushSetDeviceClass(class string, ids ...int) error {
+	if class == "" || len(ids) == 0 {
+		return ErrEmptyArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd crush set-device-class",
+		"class":  class,
+		"ids":    osdIDs(ids),
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// CrushRemoveDeviceClass removes the device class of the given OSDs.
+//
+// Similar To:
+//
+//	ceph osd crush rm-device-class <id>...
+func (osda *Admin) CrushRemoveDeviceClass(ids ...int) error {
+	if len(ids) == 0 {
+		return ErrEmptyArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd crush rm-device-class",
+		"ids":    osdIDs(ids),
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// CrushReweight sets the CRUSH weight of an OSD, usually its capacity in
+// TiB.
+//
+// Similar To:
+//
+//	ceph osd crush reweight <name> <weight>
+func (osda *Admin) CrushReweight(name string, weight float64) error {
+	if name == "" {
+		return ErrEmptyArgument
+	}
+	if weight < 0 {
+		return ErrInvalidArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd crush reweight",
+		"name":   name,
+		"weight": decimal(weight),
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// CrushReweightSubtree sets the CRUSH weight of all the OSDs below a bucket.
+//
+// Similar To:
+//
+//	ceph osd crush reweight-subtree <name> <weight>
+func (osda *Admin) CrushReweightSubtree(name string, weight float64) error {
+	if name == "" {
+		return ErrEmptyArgument
+	}
+	if weight < 0 {
+		return ErrInvalidArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd crush reweight-subtree",
+		"name":   name,
+		"weight": decimal(weight),
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
@@ -0,0 +1,122 @@
+//go:build ceph_preview
+
+package osd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func TestCrushLocationArgs(t *testing.T) {
+	l := CrushLocation{"root": "default", "host": "node1", "rack": "r1"}
+	assert.Equal(t, []string{"host=node1", "rack=r1", "root=default"}, l.args())
+	assert.Empty(t, CrushLocation(nil).args())
+}
+
+func TestParseCrushRules(t *testing.T) {
+	r := commands.NewResponse([]byte(crushRuleDumpJSON1), "", nil)
+	l, err := parseCrushRules(r)
+	require.NoError(t, err)
+	require.Len(t, l, 1)
+	assert.Equal(t, "replicated_rule", l[0].Name)
+	assert.Equal(t, 1, l[0].Type)
+	assert.Equal(t, []CrushRuleStep{
+		{Op: "take", Item: -1, ItemName: "default"},
+		{Op: "chooseleaf_firstn", Type: "host"},
+		{Op: "emit"},
+	}, l[0].Steps)
+}
+
+func (suite *OSDAdminSuite) TestCrushRules() {
+	t := suite.T()
+	osda := NewFromConn(suite.vconn.Get(t))
+
+	err := osda.CrushRuleCreateReplicated("", "default", "osd", "")
+	assert.ErrorIs(t, err, ErrEmptyArgument)
+
+	err = osda.CrushRuleCreateReplicated("go-ceph-rule", "default", "osd", "")
+	require.NoError(t, err)
+	l, err := osda.CrushRules()
+	require.NoError(t, err)
+	found := false
+	for _, r := range l {
+		if r.Name == "go-ceph-rule" {
+			found = true
+			assert.NotEmpty(t, r.Steps)
+		}
+	}
+	assert.True(t, found)
+
+	err = osda.CrushRuleRemove("go-ceph-rule")
+	require.NoError(t, err)
+	l, err = osda.CrushRules()
+	require.NoError(t, err)
+	for _, r := range l {
+		assert.NotEqual(t, "go-ceph-rule", r.Name)
+	}
+}
+
+func (suite *OSDAdminSuite) TestCrushBuckets() {
+	t := suite.T()
+	osda := NewFromConn(suite.vconn.Get(t))
+
+	err := osda.CrushAddBucket("go-ceph-rack", "rack", nil)
+	require.NoError(t, err)
+	err = osda.CrushAddBucket("go-ceph-host", "host",
+		CrushLocation{"root": "default"})
+	require.NoError(t, err)
+
+	err = osda.CrushMove("go-ceph-rack", CrushLocation{"root": "default"})
+	assert.NoError(t, err)
+	err = osda.CrushMove("go-ceph-host", CrushLocation{"rack": "go-ceph-rack"})
+	assert.NoError(t, err)
+	err = osda.CrushMove("go-ceph-host", nil)
+	assert.ErrorIs(t, err, ErrEmptyArgument)
+
+	err = osda.CrushReweightSubtree("go-ceph-host", 0)
+	assert.NoError(t, err)
+	err = osda.CrushReweight("osd.0", -1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	// the rack is not empty
+	err = osda.CrushRemove("go-ceph-rack")
+	assert.Error(t, err)
+	err = osda.CrushRemove("go-ceph-host")
+	assert.NoError(t, err)
+	err = osda.CrushRemove("go-ceph-rack")
+	assert.NoError(t, err)
+
+	err = osda.CrushSetDeviceClass("ssd")
+	assert.ErrorIs(t, err, ErrEmptyArgument)
+	err = osda.CrushRemoveDeviceClass()
+	assert.ErrorIs(t, err, ErrEmptyArgument)
+}
+
+var crushRuleDumpJSON1 = `
+[
+    {
+        "rule_id": 0,
+        "rule_name": "replicated_rule",
+        "type": 1,
+        "steps": [
+            {
+                "op": "take",
+                "item": -1,
+                "item_name": "default"
+            },
+            {
+                "op": "chooseleaf_firstn",
+                "num": 0,
+                "type": "host"
+            },
+            {
+                "op": "emit"
+            }
+        ]
+    }
+]
+`
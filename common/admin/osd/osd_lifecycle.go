@@ -0,0 +1,207 @@
+//go:build ceph_preview
+
+package osd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// OSDFlag is a cluster wide flag changing how OSDs are handled.
+type OSDFlag string
+
+const (
+	// FlagNoOut prevents OSDs from being marked out automatically.
+	FlagNoOut = OSDFlag("noout")
+	// FlagNoIn prevents booting OSDs from being marked in.
+	FlagNoIn = OSDFlag("noin")
+	// FlagNoUp prevents OSDs from being marked up.
+	FlagNoUp = OSDFlag("noup")
+	// FlagNoDown prevents OSDs from being marked down.
+	FlagNoDown = OSDFlag("nodown")
+	// FlagNoRebalance prevents data from being rebalanced.
+	FlagNoRebalance = OSDFlag("norebalance")
+	// FlagNoRecover prevents data from being recovered.
+	FlagNoRecover = OSDFlag("norecover")
+	// FlagNoBackfill prevents data from being backfilled.
+	FlagNoBackfill = OSDFlag("nobackfill")
+	// FlagNoScrub prevents PGs from being scrubbed.
+	FlagNoScrub = OSDFlag("noscrub")
+	// FlagNoDeepScrub prevents PGs from being deep scrubbed.
+	FlagNoDeepScrub = OSDFlag("nodeep-scrub")
+	// FlagNoSnapTrim prevents deleted snapshots from being trimmed.
+	FlagNoSnapTrim = OSDFlag("nosnaptrim")
+	// FlagPause stops all client reads and writes.
+	FlagPause = OSDFlag("pause")
+)
+
+// decimal is a float64 that is always marshaled to JSON with a decimal point,
+// as ceph rejects integers for float arguments.
+type decimal float64
+
+// MarshalJSON is a custom implementation for the JSON marshaling of decimal.
+func (r decimal) MarshalJSON() ([]byte, error) {
+	s := strconv.FormatFloat(float64(r), 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return []byte(s), nil
+}
+
+func osdIDs(ids []int) []string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	return s
+}
+
+func (osda *Admin) markOSDs(op string, ids []int) error {
+	if len(ids) == 0 {
+		return ErrEmptyArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd " + op,
+		"ids":    osdIDs(ids),
+	}
+	// the status reports the OSDs that were marked
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// OSDOut marks the given OSDs out, moving their data to other OSDs.
+//
+// Similar To:
+//
+//	ceph osd out <id>...
+func (osda *Admin) OSDOut(ids ...int) error {
+	return osda.markOSDs("out", ids)
+}
+
+// OSDIn marks the given OSDs in, allowing data to be placed on them.
+//
+// Similar To:
+//
+//	ceph osd in <id>...
+func (osda *Admin) OSDIn(ids ...int) error {
+	return osda.markOSDs("in", ids)
+}
+
+// OSDDown marks the given OSDs down. Running OSDs will mark themselves up
+// again unless the noup flag is set.
+//
+// Similar To:
+//
+//	ceph osd down <id>...
+func (osda *Admin) OSDDown(ids ...int) error {
+	return osda.markOSDs("down", ids)
+}
+
+func (osda *Admin) removeOSD(op string, id int) error {
+	if id < 0 {
+		return ErrInvalidArgument
+	}
+	cmd := map[string]any{
+		"prefix":               "osd " + op,
+		"id":                   id,
+		"yes_i_really_mean_it": true,
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// OSDDestroy marks the given OSD destroyed, removing its keys while keeping
+// its ID and CRUSH position so that it can be replaced by a new OSD. The
+// OSD must be down.
+//
+// Similar To:
+//
+//	ceph osd destroy <id> --yes-i-really-mean-it
+func (osda *Admin) OSDDestroy(id int) error {
+	return osda.removeOSD("destroy", id)
+}
+
+// OSDPurge removes the given OSD from the cluster, including its ID, keys
+// and CRUSH position. The OSD must be down.
+//
+// Similar To:
+//
+//	ceph osd purge <id> --yes-i-really-mean-it
+func (osda *Admin) OSDPurge(id int) error {
+	return osda.removeOSD("purge", id)
+}
+
+// OSDReweight sets the override weight of the given OSD, between 0 and 1,
+// reducing the share of the data placed on it by CRUSH.
+//
+// Similar To:
+//
+//	ceph osd reweight <id> <weight>
+func (osda *Admin) OSDReweight(id int, weight float64) error {
+	if id < 0 || weight < 0 || weight > 1 {
+		return ErrInvalidArgument
+	}
+	cmd := map[string]any{
+		"prefix": "osd reweight",
+		"id":     id,
+		"weight": decimal(weight),
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// OSDSetFlag sets a cluster wide OSD flag.
+//
+// Similar To:
+//
+//	ceph osd set <flag>
+func (osda *Admin) OSDSetFlag(flag OSDFlag) error {
+	cmd := map[string]any{
+		"prefix": "osd set",
+		"key":    string(flag),
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+// OSDUnsetFlag unsets a cluster wide OSD flag.
+//
+// Similar To:
+//
+//	ceph osd unset <flag>
+func (osda *Admin) OSDUnsetFlag(flag OSDFlag) error {
+	cmd := map[string]any{
+		"prefix": "osd unset",
+		"key":    string(flag),
+	}
+	return commands.MarshalMonCommand(osda.conn, cmd).NoBody().End()
+}
+
+func parseOSDFlags(res response) ([]OSDFlag, error) {
+	d := struct {
+		Flags string `json:"flags"`
+	}{}
+	if err := res.NoStatus().Unmarshal(&d).End(); err != nil {
+		return nil, err
+	}
+	flags := []OSDFlag{}
+	for _, f := range strings.Split(d.Flags, ",") {
+		if f != "" {
+			flags = append(flags, OSDFlag(f))
+		}
+	}
+	return flags, nil
+}
+
+// OSDFlags returns the cluster wide OSD flags that are set. Besides the
+// flags that can be set with OSDSetFlag these include flags that ceph sets
+// itself, like "sortbitwise".
+//
+// Similar To:
+//
+//	ceph osd dump
+func (osda *Admin) OSDFlags() ([]OSDFlag, error) {
+	cmd := map[string]any{
+		"prefix": "osd dump",
+		"format": "json",
+	}
+	return parseOSDFlags(commands.MarshalMonCommand(osda.conn, cmd))
+}
@@ -0,0 +1,91 @@
+//go:build ceph_preview
+
+package osd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func TestDecimalMarshalJSON(t *testing.T) {
+	for f, s := range map[float64]string{
+		1:      "1.0",
+		0:      "0.0",
+		0.5:    "0.5",
+		0.8125: "0.8125",
+	} {
+		b, err := json.Marshal(decimal(f))
+		assert.NoError(t, err)
+		assert.Equal(t, s, string(b))
+	}
+}
+
+func TestParseOSDFlags(t *testing.T) {
+	r := commands.NewResponse(
+		[]byte(`{"epoch": 12, "flags": "noout,sortbitwise,recovery_deletes"}`), "", nil)
+	flags, err := parseOSDFlags(r)
+	require.NoError(t, err)
+	assert.Equal(t, []OSDFlag{FlagNoOut, "sortbitwise", "recovery_deletes"}, flags)
+
+	r = commands.NewResponse([]byte(`{"epoch": 1, "flags": ""}`), "", nil)
+	flags, err = parseOSDFlags(r)
+	require.NoError(t, err)
+	assert.Empty(t, flags)
+}
+
+func (suite *OSDAdminSuite) TestOSDFlags() {
+	t := suite.T()
+	osda := NewFromConn(suite.vconn.Get(t))
+
+	err := osda.OSDSetFlag(FlagNoRebalance)
+	require.NoError(t, err)
+	flags, err := osda.OSDFlags()
+	require.NoError(t, err)
+	assert.Contains(t, flags, FlagNoRebalance)
+
+	err = osda.OSDUnsetFlag(FlagNoRebalance)
+	require.NoError(t, err)
+	flags, err = osda.OSDFlags()
+	require.NoError(t, err)
+	assert.NotContains(t, flags, FlagNoRebalance)
+
+	err = osda.OSDSetFlag("nosuchflag")
+	assert.Error(t, err)
+}
+
+func (suite *OSDAdminSuite) TestOSDMarkAndReweight() {
+	t := suite.T()
+	osda := NewFromConn(suite.vconn.Get(t))
+
+	err := osda.OSDOut()
+	assert.ErrorIs(t, err, ErrEmptyArgument)
+
+	err = osda.OSDSetFlag(FlagNoRebalance)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, osda.OSDUnsetFlag(FlagNoRebalance))
+	}()
+
+	err = osda.OSDOut(0)
+	assert.NoError(t, err)
+	err = osda.OSDIn(0)
+	assert.NoError(t, err)
+
+	err = osda.OSDReweight(0, 1.5)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	err = osda.OSDReweight(0, 0.9)
+	assert.NoError(t, err)
+	err = osda.OSDReweight(0, 1)
+	assert.NoError(t, err)
+
+	// the OSD is up, so it can not be destroyed
+	err = osda.OSDDestroy(0)
+	assert.Error(t, err)
+	err = osda.OSDPurge(-1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
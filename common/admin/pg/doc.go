@@ -0,0 +1,6 @@
+/*
+Package pg from common/admin contains a set of APIs used to inspect the
+placement groups (PGs) of a Ceph cluster and to trigger their scrubbing
+and repair.
+*/
+package pg
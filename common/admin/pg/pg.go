@@ -0,0 +1,241 @@
+//go:build ceph_preview
+
+package pg
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// StatSum contains the object and byte counts of a PG.
+type StatSum struct {
+	NumObjects int64 `json:"num_objects"`
+	NumBytes   int64 `json:"num_bytes"`
+}
+
+// Stat describes the state of a PG.
+type Stat struct {
+	// PGID is the ID of the PG, the pool ID and the PG number within the
+	// pool as in "1.1f".
+	PGID string `json:"pgid"`
+	// State is the state of the PG, as in "active+clean".
+	State         string `json:"state"`
+	Up            []int  `json:"up"`
+	UpPrimary     int    `json:"up_primary"`
+	Acting        []int  `json:"acting"`
+	ActingPrimary int    `json:"acting_primary"`
+
+	// the fields below are not set by Summary
+
+	StatSum            StatSum `json:"stat_sum"`
+	LastScrubStamp     string  `json:"last_scrub_stamp"`
+	LastDeepScrubStamp string  `json:"last_deep_scrub_stamp"`
+}
+
+// Pool returns the ID of the pool of the PG.
+func (s *Stat) Pool() int64 {
+	p, _, _ := strings.Cut(s.PGID, ".")
+	id, err := strconv.ParseInt(p, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return id
+}
+
+// States returns the individual states of the PG, as in ["active", "clean"].
+func (s *Stat) States() []string {
+	return strings.Split(s.State, "+")
+}
+
+// HasState returns true if the PG is in the given state, for example
+// "inconsistent".
+func (s *Stat) HasState(state string) bool {
+	for _, st := range s.States() {
+		if st == state {
+			return true
+		}
+	}
+	return false
+}
+
+// stats decodes the PG stats. Ceph releases before Pacific return a list,
+// later ones an object wrapping the list.
+type stats []Stat
+
+func (l *stats) UnmarshalJSON(data []byte) error {
+	var list []Stat
+	if err := json.Unmarshal(data, &list); err == nil {
+		*l = list
+		return nil
+	}
+	v := struct {
+		PGStats []Stat `json:"pg_stats"`
+	}{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*l = v.PGStats
+	return nil
+}
+
+// StateCounts counts the PGs by state.
+type StateCounts struct {
+	Total int `json:"total"`
+	// States maps the states, as in "active+clean", to the number of PGs in
+	// that state.
+	States map[string]int `json:"states"`
+}
+
+func (c *StateCounts) add(s Stat) {
+	if c.States == nil {
+		c.States = map[string]int{}
+	}
+	c.Total++
+	c.States[s.State]++
+}
+
+// Summary counts the PGs of the cluster by state, overall and per pool.
+type Summary struct {
+	StateCounts
+	// Pools maps the pool IDs to the state counts of their PGs.
+	Pools map[int64]*StateCounts `json:"pools"`
+}
+
+func summarize(l []Stat) *Summary {
+	s := &Summary{
+		StateCounts: StateCounts{States: map[string]int{}},
+		Pools:       map[int64]*StateCounts{},
+	}
+	for _, st := range l {
+		s.add(st)
+		p := st.Pool()
+		if s.Pools[p] == nil {
+			s.Pools[p] = &StateCounts{}
+		}
+		s.Pools[p].add(st)
+	}
+	return s
+}
+
+func parseStats(res commands.Response) ([]Stat, error) {
+	l := stats{}
+	// the status reports that the PGs were dumped
+	if err := res.Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Summary returns the number of PGs by state, overall and per pool.
+//
+// Similar To:
+//
+//	ceph pg dump pgs_brief
+func (pa *Admin) Summary() (*Summary, error) {
+	m := map[string]interface{}{
+		"prefix":       "pg dump",
+		"dumpcontents": []string{"pgs_brief"},
+		"format":       "json",
+	}
+	l, err := parseStats(commands.MarshalMgrCommand(pa.conn, m))
+	if err != nil {
+		return nil, err
+	}
+	return summarize(l), nil
+}
+
+// List returns the PGs of the cluster, limited to the PGs that are in all
+// of the given states, if any.
+//
+// Similar To:
+//
+//	ceph pg ls [<state>...]
+func (pa *Admin) List(states ...string) ([]Stat, error) {
+	m := map[string]interface{}{
+		"prefix": "pg ls",
+		"format": "json",
+	}
+	if len(states) > 0 {
+		m["states"] = states
+	}
+	return parseStats(commands.MarshalMgrCommand(pa.conn, m))
+}
+
+// ListPool returns the PGs of the named pool, limited to the PGs that are in
+// all of the given states, if any.
+//
+// Similar To:
+//
+//	ceph pg ls-by-pool <pool> [<state>...]
+func (pa *Admin) ListPool(pool string, states ...string) ([]Stat, error) {
+	m := map[string]interface{}{
+		"prefix":  "pg ls-by-pool",
+		"poolstr": pool,
+		"format":  "json",
+	}
+	if len(states) > 0 {
+		m["states"] = states
+	}
+	return parseStats(commands.MarshalMgrCommand(pa.conn, m))
+}
+
+// ListInconsistent returns the IDs of the PGs of the named pool that scrubbing
+// found to be inconsistent, ordered by ID.
+//
+// Similar To:
+//
+//	rados list-inconsistent-pg <pool>
+func (pa *Admin) ListInconsistent(pool string) ([]string, error) {
+	l, err := pa.ListPool(pool, "inconsistent")
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(l))
+	for _, s := range l {
+		ids = append(ids, s.PGID)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (pa *Admin) instruct(op, pgid string) error {
+	m := map[string]string{
+		"prefix": "pg " + op,
+		"pgid":   pgid,
+	}
+	// the status reports the OSD that was instructed
+	return commands.MarshalMgrCommand(pa.conn, m).NoBody().End()
+}
+
+// Scrub will instruct the primary OSD of the PG to scrub it.
+//
+// Similar To:
+//
+//	ceph pg scrub <pgid>
+func (pa *Admin) Scrub(pgid string) error {
+	return pa.instruct("scrub", pgid)
+}
+
+// DeepScrub will instruct the primary OSD of the PG to deep scrub it,
+// reading and comparing all object data.
+//
+// Similar To:
+//
+//	ceph pg deep-scrub <pgid>
+func (pa *Admin) DeepScrub(pgid string) error {
+	return pa.instruct("deep-scrub", pgid)
+}
+
+// Repair will instruct the primary OSD of the PG to repair its
+// inconsistencies.
+//
+// Similar To:
+//
+//	ceph pg repair <pgid>
+func (pa *Admin) Repair(pgid string) error {
+	return pa.instruct("repair", pgid)
+}
@@ -0,0 +1,134 @@
+//go:build ceph_preview
+
+package pg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func TestStat(t *testing.T) {
+	s := Stat{PGID: "12.1f", State: "active+clean+inconsistent"}
+	assert.Equal(t, int64(12), s.Pool())
+	assert.Equal(t, []string{"active", "clean", "inconsistent"}, s.States())
+	assert.True(t, s.HasState("inconsistent"))
+	assert.False(t, s.HasState("incons"))
+
+	s.PGID = "bad"
+	assert.Equal(t, int64(-1), s.Pool())
+}
+
+func TestParseStats(t *testing.T) {
+	t.Run("object", func(t *testing.T) {
+		r := commands.NewResponse([]byte(pgDumpBriefJSON1), "dumped pgs_brief", nil)
+		l, err := parseStats(r)
+		require.NoError(t, err)
+		if assert.Len(t, l, 4) {
+			assert.Equal(t, Stat{
+				PGID:          "1.0",
+				State:         "active+clean",
+				Up:            []int{0},
+				UpPrimary:     0,
+				Acting:        []int{0},
+				ActingPrimary: 0,
+			}, l[0])
+		}
+	})
+	t.Run("list", func(t *testing.T) {
+		r := commands.NewResponse([]byte(pgLsJSON1), "", nil)
+		l, err := parseStats(r)
+		require.NoError(t, err)
+		if assert.Len(t, l, 1) {
+			assert.Equal(t, int64(3), l[0].StatSum.NumObjects)
+			assert.Equal(t, int64(12288), l[0].StatSum.NumBytes)
+			assert.Equal(t, "2024-06-10T12:00:00.123456+0000", l[0].LastDeepScrubStamp)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		l, err := parseStats(r)
+		assert.Error(t, err)
+		assert.Nil(t, l)
+	})
+}
+
+func TestSummarize(t *testing.T) {
+	r := commands.NewResponse([]byte(pgDumpBriefJSON1), "", nil)
+	l, err := parseStats(r)
+	require.NoError(t, err)
+	s := summarize(l)
+	assert.Equal(t, 4, s.Total)
+	assert.Equal(t, map[string]int{
+		"active+clean":              3,
+		"active+clean+inconsistent": 1,
+	}, s.States)
+	if assert.Len(t, s.Pools, 2) {
+		assert.Equal(t, &StateCounts{
+			Total:  1,
+			States: map[string]int{"active+clean": 1},
+		}, s.Pools[1])
+		assert.Equal(t, 3, s.Pools[2].Total)
+		assert.Equal(t, 1, s.Pools[2].States["active+clean+inconsistent"])
+	}
+}
+
+func TestPGs(t *testing.T) {
+	ra := radosConnector.Get(t)
+	pa := NewFromConn(ra)
+
+	s, err := pa.Summary()
+	require.NoError(t, err)
+	require.NotZero(t, s.Total)
+
+	l, err := pa.List()
+	require.NoError(t, err)
+	require.Len(t, l, s.Total)
+
+	err = pa.Scrub(l[0].PGID)
+	assert.NoError(t, err)
+	err = pa.DeepScrub(l[0].PGID)
+	assert.NoError(t, err)
+	err = pa.Repair("999.0")
+	assert.Error(t, err)
+}
+
+var pgDumpBriefJSON1 = `
+{
+    "pg_ready": true,
+    "pg_stats": [
+        {"pgid": "1.0", "state": "active+clean", "up": [0], "acting": [0], "up_primary": 0, "acting_primary": 0},
+        {"pgid": "2.0", "state": "active+clean", "up": [0], "acting": [0], "up_primary": 0, "acting_primary": 0},
+        {"pgid": "2.1", "state": "active+clean+inconsistent", "up": [0], "acting": [0], "up_primary": 0, "acting_primary": 0},
+        {"pgid": "2.2", "state": "active+clean", "up": [0], "acting": [0], "up_primary": 0, "acting_primary": 0}
+    ]
+}
+`
+
+var pgLsJSON1 = `
+[
+    {
+        "pgid": "2.1",
+        "version": "12'3",
+        "state": "active+clean+inconsistent",
+        "stat_sum": {
+            "num_bytes": 12288,
+            "num_objects": 3,
+            "num_object_clones": 0
+        },
+        "up": [0],
+        "acting": [0],
+        "up_primary": 0,
+        "acting_primary": 0,
+        "last_scrub_stamp": "2024-06-10T12:00:00.123456+0000",
+        "last_deep_scrub_stamp": "2024-06-10T12:00:00.123456+0000"
+    }
+]
+`
@@ -0,0 +1,6 @@
+/*
+Package progress from common/admin contains a set of APIs used to interact
+with the progress module of the Ceph manager (mgr), reporting on the
+progress of long running cluster operations like recovery and rebalancing.
+*/
+package progress
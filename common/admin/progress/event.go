@@ -0,0 +1,136 @@
+//go:build ceph_preview
+
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// EventRef refers to an object of the cluster an event is about, for example
+// a pool or an OSD.
+type EventRef struct {
+	Type string
+	ID   string
+}
+
+// UnmarshalJSON decodes the [type, id] pair of an event reference. The id is
+// a number or a string depending on the type of the referenced object.
+func (r *EventRef) UnmarshalJSON(data []byte) error {
+	var pair []json.RawMessage
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("invalid event reference: %s", string(data))
+	}
+	if err := json.Unmarshal(pair[0], &r.Type); err != nil {
+		return err
+	}
+	var id interface{}
+	if err := json.Unmarshal(pair[1], &id); err != nil {
+		return err
+	}
+	r.ID = fmt.Sprint(id)
+	return nil
+}
+
+// epochTime is a time reported as fractional seconds since the epoch.
+type epochTime time.Time
+
+func (et *epochTime) UnmarshalJSON(data []byte) error {
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	sec, frac := math.Modf(f)
+	*et = epochTime(time.Unix(int64(sec), int64(frac*1e9)))
+	return nil
+}
+
+// Event describes an operation tracked by the progress module.
+type Event struct {
+	ID      string     `json:"id"`
+	Message string     `json:"message"`
+	Refs    []EventRef `json:"refs"`
+	// Progress is the completed fraction of the operation, from 0 to 1.
+	// It is only reported for events in progress.
+	Progress float64 `json:"progress"`
+	// Duration is a human readable duration of the event.
+	Duration string `json:"duration"`
+	// StartedAt is the time the event started.
+	StartedAt time.Time `json:"-"`
+	// FinishedAt is the time a completed event finished. It is zero for
+	// events in progress.
+	FinishedAt time.Time `json:"-"`
+	// Failed is set if a completed event failed.
+	Failed         bool   `json:"failed"`
+	FailureMessage string `json:"failure_message"`
+}
+
+// UnmarshalJSON decodes an event, converting its times.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type event Event
+	v := struct {
+		*event
+		StartedAt  *epochTime `json:"started_at"`
+		FinishedAt *epochTime `json:"finished_at"`
+	}{event: (*event)(e)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.StartedAt != nil {
+		e.StartedAt = time.Time(*v.StartedAt)
+	}
+	if v.FinishedAt != nil {
+		e.FinishedAt = time.Time(*v.FinishedAt)
+	}
+	return nil
+}
+
+// Events contains the events reported by the progress module.
+type Events struct {
+	// InProgress lists the events of operations that are still running.
+	InProgress []Event `json:"events"`
+	// Completed lists the events of recently finished operations.
+	Completed []Event `json:"completed"`
+}
+
+func parseEvents(res commands.Response) (*Events, error) {
+	e := &Events{}
+	if err := res.NoStatus().Unmarshal(e).End(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Events returns the events in progress and the recently completed events of
+// the progress module.
+//
+// Similar To:
+//
+//	ceph progress json
+func (pa *Admin) Events() (*Events, error) {
+	m := map[string]string{
+		"prefix": "progress json",
+		"format": "json",
+	}
+	return parseEvents(commands.MarshalMgrCommand(pa.conn, m))
+}
+
+// Clear will reset the progress module, dropping all events.
+//
+// Similar To:
+//
+//	ceph progress clear
+func (pa *Admin) Clear() error {
+	m := map[string]string{
+		"prefix": "progress clear",
+		"format": "json",
+	}
+	return commands.MarshalMgrCommand(pa.conn, m).NoData().End()
+}
@@ -0,0 +1,116 @@
+//go:build ceph_preview
+
+package progress
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func TestParseEvents(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(progressJSON1), "", nil)
+		e, err := parseEvents(r)
+		require.NoError(t, err)
+		if assert.Len(t, e.InProgress, 1) {
+			ev := e.InProgress[0]
+			assert.Equal(t, "9e5a4dc2-9d66-4bd4-b3b8-9f4ac2a3c7e1", ev.ID)
+			assert.Equal(t, "Global Recovery Event", ev.Message)
+			assert.InDelta(t, 0.25, ev.Progress, 0.0001)
+			assert.Equal(t, time.Unix(1718000000, 500000000), ev.StartedAt)
+			assert.True(t, ev.FinishedAt.IsZero())
+			assert.Equal(t, []EventRef{{Type: "pool", ID: "1"}}, ev.Refs)
+		}
+		if assert.Len(t, e.Completed, 2) {
+			ev := e.Completed[0]
+			assert.Equal(t, "Rebalancing after osd.1 marked in", ev.Message)
+			assert.Equal(t, time.Unix(1717990000, 0), ev.StartedAt)
+			assert.Equal(t, time.Unix(1717990060, 0), ev.FinishedAt)
+			assert.False(t, ev.Failed)
+			assert.Equal(t, []EventRef{{Type: "osd", ID: "1"}}, ev.Refs)
+
+			ev = e.Completed[1]
+			assert.True(t, ev.Failed)
+			assert.Equal(t, "aborted", ev.FailureMessage)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		r := commands.NewResponse([]byte(`{"events": [], "completed": []}`), "", nil)
+		e, err := parseEvents(r)
+		require.NoError(t, err)
+		assert.Empty(t, e.InProgress)
+		assert.Empty(t, e.Completed)
+	})
+	t.Run("invalidRef", func(t *testing.T) {
+		r := commands.NewResponse([]byte(
+			`{"events": [{"id": "x", "refs": [["pool"]]}], "completed": []}`), "", nil)
+		_, err := parseEvents(r)
+		assert.Error(t, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		e, err := parseEvents(r)
+		assert.Error(t, err)
+		assert.Nil(t, e)
+	})
+}
+
+func TestEvents(t *testing.T) {
+	ra := radosConnector.Get(t)
+	pa := NewFromConn(ra)
+
+	e, err := pa.Events()
+	require.NoError(t, err)
+	assert.NotNil(t, e)
+
+	err = pa.Clear()
+	require.NoError(t, err)
+	e, err = pa.Events()
+	require.NoError(t, err)
+	assert.Empty(t, e.Completed)
+}
+
+var progressJSON1 = `
+{
+    "events": [
+        {
+            "id": "9e5a4dc2-9d66-4bd4-b3b8-9f4ac2a3c7e1",
+            "message": "Global Recovery Event",
+            "duration": "2m",
+            "refs": [["pool", 1]],
+            "progress": 0.25,
+            "started_at": 1718000000.5,
+            "time_remaining": 360
+        }
+    ],
+    "completed": [
+        {
+            "id": "0fa3a8f6-e6f8-4d0b-a7c2-3f1e0dfe4e6d",
+            "message": "Rebalancing after osd.1 marked in",
+            "refs": [["osd", "1"]],
+            "started_at": 1717990000.0,
+            "finished_at": 1717990060.0,
+            "add_to_ceph_s:": true
+        },
+        {
+            "id": "b2a9e4c4-2e35-4d8d-8ad6-6a4bc3bdb0a8",
+            "message": "Global Recovery Event",
+            "refs": [],
+            "started_at": 1717980000.0,
+            "finished_at": 1717980030.0,
+            "add_to_ceph_s:": true,
+            "failed": true,
+            "failure_message": "aborted"
+        }
+    ]
+}
+`
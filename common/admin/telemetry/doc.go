@@ -0,0 +1,6 @@
+/*
+Package telemetry from common/admin contains a set of APIs used to interact
+with the telemetry module of the Ceph manager (mgr), controlling and
+inspecting the data the cluster reports to the Ceph project.
+*/
+package telemetry
@@ -0,0 +1,179 @@
+//go:build ceph_preview
+
+package telemetry
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// Channel is a category of data reported by the telemetry module.
+type Channel string
+
+const (
+	// BasicChannel reports basic information about the cluster.
+	BasicChannel = Channel("basic")
+	// CrashChannel reports information about daemon crashes.
+	CrashChannel = Channel("crash")
+	// DeviceChannel reports device health metrics.
+	DeviceChannel = Channel("device")
+	// IdentChannel reports user provided identifying information.
+	IdentChannel = Channel("ident")
+	// PerfChannel reports various performance metrics of the cluster.
+	PerfChannel = Channel("perf")
+)
+
+// LicenseSharing is the license the reported data is shared under, which has
+// to be agreed to when switching telemetry on.
+const LicenseSharing = "sharing-1-0"
+
+// Status reports the settings of the telemetry module.
+type Status struct {
+	Enabled bool `json:"enabled"`
+	// LastOptRevision is the revision of the report the cluster opted in
+	// to. Telemetry is not sent if it is older than the current revision.
+	LastOptRevision int    `json:"last_opt_revision"`
+	URL             string `json:"url"`
+	DeviceURL       string `json:"device_url"`
+	// Interval is the number of hours between reports.
+	Interval     int    `json:"interval"`
+	Leaderboard  bool   `json:"leaderboard"`
+	Contact      string `json:"contact"`
+	Description  string `json:"description"`
+	Organization string `json:"organization"`
+	Proxy        string `json:"proxy"`
+
+	ChannelBasic  bool `json:"channel_basic"`
+	ChannelCrash  bool `json:"channel_crash"`
+	ChannelDevice bool `json:"channel_device"`
+	ChannelIdent  bool `json:"channel_ident"`
+	ChannelPerf   bool `json:"channel_perf"`
+}
+
+// Channels returns the channels that are enabled.
+func (s *Status) Channels() []Channel {
+	c := []Channel{}
+	for _, e := range []struct {
+		on bool
+		ch Channel
+	}{
+		{s.ChannelBasic, BasicChannel},
+		{s.ChannelCrash, CrashChannel},
+		{s.ChannelDevice, DeviceChannel},
+		{s.ChannelIdent, IdentChannel},
+		{s.ChannelPerf, PerfChannel},
+	} {
+		if e.on {
+			c = append(c, e.ch)
+		}
+	}
+	return c
+}
+
+// Report is a telemetry report as sent by the telemetry module.
+type Report map[string]interface{}
+
+func parseStatus(res commands.Response) (*Status, error) {
+	s := &Status{}
+	if err := res.NoStatus().Unmarshal(s).End(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func parseReport(res commands.Response) (Report, error) {
+	r := Report{}
+	if err := res.NoStatus().Unmarshal(&r).End(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func channelStrings(channels []Channel) []string {
+	s := make([]string, len(channels))
+	for i, c := range channels {
+		s[i] = string(c)
+	}
+	return s
+}
+
+// Status returns the settings of the telemetry module.
+//
+// Similar To:
+//
+//	ceph telemetry status
+func (ta *Admin) Status() (*Status, error) {
+	m := map[string]string{
+		"prefix": "telemetry status",
+		"format": "json",
+	}
+	return parseStatus(commands.MarshalMgrCommand(ta.conn, m))
+}
+
+// Preview returns the report that would be sent if telemetry was switched
+// on, limited to the given channels. If no channels are given the report
+// covers the enabled channels.
+//
+// Similar To:
+//
+//	ceph telemetry preview [<channel>...]
+func (ta *Admin) Preview(channels ...Channel) (Report, error) {
+	m := map[string]interface{}{
+		"prefix": "telemetry preview",
+		"format": "json",
+	}
+	if len(channels) > 0 {
+		m["channels"] = channelStrings(channels)
+	}
+	return parseReport(commands.MarshalMgrCommand(ta.conn, m))
+}
+
+// On will switch telemetry on, agreeing to the LicenseSharing license.
+//
+// Similar To:
+//
+//	ceph telemetry on --license sharing-1-0
+func (ta *Admin) On() error {
+	m := map[string]string{
+		"prefix":  "telemetry on",
+		"license": LicenseSharing,
+	}
+	return commands.MarshalMgrCommand(ta.conn, m).NoStatus().End()
+}
+
+// Off will switch telemetry off.
+//
+// Similar To:
+//
+//	ceph telemetry off
+func (ta *Admin) Off() error {
+	m := map[string]string{
+		"prefix": "telemetry off",
+	}
+	return commands.MarshalMgrCommand(ta.conn, m).NoStatus().End()
+}
+
+// EnableChannels will enable the given telemetry channels.
+//
+// Similar To:
+//
+//	ceph telemetry enable channel <channel>...
+func (ta *Admin) EnableChannels(channels ...Channel) error {
+	m := map[string]interface{}{
+		"prefix":   "telemetry enable channel",
+		"channels": channelStrings(channels),
+	}
+	return commands.MarshalMgrCommand(ta.conn, m).NoStatus().End()
+}
+
+// DisableChannels will disable the given telemetry channels.
+//
+// Similar To:
+//
+//	ceph telemetry disable channel <channel>...
+func (ta *Admin) DisableChannels(channels ...Channel) error {
+	m := map[string]interface{}{
+		"prefix":   "telemetry disable channel",
+		"channels": channelStrings(channels),
+	}
+	return commands.MarshalMgrCommand(ta.conn, m).NoStatus().End()
+}
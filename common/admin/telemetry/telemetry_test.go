@@ -0,0 +1,112 @@
+//go:build ceph_preview
+
+package telemetry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func TestParseStatus(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(telemetryStatusJSON1), "", nil)
+		s, err := parseStatus(r)
+		require.NoError(t, err)
+		assert.False(t, s.Enabled)
+		assert.Equal(t, 3, s.LastOptRevision)
+		assert.Equal(t, 24, s.Interval)
+		assert.Equal(t, "https://telemetry.ceph.com/report", s.URL)
+		assert.Equal(t,
+			[]Channel{BasicChannel, CrashChannel, DeviceChannel}, s.Channels())
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		s, err := parseStatus(r)
+		assert.Error(t, err)
+		assert.Nil(t, s)
+	})
+}
+
+func TestParseReport(t *testing.T) {
+	r := commands.NewResponse([]byte(`{"report_version": 1, "channels": ["basic"]}`), "", nil)
+	rep, err := parseReport(r)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), rep["report_version"])
+
+	r = commands.NewResponse([]byte(`Telemetry is off.`), "", nil)
+	_, err = parseReport(r)
+	assert.Error(t, err)
+}
+
+func TestStatusAndPreview(t *testing.T) {
+	ra := radosConnector.Get(t)
+	ta := NewFromConn(ra)
+
+	s, err := ta.Status()
+	require.NoError(t, err)
+	assert.NotEmpty(t, s.URL)
+
+	rep, err := ta.Preview(BasicChannel)
+	require.NoError(t, err)
+	assert.Contains(t, rep, "report_id")
+}
+
+func TestEnableDisableChannels(t *testing.T) {
+	ra := radosConnector.Get(t)
+	ta := NewFromConn(ra)
+
+	s, err := ta.Status()
+	require.NoError(t, err)
+	perf := s.ChannelPerf
+
+	err = ta.EnableChannels(PerfChannel)
+	require.NoError(t, err)
+	s, err = ta.Status()
+	require.NoError(t, err)
+	assert.True(t, s.ChannelPerf)
+
+	err = ta.DisableChannels(PerfChannel)
+	require.NoError(t, err)
+	s, err = ta.Status()
+	require.NoError(t, err)
+	assert.False(t, s.ChannelPerf)
+
+	if perf {
+		err = ta.EnableChannels(PerfChannel)
+		assert.NoError(t, err)
+	}
+}
+
+var telemetryStatusJSON1 = `
+{
+    "channel_basic": true,
+    "channel_crash": true,
+    "channel_device": true,
+    "channel_ident": false,
+    "channel_perf": false,
+    "contact": "",
+    "description": "",
+    "device_url": "https://telemetry.ceph.com/device",
+    "enabled": false,
+    "interval": 24,
+    "last_opt_revision": 3,
+    "leaderboard": false,
+    "leaderboard_description": "",
+    "log_level": "",
+    "log_to_cluster": false,
+    "log_to_cluster_level": "info",
+    "log_to_file": false,
+    "organization": "",
+    "proxy": "",
+    "sqlite3_killpoint": 0,
+    "url": "https://telemetry.ceph.com/report"
+}
+`
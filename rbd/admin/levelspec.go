@@ -0,0 +1,103 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidLevelSpec is returned when a level spec can not be parsed.
+var ErrInvalidLevelSpec = errors.New("invalid level spec")
+
+// NewPoolLevelSpec returns a LevelSpec selecting all images in the given
+// pool.
+func NewPoolLevelSpec(pool string) LevelSpec {
+	return NewLevelSpec(pool, "", "")
+}
+
+// NewNamespaceLevelSpec returns a LevelSpec selecting all images in the given
+// namespace of the pool.
+func NewNamespaceLevelSpec(pool, namespace string) LevelSpec {
+	return NewLevelSpec(pool, namespace, "")
+}
+
+// NewImageLevelSpec returns a LevelSpec selecting a single image. The
+// namespace may be empty to select an image in the default namespace of the
+// pool.
+func NewImageLevelSpec(pool, namespace, image string) LevelSpec {
+	return NewLevelSpec(pool, namespace, image)
+}
+
+// ParseLevelSpec parses and validates a level spec in the form used by the
+// rbd command line tools:
+//
+//	<pool>[/<namespace>][/<image>]
+//
+// A pool or namespace level spec may be given with or without a trailing
+// slash. An ErrInvalidLevelSpec error is returned if the spec is malformed.
+func ParseLevelSpec(spec string) (LevelSpec, error) {
+	invalid := func(reason string) (LevelSpec, error) {
+		return LevelSpec{}, fmt.Errorf("%w: %q: %s",
+			ErrInvalidLevelSpec, spec, reason)
+	}
+	if strings.ContainsAny(spec, "@") {
+		return invalid("snapshots are not supported")
+	}
+	parts := strings.Split(spec, "/")
+	if parts[0] == "" {
+		return invalid("missing pool name")
+	}
+	switch len(parts) {
+	case 1:
+		// <pool>
+		return NewPoolLevelSpec(parts[0]), nil
+	case 2:
+		// <pool>/ or <pool>/<image>
+		return NewLevelSpec(parts[0], "", parts[1]), nil
+	case 3:
+		// <pool>/<namespace>/ or <pool>/<namespace>/<image>
+		if parts[1] == "" {
+			return invalid("missing namespace name")
+		}
+		return NewLevelSpec(parts[0], parts[1], parts[2]), nil
+	}
+	return invalid("too many components")
+}
+
+func (l LevelSpec) components() (pool, namespace, image string) {
+	parts := strings.Split(l.spec, "/")
+	switch len(parts) {
+	case 1:
+		return parts[0], "", ""
+	case 2:
+		return parts[0], "", parts[1]
+	}
+	return parts[0], parts[1], parts[2]
+}
+
+// Pool returns the name of the pool selected by the level spec.
+func (l LevelSpec) Pool() string {
+	pool, _, _ := l.components()
+	return pool
+}
+
+// Namespace returns the name of the namespace selected by the level spec or
+// an empty string if the level spec does not select a namespace.
+func (l LevelSpec) Namespace() string {
+	_, namespace, _ := l.components()
+	return namespace
+}
+
+// Image returns the name of the image selected by the level spec or an empty
+// string if the level spec selects an entire pool or namespace.
+func (l LevelSpec) Image() string {
+	_, _, image := l.components()
+	return image
+}
+
+// String returns the level spec in the form passed to ceph.
+func (l LevelSpec) String() string {
+	return l.spec
+}
@@ -0,0 +1,56 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelSpecConstructors(t *testing.T) {
+	assert.Equal(t, "rbd/", NewPoolLevelSpec("rbd").String())
+	assert.Equal(t, "rbd/ns/", NewNamespaceLevelSpec("rbd", "ns").String())
+	assert.Equal(t, "rbd/img", NewImageLevelSpec("rbd", "", "img").String())
+	assert.Equal(t, "rbd/ns/img", NewImageLevelSpec("rbd", "ns", "img").String())
+}
+
+func TestParseLevelSpec(t *testing.T) {
+	tests := []struct {
+		spec      string
+		want      string
+		pool      string
+		namespace string
+		image     string
+	}{
+		{"rbd", "rbd/", "rbd", "", ""},
+		{"rbd/", "rbd/", "rbd", "", ""},
+		{"rbd/img", "rbd/img", "rbd", "", "img"},
+		{"rbd/ns/", "rbd/ns/", "rbd", "ns", ""},
+		{"rbd/ns/img", "rbd/ns/img", "rbd", "ns", "img"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			l, err := ParseLevelSpec(tt.spec)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, l.String())
+			assert.Equal(t, tt.pool, l.Pool())
+			assert.Equal(t, tt.namespace, l.Namespace())
+			assert.Equal(t, tt.image, l.Image())
+		})
+	}
+
+	invalid := []string{
+		"",
+		"/img",
+		"rbd//img",
+		"rbd/ns/img/extra",
+		"rbd/img@snap",
+	}
+	for _, spec := range invalid {
+		t.Run("invalid:"+spec, func(t *testing.T) {
+			_, err := ParseLevelSpec(spec)
+			assert.ErrorIs(t, err, ErrInvalidLevelSpec)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+const mirrorSnapshotRetentionOption = "rbd_mirroring_max_mirroring_snapshots"
+
+// SetMirrorSnapshotRetention sets the maximum number of mirror snapshots that
+// are retained per image for all rbd clients. Once the limit is reached the
+// oldest mirror snapshot of an image is removed when a new one is created.
+// Ceph enforces a minimum value of 3.
+//
+// The retention can be overridden for individual pools or images by setting
+// the "conf_rbd_mirroring_max_mirroring_snapshots" pool or image metadata
+// using the rbd package.
+//
+// Similar To:
+//
+//	ceph config set client rbd_mirroring_max_mirroring_snapshots <count>
+func (ra *RBDAdmin) SetMirrorSnapshotRetention(count int) error {
+	m := map[string]string{
+		"prefix": "config set",
+		"who":    "client",
+		"name":   mirrorSnapshotRetentionOption,
+		"value":  strconv.Itoa(count),
+	}
+	return commands.MarshalMonCommand(ra.conn, m).NoData().End()
+}
+
+// GetMirrorSnapshotRetention returns the maximum number of mirror snapshots
+// that are retained per image for all rbd clients.
+//
+// Similar To:
+//
+//	ceph config get client rbd_mirroring_max_mirroring_snapshots
+func (ra *RBDAdmin) GetMirrorSnapshotRetention() (int, error) {
+	m := map[string]string{
+		"prefix": "config get",
+		"who":    "client",
+		"key":    mirrorSnapshotRetentionOption,
+	}
+	return parseMirrorSnapshotRetention(commands.MarshalMonCommand(ra.conn, m))
+}
+
+func parseMirrorSnapshotRetention(res commands.Response) (int, error) {
+	if err := res.NoStatus().End(); err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(res.Body())))
+}
+
+// ResetMirrorSnapshotRetention removes a previously set mirror snapshot
+// retention, restoring the ceph default.
+//
+// Similar To:
+//
+//	ceph config rm client rbd_mirroring_max_mirroring_snapshots
+func (ra *RBDAdmin) ResetMirrorSnapshotRetention() error {
+	m := map[string]string{
+		"prefix": "config rm",
+		"who":    "client",
+		"name":   mirrorSnapshotRetentionOption,
+	}
+	return commands.MarshalMonCommand(ra.conn, m).NoData().End()
+}
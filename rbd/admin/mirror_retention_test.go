@@ -0,0 +1,53 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func TestParseMirrorSnapshotRetention(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte("5\n"), "", nil)
+		n, err := parseMirrorSnapshotRetention(r)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, n)
+	})
+	t.Run("notANumber", func(t *testing.T) {
+		r := commands.NewResponse([]byte("five\n"), "", nil)
+		_, err := parseMirrorSnapshotRetention(r)
+		assert.Error(t, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse([]byte{}, "", errors.New("yikes"))
+		_, err := parseMirrorSnapshotRetention(r)
+		assert.Error(t, err)
+	})
+}
+
+func TestMirrorSnapshotRetention(t *testing.T) {
+	ra := getAdmin(t)
+	orig, err := ra.GetMirrorSnapshotRetention()
+	assert.NoError(t, err)
+
+	err = ra.SetMirrorSnapshotRetention(7)
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, ra.ResetMirrorSnapshotRetention())
+		n, err := ra.GetMirrorSnapshotRetention()
+		assert.NoError(t, err)
+		assert.Equal(t, orig, n)
+	}()
+
+	n, err := ra.GetMirrorSnapshotRetention()
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+
+	err = ra.SetMirrorSnapshotRetention(-1)
+	assert.Error(t, err)
+}
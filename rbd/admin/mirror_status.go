@@ -0,0 +1,232 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+const rbdMirrorService = "rbd-mirror"
+
+// MirrorHealth summarizes the health of the rbd-mirror daemons.
+type MirrorHealth string
+
+const (
+	// MirrorHealthUnknown indicates the health could not be determined,
+	// for example because no rbd-mirror daemons are running.
+	MirrorHealthUnknown = MirrorHealth("UNKNOWN")
+	// MirrorHealthOK indicates no problems were reported.
+	MirrorHealthOK = MirrorHealth("OK")
+	// MirrorHealthWarning indicates that problems were reported that may
+	// require attention.
+	MirrorHealthWarning = MirrorHealth("WARNING")
+	// MirrorHealthError indicates that errors were reported.
+	MirrorHealthError = MirrorHealth("ERROR")
+)
+
+// MirrorCallout is a message reported by an rbd-mirror daemon for a pool.
+type MirrorCallout struct {
+	Level string `json:"level"`
+	Text  string `json:"text"`
+}
+
+// MirrorDaemonStatus contains the status of a single rbd-mirror daemon for a
+// pool.
+type MirrorDaemonStatus struct {
+	ServiceID          string
+	InstanceID         string
+	ClientID           string
+	Hostname           string
+	Version            string
+	Reporting          bool
+	Leader             bool
+	Health             MirrorHealth
+	Callouts           []MirrorCallout
+	ImageAssignedCount int
+	ImageErrorCount    int
+	ImageLocalCount    int
+	ImageRemoteCount   int
+	ImageWarningCount  int
+}
+
+// MirrorPoolDaemonSummary contains the combined health of all rbd-mirror
+// daemons for a pool as well as the status of each daemon.
+type MirrorPoolDaemonSummary struct {
+	Health  MirrorHealth
+	Daemons []MirrorDaemonStatus
+}
+
+// MirrorPoolDaemonStatus returns the health and status of the rbd-mirror
+// daemons serving the pool with the given name. The summary of the image
+// states of a pool is available from the rbd package's
+// MirrorImageStatusSummary function.
+//
+// Similar To:
+//
+//	rbd mirror pool status --verbose <pool>
+func (ra *RBDAdmin) MirrorPoolDaemonStatus(pool string) (
+	*MirrorPoolDaemonSummary, error) {
+
+	dump, err := parseServiceDump(commands.MarshalMgrCommand(
+		ra.conn,
+		map[string]string{
+			"prefix": "service dump",
+			"format": "json",
+		}))
+	if err != nil {
+		return nil, err
+	}
+	status, err := parseServiceStatus(commands.MarshalMgrCommand(
+		ra.conn,
+		map[string]string{
+			"prefix": "service status",
+			"format": "json",
+		}))
+	if err != nil {
+		return nil, err
+	}
+	return mirrorPoolDaemonSummary(pool, dump, status), nil
+}
+
+type serviceDaemonMetadata struct {
+	ID         string `json:"id"`
+	Hostname   string `json:"hostname"`
+	InstanceID string `json:"instance_id"`
+	Version    string `json:"ceph_version_short"`
+}
+
+type serviceDaemon struct {
+	Metadata serviceDaemonMetadata `json:"metadata"`
+}
+
+// serviceDaemons maps the service id to the daemon info.
+type serviceDaemons map[string]serviceDaemon
+
+type serviceDump struct {
+	Services map[string]struct {
+		Daemons map[string]json.RawMessage `json:"daemons"`
+	} `json:"services"`
+}
+
+func parseServiceDump(res commands.Response) (serviceDaemons, error) {
+	var sd serviceDump
+	if err := res.NoStatus().Unmarshal(&sd).End(); err != nil {
+		return nil, err
+	}
+	daemons := serviceDaemons{}
+	for id, raw := range sd.Services[rbdMirrorService].Daemons {
+		// the daemons map contains a "summary" string next to the daemon
+		// objects
+		if id == "summary" {
+			continue
+		}
+		var d serviceDaemon
+		if err := json.Unmarshal(raw, &d); err != nil {
+			return nil, err
+		}
+		daemons[id] = d
+	}
+	return daemons, nil
+}
+
+type mirrorPoolStatus struct {
+	Name               string                   `json:"name"`
+	Callouts           map[string]MirrorCallout `json:"callouts"`
+	ImageAssignedCount int                      `json:"image_assigned_count"`
+	ImageErrorCount    int                      `json:"image_error_count"`
+	ImageLocalCount    int                      `json:"image_local_count"`
+	ImageRemoteCount   int                      `json:"image_remote_count"`
+	ImageWarningCount  int                      `json:"image_warning_count"`
+	InstanceID         string                   `json:"instance_id"`
+	Leader             bool                     `json:"leader"`
+}
+
+// serviceStatus maps the service id to the per-pool status of the daemon.
+// The per-pool status is keyed on the pool id.
+type serviceStatus map[string]map[string]mirrorPoolStatus
+
+func parseServiceStatus(res commands.Response) (serviceStatus, error) {
+	var ss map[string]map[string]struct {
+		Status struct {
+			JSON string `json:"json"`
+		} `json:"status"`
+	}
+	if err := res.NoStatus().Unmarshal(&ss).End(); err != nil {
+		return nil, err
+	}
+	status := serviceStatus{}
+	for id, s := range ss[rbdMirrorService] {
+		pools := map[string]mirrorPoolStatus{}
+		if s.Status.JSON != "" {
+			if err := json.Unmarshal([]byte(s.Status.JSON), &pools); err != nil {
+				return nil, err
+			}
+		}
+		status[id] = pools
+	}
+	return status, nil
+}
+
+func worseHealth(a, b MirrorHealth) MirrorHealth {
+	rank := map[MirrorHealth]int{
+		MirrorHealthOK:      0,
+		MirrorHealthUnknown: 1,
+		MirrorHealthWarning: 2,
+		MirrorHealthError:   3,
+	}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+func mirrorPoolDaemonSummary(
+	pool string, dump serviceDaemons, status serviceStatus) *MirrorPoolDaemonSummary {
+
+	summary := &MirrorPoolDaemonSummary{Health: MirrorHealthOK}
+	for id, d := range dump {
+		ds := MirrorDaemonStatus{
+			ServiceID:  id,
+			InstanceID: d.Metadata.InstanceID,
+			ClientID:   d.Metadata.ID,
+			Hostname:   d.Metadata.Hostname,
+			Version:    d.Metadata.Version,
+			Health:     MirrorHealthWarning,
+		}
+		for _, ps := range status[id] {
+			if ps.Name != pool {
+				continue
+			}
+			ds.Reporting = true
+			ds.Health = MirrorHealthOK
+			ds.InstanceID = ps.InstanceID
+			ds.Leader = ps.Leader
+			ds.ImageAssignedCount = ps.ImageAssignedCount
+			ds.ImageErrorCount = ps.ImageErrorCount
+			ds.ImageLocalCount = ps.ImageLocalCount
+			ds.ImageRemoteCount = ps.ImageRemoteCount
+			ds.ImageWarningCount = ps.ImageWarningCount
+			for _, c := range ps.Callouts {
+				ds.Callouts = append(ds.Callouts, c)
+				switch c.Level {
+				case "error":
+					ds.Health = worseHealth(ds.Health, MirrorHealthError)
+				case "warning":
+					ds.Health = worseHealth(ds.Health, MirrorHealthWarning)
+				}
+			}
+		}
+		summary.Health = worseHealth(summary.Health, ds.Health)
+		summary.Daemons = append(summary.Daemons, ds)
+	}
+	if len(summary.Daemons) == 0 {
+		summary.Health = MirrorHealthUnknown
+	}
+	sort.Slice(summary.Daemons, func(i, j int) bool {
+		return summary.Daemons[i].ServiceID < summary.Daemons[j].ServiceID
+	})
+	return summary
+}
@@ -0,0 +1,136 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var serviceDump1 = `
+{
+    "epoch": 7,
+    "modified": "2021-03-02T16:30:00.000000+0000",
+    "services": {
+        "rbd-mirror": {
+            "daemons": {
+                "summary": "",
+                "4154": {
+                    "start_epoch": 3,
+                    "gid": 4154,
+                    "metadata": {
+                        "ceph_version_short": "17.2.6",
+                        "hostname": "host-a",
+                        "id": "a",
+                        "instance_id": "4154"
+                    }
+                },
+                "4160": {
+                    "start_epoch": 5,
+                    "gid": 4160,
+                    "metadata": {
+                        "ceph_version_short": "17.2.6",
+                        "hostname": "host-b",
+                        "id": "b",
+                        "instance_id": "4160"
+                    }
+                }
+            }
+        }
+    }
+}
+`
+
+var serviceStatus1 = `
+{
+    "rbd-mirror": {
+        "4154": {
+            "status_stamp": "2021-03-02T16:30:00.000000+0000",
+            "last_beacon": "2021-03-02T16:30:00.000000+0000",
+            "status": {
+                "json": "{\"2\":{\"name\":\"rbd\",\"callouts\":{},\"image_assigned_count\":3,\"image_error_count\":0,\"image_local_count\":3,\"image_remote_count\":3,\"image_warning_count\":0,\"instance_id\":\"4156\",\"leader\":true}}"
+            }
+        },
+        "4160": {
+            "status_stamp": "2021-03-02T16:30:00.000000+0000",
+            "last_beacon": "2021-03-02T16:30:00.000000+0000",
+            "status": {
+                "json": "{\"2\":{\"name\":\"rbd\",\"callouts\":{\"0\":{\"level\":\"error\",\"text\":\"unable to connect to remote cluster\"}},\"image_assigned_count\":0,\"image_error_count\":0,\"image_local_count\":0,\"image_remote_count\":0,\"image_warning_count\":0,\"instance_id\":\"4162\",\"leader\":false}}"
+            }
+        }
+    }
+}
+`
+
+func TestParseMirrorPoolDaemonStatus(t *testing.T) {
+	dump, err := parseServiceDump(
+		commands.NewResponse([]byte(serviceDump1), "", nil))
+	require.NoError(t, err)
+	assert.Len(t, dump, 2)
+	status, err := parseServiceStatus(
+		commands.NewResponse([]byte(serviceStatus1), "", nil))
+	require.NoError(t, err)
+	assert.Len(t, status, 2)
+
+	t.Run("reporting", func(t *testing.T) {
+		s := mirrorPoolDaemonSummary("rbd", dump, status)
+		assert.Equal(t, MirrorHealthError, s.Health)
+		if assert.Len(t, s.Daemons, 2) {
+			d := s.Daemons[0]
+			assert.Equal(t, "4154", d.ServiceID)
+			assert.Equal(t, "4156", d.InstanceID)
+			assert.Equal(t, "a", d.ClientID)
+			assert.Equal(t, "host-a", d.Hostname)
+			assert.True(t, d.Reporting)
+			assert.True(t, d.Leader)
+			assert.Equal(t, MirrorHealthOK, d.Health)
+			assert.Equal(t, 3, d.ImageAssignedCount)
+			d = s.Daemons[1]
+			assert.Equal(t, "4160", d.ServiceID)
+			assert.False(t, d.Leader)
+			assert.Equal(t, MirrorHealthError, d.Health)
+			if assert.Len(t, d.Callouts, 1) {
+				assert.Equal(t, "error", d.Callouts[0].Level)
+			}
+		}
+	})
+	t.Run("notReporting", func(t *testing.T) {
+		s := mirrorPoolDaemonSummary("other", dump, status)
+		assert.Equal(t, MirrorHealthWarning, s.Health)
+		if assert.Len(t, s.Daemons, 2) {
+			assert.False(t, s.Daemons[0].Reporting)
+			assert.False(t, s.Daemons[1].Reporting)
+		}
+	})
+	t.Run("noDaemons", func(t *testing.T) {
+		s := mirrorPoolDaemonSummary("rbd", serviceDaemons{}, status)
+		assert.Equal(t, MirrorHealthUnknown, s.Health)
+		assert.Len(t, s.Daemons, 0)
+	})
+	t.Run("error", func(t *testing.T) {
+		_, err := parseServiceDump(
+			commands.NewResponse([]byte{}, "", errors.New("yikes")))
+		assert.Error(t, err)
+		_, err = parseServiceStatus(
+			commands.NewResponse([]byte{}, "", errors.New("yikes")))
+		assert.Error(t, err)
+	})
+}
+
+func TestMirrorPoolDaemonStatus(t *testing.T) {
+	ensureDefaultPool(t)
+	ra := getAdmin(t)
+	s, err := ra.MirrorPoolDaemonStatus(defaultPoolName)
+	assert.NoError(t, err)
+	if assert.NotNil(t, s) {
+		// the test environment may or may not run an rbd-mirror daemon
+		for _, d := range s.Daemons {
+			assert.NotEmpty(t, d.ServiceID)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"time"
+)
+
+// scheduleTimeLayout is the layout of the schedule times reported by the
+// rbd_support mgr module.
+const scheduleTimeLayout = "2006-01-02 15:04:05"
+
+// Time returns the ScheduleTime value parsed as a time.Time. The rbd_support
+// mgr module reports schedule times without a zone, the values are
+// interpreted as UTC.
+func (st ScheduleTime) Time() (time.Time, error) {
+	return time.Parse(scheduleTimeLayout, string(st))
+}
+
+// ImageSpec returns an ImageSpec identifying the image that is scheduled.
+// The returned value can be passed to other functions in this package that
+// accept an ImageSpec.
+func (si ScheduledImage) ImageSpec() ImageSpec {
+	return NewRawImageSpec(si.Image)
+}
@@ -0,0 +1,86 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/rbd"
+)
+
+func TestScheduleTime(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		st := ScheduleTime("2021-03-02 16:30:00")
+		tm, err := st.Time()
+		assert.NoError(t, err)
+		assert.Equal(t,
+			time.Date(2021, time.March, 2, 16, 30, 0, 0, time.UTC), tm)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		st := ScheduleTime("henry")
+		_, err := st.Time()
+		assert.Error(t, err)
+	})
+}
+
+func TestScheduledImageSpec(t *testing.T) {
+	si := ScheduledImage{Image: "rbd/ns1/foo"}
+	assert.Equal(t, NewImageSpec("rbd", "ns1", "foo"), si.ImageSpec())
+}
+
+func TestMirrorSnapshotScheduleNamespace(t *testing.T) {
+	ensureDefaultPool(t)
+	conn := getConn(t)
+
+	ioctx, err := conn.OpenIOContext(defaultPoolName)
+	require.NoError(t, err)
+	defer ioctx.Destroy()
+
+	nsName := "msns"
+	require.NoError(t, rbd.NamespaceCreate(ioctx, nsName))
+	defer func() {
+		assert.NoError(t, rbd.NamespaceRemove(ioctx, nsName))
+	}()
+
+	ra := getAdmin(t)
+	scheduler := ra.MirrorSnashotSchedule()
+	lspec := NewLevelSpec(defaultPoolName, nsName, "")
+	err = scheduler.Add(lspec, Interval("1d"), NoStartTime)
+	assert.NoError(t, err)
+	err = scheduler.Add(lspec, Interval("8h"), NoStartTime)
+	assert.NoError(t, err)
+
+	slist, err := scheduler.List(lspec)
+	assert.NoError(t, err)
+	if assert.Len(t, slist, 1) {
+		assert.Equal(t, "rbd/msns/", slist[0].Name)
+		if assert.Len(t, slist[0].Schedule, 2) {
+			sched := slist[0].Schedule
+			sort.Slice(sched, func(i, j int) bool {
+				return sched[i].Interval < sched[j].Interval
+			})
+			assert.Equal(t, Interval("1d"), sched[0].Interval)
+			assert.Equal(t, Interval("8h"), sched[1].Interval)
+		}
+	}
+
+	// remove only one of the intervals
+	err = scheduler.Remove(lspec, Interval("1d"), NoStartTime)
+	assert.NoError(t, err)
+	slist, err = scheduler.List(lspec)
+	assert.NoError(t, err)
+	if assert.Len(t, slist, 1) && assert.Len(t, slist[0].Schedule, 1) {
+		assert.Equal(t, Interval("8h"), slist[0].Schedule[0].Interval)
+	}
+
+	err = scheduler.Remove(lspec, Interval("8h"), NoStartTime)
+	assert.NoError(t, err)
+	slist, err = scheduler.List(lspec)
+	assert.NoError(t, err)
+	assert.Len(t, slist, 0)
+}
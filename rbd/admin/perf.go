@@ -0,0 +1,124 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"fmt"
+
+	ccom "github.com/ceph/go-ceph/common/commands"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// PerfAdmin encapsulates functions to query the performance statistics of
+// rbd images collected by the ceph mgr.
+type PerfAdmin struct {
+	conn ccom.MgrCommander
+}
+
+// Perf returns a PerfAdmin type for querying rbd image performance
+// statistics.
+func (ra *RBDAdmin) Perf() *PerfAdmin {
+	return &PerfAdmin{conn: ra.conn}
+}
+
+// PerfSortBy selects the statistic used to sort the results of an image
+// performance statistics query.
+type PerfSortBy string
+
+const (
+	// NoPerfSortBy indicates the default sort order (write ops).
+	NoPerfSortBy = PerfSortBy("")
+	// PerfSortByWriteOps sorts by write operations per second.
+	PerfSortByWriteOps = PerfSortBy("write_ops")
+	// PerfSortByReadOps sorts by read operations per second.
+	PerfSortByReadOps = PerfSortBy("read_ops")
+	// PerfSortByWriteBytes sorts by bytes written per second.
+	PerfSortByWriteBytes = PerfSortBy("write_bytes")
+	// PerfSortByReadBytes sorts by bytes read per second.
+	PerfSortByReadBytes = PerfSortBy("read_bytes")
+	// PerfSortByWriteLatency sorts by the write latency.
+	PerfSortByWriteLatency = PerfSortBy("write_latency")
+	// PerfSortByReadLatency sorts by the read latency.
+	PerfSortByReadLatency = PerfSortBy("read_latency")
+)
+
+// ImagePerfStats contains the performance statistics of a single image.
+// Operation and byte counts are rates per second, latencies are in
+// nanoseconds.
+type ImagePerfStats struct {
+	Image        string
+	WriteOps     float64
+	ReadOps      float64
+	WriteBytes   float64
+	ReadBytes    float64
+	WriteLatency float64
+	ReadLatency  float64
+}
+
+// ImageStats returns the performance statistics of the images in the given
+// pool and namespace, sorted in descending order by the given statistic. If
+// pool is empty the statistics of the images in all pools are returned.
+//
+// The mgr only starts collecting the statistics after they are first
+// requested, thus the first calls may return no or incomplete results.
+//
+// Similar To:
+//
+//	rbd perf image stats <pool_spec> [--sort-by <sort_by>]
+func (pa *PerfAdmin) ImageStats(pool, namespace string, sortBy PerfSortBy) (
+	[]ImagePerfStats, error) {
+
+	m := map[string]string{
+		"prefix": "rbd perf image stats",
+		"format": "json",
+	}
+	if pool != "" {
+		m["pool_spec"] = pool
+		if namespace != "" {
+			m["pool_spec"] = fmt.Sprintf("%s/%s", pool, namespace)
+		}
+	}
+	if sortBy != NoPerfSortBy {
+		m["sort_by"] = string(sortBy)
+	}
+	return parseImagePerfStats(commands.MarshalMgrCommand(pa.conn, m))
+}
+
+type imagePerfStatsResult struct {
+	StatDescriptors []string               `json:"stat_descriptors"`
+	Stats           []map[string][]float64 `json:"stats"`
+}
+
+func parseImagePerfStats(res commands.Response) ([]ImagePerfStats, error) {
+	var r imagePerfStatsResult
+	if err := res.NoStatus().Unmarshal(&r).End(); err != nil {
+		return nil, err
+	}
+	stats := make([]ImagePerfStats, 0, len(r.Stats))
+	for _, entry := range r.Stats {
+		for image, counters := range entry {
+			s := ImagePerfStats{Image: image}
+			for i, name := range r.StatDescriptors {
+				if i >= len(counters) {
+					break
+				}
+				switch PerfSortBy(name) {
+				case PerfSortByWriteOps:
+					s.WriteOps = counters[i]
+				case PerfSortByReadOps:
+					s.ReadOps = counters[i]
+				case PerfSortByWriteBytes:
+					s.WriteBytes = counters[i]
+				case PerfSortByReadBytes:
+					s.ReadBytes = counters[i]
+				case PerfSortByWriteLatency:
+					s.WriteLatency = counters[i]
+				case PerfSortByReadLatency:
+					s.ReadLatency = counters[i]
+				}
+			}
+			stats = append(stats, s)
+		}
+	}
+	return stats, nil
+}
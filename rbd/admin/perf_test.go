@@ -0,0 +1,86 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var perfStats1 = `
+{
+    "stat_descriptors": [
+        "write_ops",
+        "read_ops",
+        "write_bytes",
+        "read_bytes",
+        "write_latency",
+        "read_latency"
+    ],
+    "stats": [
+        {
+            "rbd/img1": [12.5, 3.0, 51200.0, 12288.0, 1500000.0, 800000.0]
+        },
+        {
+            "rbd/ns1/img2": [1.0, 0.0, 4096.0, 0.0, 900000.0, 0.0]
+        }
+    ]
+}
+`
+
+func TestParseImagePerfStats(t *testing.T) {
+	t.Run("stats1", func(t *testing.T) {
+		r := commands.NewResponse([]byte(perfStats1), "", nil)
+		s, err := parseImagePerfStats(r)
+		assert.NoError(t, err)
+		if assert.Len(t, s, 2) {
+			assert.Equal(t, "rbd/img1", s[0].Image)
+			assert.Equal(t, 12.5, s[0].WriteOps)
+			assert.Equal(t, 3.0, s[0].ReadOps)
+			assert.Equal(t, 51200.0, s[0].WriteBytes)
+			assert.Equal(t, 12288.0, s[0].ReadBytes)
+			assert.Equal(t, 1500000.0, s[0].WriteLatency)
+			assert.Equal(t, 800000.0, s[0].ReadLatency)
+			assert.Equal(t, "rbd/ns1/img2", s[1].Image)
+			assert.Equal(t, 1.0, s[1].WriteOps)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		r := commands.NewResponse(
+			[]byte(`{"stat_descriptors": [], "stats": []}`), "", nil)
+		s, err := parseImagePerfStats(r)
+		assert.NoError(t, err)
+		assert.Len(t, s, 0)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse([]byte{}, "", errors.New("yikes"))
+		s, err := parseImagePerfStats(r)
+		assert.Error(t, err)
+		assert.Len(t, s, 0)
+	})
+}
+
+func TestPerfImageStats(t *testing.T) {
+	ensureDefaultPool(t)
+	pa := getAdmin(t).Perf()
+	// the mgr may need some time to begin collecting the stats after the
+	// first request
+	var err error
+	for i := 0; i < 30; i++ {
+		_, err = pa.ImageStats(defaultPoolName, "", PerfSortByReadOps)
+		if err == nil {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	assert.NoError(t, err)
+	_, err = pa.ImageStats("", "", NoPerfSortBy)
+	assert.NoError(t, err)
+	_, err = pa.ImageStats(defaultPoolName, "", PerfSortBy("bogus"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// AddMigrationExecute adds a background task to execute the migration of an
+// image, that has previously been prepared for migration, based on the
+// supplied image spec of the migration target.
+//
+// Similar To:
+//
+//	rbd task add migration execute <image_spec>
+func (ta *TaskAdmin) AddMigrationExecute(img ImageSpec) (TaskResponse, error) {
+	m := map[string]string{
+		"prefix":     "rbd task add migration execute",
+		"image_spec": img.spec,
+		"format":     "json",
+	}
+	return parseTaskResponse(commands.MarshalMgrCommand(ta.conn, m))
+}
+
+// AddMigrationCommit adds a background task to commit the executed migration
+// of an image based on the supplied image spec of the migration target.
+//
+// Similar To:
+//
+//	rbd task add migration commit <image_spec>
+func (ta *TaskAdmin) AddMigrationCommit(img ImageSpec) (TaskResponse, error) {
+	m := map[string]string{
+		"prefix":     "rbd task add migration commit",
+		"image_spec": img.spec,
+		"format":     "json",
+	}
+	return parseTaskResponse(commands.MarshalMgrCommand(ta.conn, m))
+}
+
+// AddMigrationAbort adds a background task to cancel an interrupted migration
+// of an image based on the supplied image spec of the migration target.
+//
+// Similar To:
+//
+//	rbd task add migration abort <image_spec>
+func (ta *TaskAdmin) AddMigrationAbort(img ImageSpec) (TaskResponse, error) {
+	m := map[string]string{
+		"prefix":     "rbd task add migration abort",
+		"image_spec": img.spec,
+		"format":     "json",
+	}
+	return parseTaskResponse(commands.MarshalMgrCommand(ta.conn, m))
+}
@@ -0,0 +1,97 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+	"github.com/ceph/go-ceph/rbd"
+)
+
+var trMigration = `
+{
+   "sequence":3,
+   "id":"id-3",
+   "message":"Migrating image pool/dst",
+   "refs":{
+      "action":"migrate execute",
+      "pool_name":"pool",
+      "pool_namespace":"",
+      "image_name":"dst",
+      "image_id":"1234567"
+   }
+}
+`
+
+func TestParseTaskResponseMigration(t *testing.T) {
+	got, err := parseTaskResponse(
+		commands.NewResponse([]byte(trMigration), "", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "id-3", got.ID)
+	assert.Equal(t, "migrate execute", got.Refs.Action)
+	assert.Equal(t, "dst", got.Refs.ImageName)
+}
+
+func TestTaskAdminAddMigration(t *testing.T) {
+	ensureDefaultPool(t)
+	conn := getConn(t)
+
+	ioctx, err := conn.OpenIOContext(defaultPoolName)
+	require.NoError(t, err)
+	defer ioctx.Destroy()
+
+	options := rbd.NewRbdImageOptions()
+	defer options.Destroy()
+	assert.NoError(t,
+		options.SetUint64(rbd.ImageOptionOrder, uint64(testImageOrder)))
+
+	srcName := "migsrc"
+	dstName := "migdst"
+	err = rbd.CreateImage(ioctx, srcName, testImageSize, options)
+	require.NoError(t, err)
+
+	err = rbd.MigrationPrepare(ioctx, srcName, ioctx, dstName, options)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, rbd.RemoveImage(ioctx, dstName))
+	}()
+
+	ta := getAdmin(t).Task()
+	waitForState := func(state rbd.MigrationImageState) {
+		var status *rbd.MigrationImageStatus
+		for i := 0; i < 35; i++ {
+			status, err = rbd.MigrationStatus(ioctx, dstName)
+			if err == nil && status.State == state {
+				break
+			}
+			time.Sleep(time.Second)
+		}
+		if assert.NoError(t, err) {
+			assert.Equal(t, state, status.State)
+		}
+	}
+
+	tr, err := ta.AddMigrationExecute(NewImageSpec(defaultPoolName, "", dstName))
+	assert.NoError(t, err)
+	assert.Equal(t, dstName, tr.Refs.ImageName)
+	assert.Equal(t, defaultPoolName, tr.Refs.PoolName)
+	waitForState(rbd.MigrationImageExecuted)
+
+	tr, err = ta.AddMigrationCommit(NewImageSpec(defaultPoolName, "", dstName))
+	assert.NoError(t, err)
+	assert.Equal(t, dstName, tr.Refs.ImageName)
+
+	// once committed the migration status is no longer available
+	for i := 0; i < 35; i++ {
+		if _, err = rbd.MigrationStatus(ioctx, dstName); err != nil {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	ccom "github.com/ceph/go-ceph/common/commands"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// TrashPurgeScheduleAdmin encapsulates management functions for
+// ceph rbd trash purge schedules.
+type TrashPurgeScheduleAdmin struct {
+	conn ccom.MgrCommander
+}
+
+// TrashPurgeSchedule returns a TrashPurgeScheduleAdmin type for
+// managing ceph rbd trash purge schedules.
+func (ra *RBDAdmin) TrashPurgeSchedule() *TrashPurgeScheduleAdmin {
+	return &TrashPurgeScheduleAdmin{conn: ra.conn}
+}
+
+// Add a new trash purge schedule to the given pool or namespace based on the
+// supplied level spec.
+//
+// Similar To:
+//
+//	rbd trash purge schedule add <level_spec> <interval> <start_time>
+func (tps *TrashPurgeScheduleAdmin) Add(l LevelSpec, i Interval, s StartTime) error {
+	m := map[string]string{
+		"prefix":     "rbd trash purge schedule add",
+		"level_spec": l.spec,
+		"format":     "json",
+	}
+	if i != NoInterval {
+		m["interval"] = string(i)
+	}
+	if s != NoStartTime {
+		m["start_time"] = string(s)
+	}
+	return commands.MarshalMgrCommand(tps.conn, m).NoData().End()
+}
+
+// List the trash purge schedules based on the supplied level spec.
+//
+// Similar To:
+//
+//	rbd trash purge schedule list <level_spec>
+func (tps *TrashPurgeScheduleAdmin) List(l LevelSpec) ([]SnapshotSchedule, error) {
+	m := map[string]string{
+		"prefix":     "rbd trash purge schedule list",
+		"level_spec": l.spec,
+		"format":     "json",
+	}
+	// the mgr uses the same schedule format for both the mirror snapshot
+	// and the trash purge schedules
+	return parseMirrorSnapshotScheduleList(
+		commands.MarshalMgrCommand(tps.conn, m))
+}
+
+// Remove a trash purge schedule matching the supplied arguments.
+//
+// Similar To:
+//
+//	rbd trash purge schedule remove <level_spec> <interval> <start_time>
+func (tps *TrashPurgeScheduleAdmin) Remove(
+	l LevelSpec, i Interval, s StartTime) error {
+
+	m := map[string]string{
+		"prefix":     "rbd trash purge schedule remove",
+		"level_spec": l.spec,
+		"format":     "json",
+	}
+	if i != NoInterval {
+		m["interval"] = string(i)
+	}
+	if s != NoStartTime {
+		m["start_time"] = string(s)
+	}
+	return commands.MarshalMgrCommand(tps.conn, m).NoData().End()
+}
+
+// Status returns the status of the trash purge schedules (eg. when the trash
+// of a pool or namespace will next be purged) matching the supplied level
+// spec.
+//
+// Similar To:
+//
+//	rbd trash purge schedule status <level_spec>
+func (tps *TrashPurgeScheduleAdmin) Status(l LevelSpec) ([]ScheduledTrashPurge, error) {
+	m := map[string]string{
+		"prefix":     "rbd trash purge schedule status",
+		"level_spec": l.spec,
+		"format":     "json",
+	}
+	return parseTrashPurgeScheduleStatus(
+		commands.MarshalMgrCommand(tps.conn, m))
+}
+
+// ScheduledTrashPurge contains the pool and namespace scheduled to have its
+// trash purged and when the purge will next occur.
+type ScheduledTrashPurge struct {
+	PoolID       string       `json:"pool_id"`
+	PoolName     string       `json:"pool_name"`
+	Namespace    string       `json:"namespace"`
+	ScheduleTime ScheduleTime `json:"schedule_time"`
+}
+
+type scheduledTrashPurgeWrapper struct {
+	Scheduled []ScheduledTrashPurge `json:"scheduled"`
+}
+
+func parseTrashPurgeScheduleStatus(res commands.Response) (
+	[]ScheduledTrashPurge, error) {
+
+	var stw scheduledTrashPurgeWrapper
+	if err := res.NoStatus().Unmarshal(&stw).End(); err != nil {
+		return nil, err
+	}
+	return stw.Scheduled, nil
+}
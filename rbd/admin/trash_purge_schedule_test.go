@@ -0,0 +1,127 @@
+//go:build !nautilus && ceph_preview
+
+package admin
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var tpsStatus1 = `
+{
+    "scheduled": []
+}
+`
+
+var tpsStatus2 = `
+{
+    "scheduled": [
+        {
+            "namespace": "",
+            "pool_id": "2",
+            "pool_name": "rbd",
+            "schedule_time": "2021-03-02 16:30:00"
+        },
+        {
+            "namespace": "ns1",
+            "pool_id": "2",
+            "pool_name": "rbd",
+            "schedule_time": "2021-03-03 00:00:00"
+        }
+    ]
+}
+`
+
+func TestParseTrashPurgeScheduleStatus(t *testing.T) {
+	t.Run("status1", func(t *testing.T) {
+		r := commands.NewResponse([]byte(tpsStatus1), "", nil)
+		s, err := parseTrashPurgeScheduleStatus(r)
+		assert.NoError(t, err)
+		assert.Len(t, s, 0)
+	})
+	t.Run("status2", func(t *testing.T) {
+		r := commands.NewResponse([]byte(tpsStatus2), "", nil)
+		s, err := parseTrashPurgeScheduleStatus(r)
+		assert.NoError(t, err)
+		if assert.Len(t, s, 2) {
+			assert.Equal(t, "rbd", s[0].PoolName)
+			assert.Equal(t, "", s[0].Namespace)
+			assert.Contains(t, s[0].ScheduleTime, "16:30")
+			assert.Equal(t, "rbd", s[1].PoolName)
+			assert.Equal(t, "ns1", s[1].Namespace)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse([]byte{}, "", errors.New("zrkk"))
+		s, err := parseTrashPurgeScheduleStatus(r)
+		assert.Error(t, err)
+		assert.Len(t, s, 0)
+	})
+}
+
+func TestTrashPurgeScheduleAddRemove(t *testing.T) {
+	ensureDefaultPool(t)
+	ra := getAdmin(t)
+	scheduler := ra.TrashPurgeSchedule()
+	t.Run("noStartTime", func(t *testing.T) {
+		err := scheduler.Add(NewLevelSpec(defaultPoolName, "", ""), Interval("1d"), NoStartTime)
+		assert.NoError(t, err)
+		err = scheduler.Remove(NewLevelSpec(defaultPoolName, "", ""), Interval("1d"), NoStartTime)
+		assert.NoError(t, err)
+	})
+	t.Run("startTime", func(t *testing.T) {
+		stime := StartTime("12:00:00")
+		err := scheduler.Add(NewLevelSpec(defaultPoolName, "", ""), Interval("1d"), stime)
+		assert.NoError(t, err)
+		err = scheduler.Remove(NewLevelSpec(defaultPoolName, "", ""), Interval("1d"), stime)
+		assert.NoError(t, err)
+	})
+	t.Run("badStartTime", func(t *testing.T) {
+		stime := StartTime("henry")
+		err := scheduler.Add(NewLevelSpec(defaultPoolName, "", ""), Interval("1d"), stime)
+		assert.Error(t, err)
+	})
+}
+
+func TestTrashPurgeScheduleList(t *testing.T) {
+	ensureDefaultPool(t)
+	ra := getAdmin(t)
+	scheduler := ra.TrashPurgeSchedule()
+	err := scheduler.Add(NewLevelSpec(defaultPoolName, "", ""), Interval("1d"), NoStartTime)
+	assert.NoError(t, err)
+	defer func() {
+		err = scheduler.Remove(NewLevelSpec(defaultPoolName, "", ""), Interval("1d"), NoStartTime)
+		assert.NoError(t, err)
+	}()
+	err = scheduler.Add(NewLevelSpec(defaultPoolName, "", ""), Interval("8h"), NoStartTime)
+	assert.NoError(t, err)
+	defer func() {
+		err = scheduler.Remove(NewLevelSpec(defaultPoolName, "", ""), Interval("8h"), NoStartTime)
+		assert.NoError(t, err)
+	}()
+
+	slist, err := scheduler.List(NewLevelSpec(defaultPoolName, "", ""))
+	assert.NoError(t, err)
+	if assert.Len(t, slist, 1) {
+		assert.Equal(t, "rbd/", slist[0].Name)
+		if assert.Len(t, slist[0].Schedule, 2) {
+			sched := slist[0].Schedule
+			sort.Slice(sched, func(i, j int) bool {
+				return sched[i].Interval < sched[j].Interval
+			})
+			assert.Equal(t, Interval("1d"), sched[0].Interval)
+			assert.Equal(t, Interval("8h"), sched[1].Interval)
+		}
+	}
+
+	status, err := scheduler.Status(NewLevelSpec(defaultPoolName, "", ""))
+	assert.NoError(t, err)
+	for _, s := range status {
+		assert.Equal(t, defaultPoolName, s.PoolName)
+	}
+}
@@ -9,6 +9,20 @@ import (
 	"net/http"
 )
 
+// Types of the quotas of an account, as used by SetAccountQuota
+const (
+	// AccountQuotaTypeAccount is the quota of the account as a whole
+	AccountQuotaTypeAccount = "account"
+	// AccountQuotaTypeBucket is the quota of each bucket of the account
+	AccountQuotaTypeBucket = "bucket"
+)
+
+// accountQuotaRequest contains the parameters of SetAccountQuota
+type accountQuotaRequest struct {
+	ID    string `url:"id"`
+	Quota QuotaSpec
+}
+
 // Account represents an RGW account
 type Account struct {
 	ID            string `json:"id" url:"id"`
@@ -20,9 +34,7 @@ type Account struct {
 	MaxGroups     *int64 `json:"max_groups" url:"max-groups"`
 	MaxAccessKeys *int64 `json:"max_access_keys" url:"max-access-keys"`
 	MaxBuckets    *int64 `json:"max_buckets" url:"max-buckets"`
-	// Quota apis for account is only available mainline,
-	// but fields are available for preview builds for future compatibility
-	// Once backports merged will add account quota apis
+	// Quotas are set using SetAccountQuota
 	Quota       QuotaSpec `json:"quota"`
 	BucketQuota QuotaSpec `json:"bucket_quota"`
 }
@@ -101,3 +113,48 @@ func (api *API) ModifyAccount(ctx context.Context, account Account) (Account, er
 
 	return a, nil
 }
+
+// ListAccounts will return the IDs of all the RGW accounts
+func (api *API) ListAccounts(ctx context.Context) ([]string, error) {
+	body, err := api.call(ctx, http.MethodGet, "/metadata/account", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []string
+	err = json.Unmarshal(body, &accounts)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return accounts, nil
+}
+
+// SetAccountQuota will set a quota of the RGW account. The QuotaType of the
+// quota selects whether the quota applies to the account as a whole,
+// AccountQuotaTypeAccount, or to each of its buckets, AccountQuotaTypeBucket.
+// The current quotas are returned by GetAccount.
+func (api *API) SetAccountQuota(ctx context.Context, accountID string, quota QuotaSpec) error {
+	if accountID == "" {
+		return ErrInvalidArgument
+	}
+	switch quota.QuotaType {
+	case AccountQuotaTypeAccount, AccountQuotaTypeBucket:
+	default:
+		return fmt.Errorf("%w: invalid account quota type %q", ErrInvalidArgument, quota.QuotaType)
+	}
+
+	req := accountQuotaRequest{ID: accountID, Quota: quota}
+	_, err := api.call(ctx, http.MethodPut, "/account?quota", valueToURLParams(req, []string{"id", "quota-type", "enabled", "max-size", "max-size-kb", "max-objects"}))
+	return err
+}
+
+// MoveUserToAccount will move an existing user into the RGW account. The
+// user becomes subject to the IAM policies of the account.
+func (api *API) MoveUserToAccount(ctx context.Context, uid, accountID string) (User, error) {
+	if accountID == "" {
+		return User{}, ErrInvalidArgument
+	}
+
+	return api.ModifyUser(ctx, User{ID: uid, AccountID: accountID})
+}
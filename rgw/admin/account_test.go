@@ -3,12 +3,16 @@
 package admin
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"testing"
 
 	"github.com/ceph/go-ceph/internal/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func (suite *RadosGWTestSuite) TestAccount() {
@@ -68,3 +72,66 @@ func (suite *RadosGWTestSuite) TestAccount() {
 		assert.NoError(t, err)
 	})
 }
+
+func returnAccountMockClient(requests *[]string) *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			*requests = append(*requests, req.Method+" "+req.URL.Path+"?"+req.URL.RawQuery)
+			var body []byte
+			switch {
+			case req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/admin/metadata/account":
+				body = []byte(`["RGW12345678901234567", "RGW76543210987654321"]`)
+			case req.Method == http.MethodPut && req.URL.Path == "127.0.0.1/admin/account":
+			case req.Method == http.MethodPost && req.URL.Path == "127.0.0.1/admin/user":
+				body = fakeUserResponse
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestAccountMock(t *testing.T) {
+	var requests []string
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnAccountMockClient(&requests))
+	require.NoError(t, err)
+
+	t.Run("listAccounts", func(t *testing.T) {
+		accounts, err := api.ListAccounts(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"RGW12345678901234567", "RGW76543210987654321"}, accounts)
+	})
+	t.Run("setAccountQuota", func(t *testing.T) {
+		enabled := true
+		maxObjects := int64(1000)
+		quota := QuotaSpec{QuotaType: AccountQuotaTypeAccount, Enabled: &enabled, MaxObjects: &maxObjects}
+
+		requests = nil
+		err := api.SetAccountQuota(context.TODO(), "RGW12345678901234567", quota)
+		assert.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Equal(t, "PUT 127.0.0.1/admin/account?enabled=true&format=json&id=RGW12345678901234567&max-objects=1000&quota=&quota-type=account", requests[0])
+
+		err = api.SetAccountQuota(context.TODO(), "", quota)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		quota.QuotaType = "user"
+		err = api.SetAccountQuota(context.TODO(), "RGW12345678901234567", quota)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+	t.Run("moveUserToAccount", func(t *testing.T) {
+		requests = nil
+		_, err := api.MoveUserToAccount(context.TODO(), "dashboard-admin", "RGW12345678901234567")
+		assert.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Contains(t, requests[0], "account-id=RGW12345678901234567")
+
+		_, err = api.MoveUserToAccount(context.TODO(), "dashboard-admin", "")
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = api.MoveUserToAccount(context.TODO(), "", "RGW12345678901234567")
+		assert.ErrorIs(t, err, errMissingUserID)
+	})
+}
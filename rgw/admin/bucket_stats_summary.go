@@ -0,0 +1,78 @@
+//go:build ceph_preview
+
+package admin
+
+import "context"
+
+// BucketStatsSummary sums the stats of the buckets of a user
+type BucketStatsSummary struct {
+	UID string
+	// Size is the number of bytes stored
+	Size uint64
+	// SizeRounded is the number of bytes stored, each object rounded up to
+	// a multiple of 4KiB
+	SizeRounded uint64
+	NumObjects  uint64
+	// Buckets contains the buckets with their stats, if the stats are summed
+	// client-side
+	Buckets []Bucket
+	// ServerSide is set if the stats were summed by the RGW
+	ServerSide bool
+}
+
+func addUsage(s *BucketStatsSummary, u RgwUsage) {
+	if u.Size != nil {
+		s.Size += *u.Size
+	}
+	if u.SizeActual != nil {
+		s.SizeRounded += *u.SizeActual
+	}
+	if u.NumObjects != nil {
+		s.NumObjects += *u.NumObjects
+	}
+}
+
+// SummarizeBucketStats sums the stats of the buckets of a user, as returned
+// by ListUsersBucketsWithStat
+func SummarizeBucketStats(uid string, buckets []Bucket) BucketStatsSummary {
+	s := BucketStatsSummary{UID: uid, Buckets: buckets}
+	for _, b := range buckets {
+		addUsage(&s, b.Usage.RgwMain)
+		addUsage(&s, b.Usage.RgwMultimeta)
+	}
+	return s
+}
+
+// GetUserBucketStats will return the stats of the buckets of a user summed.
+// Unless perBucket is set the sums are taken from the stats of the user,
+// computed by the RGW. Otherwise, or if the RGW does not report the stats of
+// the user, the stats of every bucket are retrieved and summed client-side.
+func (api *API) GetUserBucketStats(ctx context.Context, uid string, perBucket bool) (BucketStatsSummary, error) {
+	if uid == "" {
+		return BucketStatsSummary{}, errMissingUserID
+	}
+
+	if !perBucket {
+		generateStat := true
+		u, err := api.GetUser(ctx, User{ID: uid, GenerateStat: &generateStat})
+		if err != nil {
+			return BucketStatsSummary{}, err
+		}
+		if u.Stat.Size != nil {
+			s := BucketStatsSummary{UID: uid, Size: *u.Stat.Size, ServerSide: true}
+			if u.Stat.SizeRounded != nil {
+				s.SizeRounded = *u.Stat.SizeRounded
+			}
+			if u.Stat.NumObjects != nil {
+				s.NumObjects = *u.Stat.NumObjects
+			}
+			return s, nil
+		}
+	}
+
+	buckets, err := api.ListUsersBucketsWithStat(ctx, uid)
+	if err != nil {
+		return BucketStatsSummary{}, err
+	}
+	return SummarizeBucketStats(uid, buckets), nil
+}
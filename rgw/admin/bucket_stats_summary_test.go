@@ -0,0 +1,98 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fakeUserBucketsWithStatResponse = []byte(`[
+  {
+    "bucket": "bucket1",
+    "owner": "leseb",
+    "usage": {
+      "rgw.main": {"size": 1000, "size_actual": 4096, "num_objects": 1},
+      "rgw.multimeta": {"size": 0, "size_actual": 0, "num_objects": 2}
+    }
+  },
+  {
+    "bucket": "bucket2",
+    "owner": "leseb",
+    "usage": {
+      "rgw.main": {"size": 5000, "size_actual": 8192, "num_objects": 3}
+    }
+  },
+  {
+    "bucket": "empty",
+    "owner": "leseb",
+    "usage": {}
+  }
+]`)
+
+func returnBucketStatsMockClient(userStats string) *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.Method != http.MethodGet || q.Get("uid") != "leseb" || q.Get("stats") != "true" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			var body []byte
+			switch req.URL.Path {
+			case "127.0.0.1/admin/user":
+				body = []byte(fmt.Sprintf(`{"user_id": "leseb"%s}`, userStats))
+			case "127.0.0.1/admin/bucket":
+				body = fakeUserBucketsWithStatResponse
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestGetUserBucketStats(t *testing.T) {
+	t.Run("serverSide", func(t *testing.T) {
+		api, err := New("127.0.0.1", "accessKey", "secretKey", returnBucketStatsMockClient(
+			`, "stats": {"size": 6000, "size_rounded": 12288, "num_objects": 6}`))
+		require.NoError(t, err)
+
+		s, err := api.GetUserBucketStats(context.TODO(), "leseb", false)
+		require.NoError(t, err)
+		assert.Equal(t, BucketStatsSummary{UID: "leseb", Size: 6000, SizeRounded: 12288, NumObjects: 6, ServerSide: true}, s)
+
+		s, err = api.GetUserBucketStats(context.TODO(), "leseb", true)
+		require.NoError(t, err)
+		assert.False(t, s.ServerSide)
+		assert.Len(t, s.Buckets, 3)
+		assert.Equal(t, uint64(6000), s.Size)
+		assert.Equal(t, uint64(12288), s.SizeRounded)
+		assert.Equal(t, uint64(6), s.NumObjects)
+	})
+	t.Run("clientSideFallback", func(t *testing.T) {
+		api, err := New("127.0.0.1", "accessKey", "secretKey", returnBucketStatsMockClient(""))
+		require.NoError(t, err)
+
+		s, err := api.GetUserBucketStats(context.TODO(), "leseb", false)
+		require.NoError(t, err)
+		assert.False(t, s.ServerSide)
+		assert.Equal(t, uint64(6000), s.Size)
+		assert.Len(t, s.Buckets, 3)
+	})
+	t.Run("missingUser", func(t *testing.T) {
+		api, err := New("127.0.0.1", "accessKey", "secretKey", returnBucketStatsMockClient(""))
+		require.NoError(t, err)
+		_, err = api.GetUserBucketStats(context.TODO(), "", false)
+		assert.ErrorIs(t, err, errMissingUserID)
+	})
+}
@@ -0,0 +1,53 @@
+//go:build ceph_preview
+
+package admin
+
+import "errors"
+
+const (
+	// ErrBucketAlreadyExists - Bucket already exists
+	ErrBucketAlreadyExists errorReason = "BucketAlreadyExists"
+
+	// ErrInvalidBucketName - Invalid bucket name specified
+	ErrInvalidBucketName errorReason = "InvalidBucketName"
+
+	// ErrTooManyBuckets - User has reached the maximum number of buckets
+	ErrTooManyBuckets errorReason = "TooManyBuckets"
+
+	// ErrQuotaExceeded - Quota of the user or bucket exceeded
+	ErrQuotaExceeded errorReason = "QuotaExceeded"
+
+	// ErrSlowDown - Rate limit exceeded
+	ErrSlowDown errorReason = "SlowDown"
+
+	// ErrServiceUnavailable - RGW is unable to serve the request
+	ErrServiceUnavailable errorReason = "ServiceUnavailable"
+)
+
+// ErrorDetails describes an error reported by the RGW
+type ErrorDetails struct {
+	// Code is the error code, as matched by the errorReason constants
+	// using errors.Is
+	Code string
+	// RequestID identifies the request in the logs of the RGW
+	RequestID string
+	HostID    string
+	// StatusCode is the HTTP status of the response, zero if unknown
+	StatusCode int
+}
+
+// GetErrorDetails returns the details of an error reported by the RGW. The
+// boolean is false if err was not reported by the RGW, for example in case
+// of a connection error.
+func GetErrorDetails(err error) (ErrorDetails, bool) {
+	var se statusError
+	if !errors.As(err, &se) {
+		return ErrorDetails{}, false
+	}
+	return ErrorDetails{
+		Code:       se.Code,
+		RequestID:  se.RequestID,
+		HostID:     se.HostID,
+		StatusCode: se.statusCode,
+	}, true
+}
@@ -0,0 +1,66 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetErrorDetails(t *testing.T) {
+	err := handleHTTPError(404, fakeGetUserError)
+	assert.ErrorIs(t, err, ErrNoSuchUser)
+	d, ok := GetErrorDetails(err)
+	require.True(t, ok)
+	assert.Equal(t, ErrorDetails{
+		Code:       "NoSuchUser",
+		RequestID:  "tx0000000000000000005a9-00608957a2-10496-my-store",
+		HostID:     "10496-my-store-my-store",
+		StatusCode: 404,
+	}, d)
+
+	err = handleHTTPError(403, []byte(`{"Code": "QuotaExceeded"}`))
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+	err = handleHTTPError(502, []byte("<html>Bad Gateway</html>"))
+	assert.ErrorIs(t, err, ErrUnknown)
+	assert.Contains(t, err.Error(), "Bad Gateway")
+	d, ok = GetErrorDetails(err)
+	require.True(t, ok)
+	assert.Equal(t, 502, d.StatusCode)
+
+	_, ok = GetErrorDetails(errors.New("connection refused"))
+	assert.False(t, ok)
+}
+
+func TestErrorDetailsMockAPI(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 503,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"Code": "SlowDown", "RequestId": "tx1", "HostId": "h"}`))),
+			}, nil
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = api.ListBuckets(context.TODO())
+	assert.ErrorIs(t, err, ErrSlowDown)
+	d, ok := GetErrorDetails(err)
+	require.True(t, ok)
+	assert.Equal(t, "tx1", d.RequestID)
+	assert.Equal(t, 503, d.StatusCode)
+
+	_, err = api.StatObject(context.TODO(), "mybucket", "myobject")
+	assert.ErrorIs(t, err, ErrUnknown)
+	d, ok = GetErrorDetails(err)
+	require.True(t, ok)
+	assert.Equal(t, 503, d.StatusCode)
+}
@@ -111,16 +111,21 @@ type statusError struct {
 	Code      string `json:"Code,omitempty"`
 	RequestID string `json:"RequestId,omitempty"`
 	HostID    string `json:"HostId,omitempty"`
+	// statusCode is the HTTP status of the response, if known
+	statusCode int
 }
 
-func handleStatusError(decodedResponse []byte) error {
-	statusError := statusError{}
-	err := json.Unmarshal(decodedResponse, &statusError)
-	if err != nil {
-		return fmt.Errorf("%s. %s. %w", unmarshalError, string(decodedResponse), err)
+// handleHTTPError returns the error reported by a response with the given
+// HTTP status. Responses without an error document, as may be returned by
+// proxies, are reported as ErrUnknown.
+func handleHTTPError(statusCode int, decodedResponse []byte) error {
+	e := statusError{}
+	if err := json.Unmarshal(decodedResponse, &e); err != nil || e.Code == "" {
+		e = statusError{Code: string(ErrUnknown), statusCode: statusCode}
+		return fmt.Errorf("%w. %s. %s", e, unmarshalError, string(decodedResponse))
 	}
-
-	return statusError
+	e.statusCode = statusCode
+	return e
 }
 
 func (e errorReason) Error() string { return string(e) }
@@ -12,12 +12,12 @@ var (
 	fakeGetSubUserError = []byte(`{"Code":"NoSuchSubUser","RequestId":"tx0000000000000000005a9-00608957a2-10496-my-store","HostId":"10496-my-store-my-store"}`)
 )
 
-func TestHandleStatusError(t *testing.T) {
-	err := handleStatusError(fakeGetUserError)
+func TestHandleHTTPError(t *testing.T) {
+	err := handleHTTPError(404, fakeGetUserError)
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, ErrNoSuchUser), err)
 
-	err = handleStatusError(fakeGetSubUserError)
+	err = handleHTTPError(404, fakeGetSubUserError)
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, ErrNoSuchSubUser), err)
 }
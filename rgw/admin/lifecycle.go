@@ -0,0 +1,112 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// The Admin Ops API does not provide access to the lifecycle configuration
+// of buckets, so the S3 API is used. Triggering the processing of the
+// lifecycle rules remains a radosgw-admin operation.
+
+// ErrNoSuchLifecycleConfiguration - Bucket has no lifecycle configuration
+const ErrNoSuchLifecycleConfiguration errorReason = "NoSuchLifecycleConfiguration"
+
+// LifecycleTag is a tag an object must have for a lifecycle rule to apply
+type LifecycleTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// LifecycleFilter selects the objects a lifecycle rule applies to
+type LifecycleFilter struct {
+	Prefix string        `xml:"Prefix"`
+	Tag    *LifecycleTag `xml:"Tag"`
+	And    *struct {
+		Prefix string         `xml:"Prefix"`
+		Tags   []LifecycleTag `xml:"Tag"`
+	} `xml:"And"`
+}
+
+// LifecycleExpiration describes when objects expire
+type LifecycleExpiration struct {
+	Days                      int    `xml:"Days"`
+	Date                      string `xml:"Date"`
+	ExpiredObjectDeleteMarker bool   `xml:"ExpiredObjectDeleteMarker"`
+}
+
+// LifecycleTransition describes when objects move to another storage class
+type LifecycleTransition struct {
+	Days         int    `xml:"Days"`
+	Date         string `xml:"Date"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+// LifecycleRule is a rule of the lifecycle configuration of a bucket
+type LifecycleRule struct {
+	ID     string `xml:"ID"`
+	Status string `xml:"Status"`
+	// Prefix is set by rules not using a filter
+	Prefix                      string               `xml:"Prefix"`
+	Filter                      *LifecycleFilter     `xml:"Filter"`
+	Expiration                  *LifecycleExpiration `xml:"Expiration"`
+	NoncurrentVersionExpiration *struct {
+		NoncurrentDays int `xml:"NoncurrentDays"`
+	} `xml:"NoncurrentVersionExpiration"`
+	Transitions                  []LifecycleTransition `xml:"Transition"`
+	NoncurrentVersionTransitions []struct {
+		NoncurrentDays int    `xml:"NoncurrentDays"`
+		StorageClass   string `xml:"StorageClass"`
+	} `xml:"NoncurrentVersionTransition"`
+	AbortIncompleteMultipartUpload *struct {
+		DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+	} `xml:"AbortIncompleteMultipartUpload"`
+}
+
+// LifecycleConfiguration is the lifecycle configuration of a bucket
+type LifecycleConfiguration struct {
+	Rules []LifecycleRule `xml:"Rule"`
+	// Raw is the configuration document as returned by the RGW
+	Raw []byte `xml:"-"`
+}
+
+// GetBucketLifecycle will return the lifecycle configuration of a bucket.
+// ErrNoSuchLifecycleConfiguration is returned if the bucket has none. The
+// credentials of the API must grant access to the bucket, as is the case
+// for system users.
+func (api *API) GetBucketLifecycle(ctx context.Context, bucket string) (LifecycleConfiguration, error) {
+	if bucket == "" {
+		return LifecycleConfiguration{}, errMissingBucket
+	}
+
+	_, body, err := api.callS3(ctx, http.MethodGet, "/"+url.PathEscape(bucket), "lifecycle")
+	if err != nil {
+		return LifecycleConfiguration{}, err
+	}
+
+	ref := LifecycleConfiguration{}
+	err = xml.Unmarshal(body, &ref)
+	if err != nil {
+		return LifecycleConfiguration{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+	ref.Raw = body
+
+	return ref, nil
+}
+
+// RemoveBucketLifecycle will remove the lifecycle configuration of a bucket.
+// The credentials of the API must grant access to the bucket, as is the case
+// for system users.
+func (api *API) RemoveBucketLifecycle(ctx context.Context, bucket string) error {
+	if bucket == "" {
+		return errMissingBucket
+	}
+
+	_, _, err := api.callS3(ctx, http.MethodDelete, "/"+url.PathEscape(bucket), "lifecycle")
+	return err
+}
@@ -0,0 +1,97 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeLifecycleResponse = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Rule>
+    <ID>expire-logs</ID>
+    <Filter><Prefix>logs/</Prefix></Filter>
+    <Status>Enabled</Status>
+    <Expiration><Days>30</Days></Expiration>
+    <Transition><Days>7</Days><StorageClass>COLD</StorageClass></Transition>
+  </Rule>
+  <Rule>
+    <ID>cleanup</ID>
+    <Prefix></Prefix>
+    <Status>Disabled</Status>
+    <AbortIncompleteMultipartUpload><DaysAfterInitiation>2</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+  </Rule>
+</LifecycleConfiguration>`)
+	fakeNoLifecycleResponse = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>NoSuchLifecycleConfiguration</Code><BucketName>empty</BucketName><RequestId>tx0</RequestId><HostId>h</HostId></Error>`)
+)
+
+func returnLifecycleMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			if !req.URL.Query().Has("lifecycle") {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			resp := &http.Response{StatusCode: 200}
+			var body []byte
+			switch {
+			case req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/mybucket":
+				body = fakeLifecycleResponse
+			case req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/empty":
+				resp.StatusCode = http.StatusNotFound
+				body = fakeNoLifecycleResponse
+			case req.Method == http.MethodDelete && req.URL.Path == "127.0.0.1/mybucket":
+				resp.StatusCode = http.StatusNoContent
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			return resp, nil
+		},
+	}
+}
+
+func TestBucketLifecycle(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnLifecycleMockClient())
+	require.NoError(t, err)
+
+	t.Run("get", func(t *testing.T) {
+		lc, err := api.GetBucketLifecycle(context.TODO(), "mybucket")
+		require.NoError(t, err)
+		assert.Equal(t, fakeLifecycleResponse, lc.Raw)
+		require.Len(t, lc.Rules, 2)
+		r := lc.Rules[0]
+		assert.Equal(t, "expire-logs", r.ID)
+		assert.Equal(t, "Enabled", r.Status)
+		require.NotNil(t, r.Filter)
+		assert.Equal(t, "logs/", r.Filter.Prefix)
+		require.NotNil(t, r.Expiration)
+		assert.Equal(t, 30, r.Expiration.Days)
+		assert.Equal(t, []LifecycleTransition{{Days: 7, StorageClass: "COLD"}}, r.Transitions)
+		r = lc.Rules[1]
+		assert.Nil(t, r.Filter)
+		require.NotNil(t, r.AbortIncompleteMultipartUpload)
+		assert.Equal(t, 2, r.AbortIncompleteMultipartUpload.DaysAfterInitiation)
+	})
+	t.Run("getMissing", func(t *testing.T) {
+		_, err := api.GetBucketLifecycle(context.TODO(), "empty")
+		assert.ErrorIs(t, err, ErrNoSuchLifecycleConfiguration)
+		_, err = api.GetBucketLifecycle(context.TODO(), "")
+		assert.ErrorIs(t, err, errMissingBucket)
+	})
+	t.Run("remove", func(t *testing.T) {
+		err := api.RemoveBucketLifecycle(context.TODO(), "mybucket")
+		assert.NoError(t, err)
+		err = api.RemoveBucketLifecycle(context.TODO(), "")
+		assert.ErrorIs(t, err, errMissingBucket)
+	})
+}
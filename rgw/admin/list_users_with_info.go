@@ -0,0 +1,90 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// defaultUserInfoConcurrency is the number of users retrieved at once by
+// ListUsersWithInfo if no concurrency is given
+const defaultUserInfoConcurrency = 8
+
+// ListUsersWithInfo will return the information of all the users, sorted
+// like the IDs returned by GetUsers. The users are retrieved by concurrency
+// requests at a time, a default is used if concurrency is zero. Users
+// removed while they are listed are omitted. The first error stops the
+// retrieval and is returned.
+func (api *API) ListUsersWithInfo(ctx context.Context, concurrency int) ([]User, error) {
+	if concurrency <= 0 {
+		concurrency = defaultUserInfoConcurrency
+	}
+
+	var ids []string
+	err := api.WalkUsers(ctx, 0, func(uid string) error {
+		ids = append(ids, uid)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	users := make([]User, len(ids))
+	found := make([]bool, len(ids))
+	next := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for w := 0; w < concurrency && w < len(ids); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				u, err := api.GetUser(ctx, User{ID: ids[i]})
+				if errors.Is(err, ErrNoSuchUser) {
+					continue
+				}
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				users[i], found[i] = u, true
+			}
+		}()
+	}
+feed:
+	for i := range ids {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]User, 0, len(users))
+	for i, u := range users {
+		if found[i] {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func returnUsersWithInfoMockClient(failUser string, calls *int32) *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			status := 200
+			var body string
+			switch {
+			case req.URL.Path == "127.0.0.1/admin/metadata/user":
+				body = `{"keys": ["alice", "bob", "gone", "carol", "dave"], "truncated": false, "count": 5}`
+			case req.URL.Path == "127.0.0.1/admin/user":
+				atomic.AddInt32(calls, 1)
+				uid := q.Get("uid")
+				switch uid {
+				case "gone":
+					status, body = 404, `{"Code": "NoSuchUser", "RequestId": "tx0", "HostId": "h"}`
+				case failUser:
+					status, body = 500, `{"Code": "InternalError", "RequestId": "tx0", "HostId": "h"}`
+				default:
+					body = fmt.Sprintf(`{"user_id": %q, "display_name": "user %s"}`, uid, uid)
+				}
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			}, nil
+		},
+	}
+}
+
+func TestListUsersWithInfo(t *testing.T) {
+	var calls int32
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnUsersWithInfoMockClient("", &calls))
+	require.NoError(t, err)
+
+	users, err := api.ListUsersWithInfo(context.TODO(), 2)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+		assert.Equal(t, "user "+u.ID, u.DisplayName)
+	}
+	assert.Equal(t, []string{"alice", "bob", "carol", "dave"}, ids)
+	assert.Equal(t, int32(5), calls)
+}
+
+func TestListUsersWithInfoError(t *testing.T) {
+	var calls int32
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnUsersWithInfoMockClient("bob", &calls))
+	require.NoError(t, err)
+
+	_, err = api.ListUsersWithInfo(context.TODO(), 1)
+	assert.ErrorIs(t, err, ErrInternalError)
+	assert.LessOrEqual(t, calls, int32(3))
+}
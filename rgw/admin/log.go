@@ -0,0 +1,232 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var errMissingLogMarker = errors.New("missing log marker")
+
+// MetadataLogRequest contains the parameters of the metadata log requests
+type MetadataLogRequest struct {
+	ShardID int `url:"id"`
+	// Period is the ID of the period of the log, the current period is
+	// used if unset
+	Period     string `url:"period"`
+	Marker     string `url:"marker"`
+	MaxEntries *int   `url:"max-entries"`
+}
+
+// DataLogRequest contains the parameters of the data log requests
+type DataLogRequest struct {
+	ShardID    int    `url:"id"`
+	Marker     string `url:"marker"`
+	MaxEntries *int   `url:"max-entries"`
+}
+
+// BucketIndexLogRequest contains the parameters of the bucket index log
+// requests
+type BucketIndexLogRequest struct {
+	Bucket string `url:"bucket"`
+	// BucketInstance optionally selects the bucket instance and shard, in
+	// the form "<bucket>:<bucket id>:<shard>"
+	BucketInstance string `url:"bucket-instance"`
+	Marker         string `url:"marker"`
+	MaxEntries     *int   `url:"max-entries"`
+	// EndMarker is the last entry removed when trimming
+	EndMarker string `url:"end-marker"`
+}
+
+// MetadataLogInfo describes the metadata log of a period
+type MetadataLogInfo struct {
+	NumShards  int    `json:"num_objects"`
+	Period     string `json:"period"`
+	RealmEpoch int    `json:"realm_epoch"`
+}
+
+// DataLogInfo describes the data log of the zone
+type DataLogInfo struct {
+	NumShards int `json:"num_objects"`
+}
+
+// MetadataLogShardInfo reports the position of a metadata log shard
+type MetadataLogShardInfo = DataLogShardInfo
+
+// MetadataLogEntry is an entry of the metadata log
+type MetadataLogEntry struct {
+	ID        string `json:"id"`
+	Section   string `json:"section"`
+	Name      string `json:"name"`
+	Timestamp string `json:"timestamp"`
+	// Data is the status of the change, its content depends on the
+	// version
+	Data json.RawMessage `json:"data"`
+}
+
+// MetadataLogEntries are the entries of a metadata log shard
+type MetadataLogEntries struct {
+	Marker    string             `json:"marker"`
+	Truncated bool               `json:"truncated"`
+	Entries   []MetadataLogEntry `json:"entries"`
+}
+
+// DataLogEntry is an entry of the data log
+type DataLogEntry struct {
+	LogID        string `json:"log_id"`
+	LogTimestamp string `json:"log_timestamp"`
+	Entry        struct {
+		// Key identifies the changed bucket shard
+		Key       string `json:"key"`
+		Timestamp string `json:"timestamp"`
+	} `json:"entry"`
+}
+
+// DataLogEntries are the entries of a data log shard
+type DataLogEntries struct {
+	Marker    string         `json:"marker"`
+	Truncated bool           `json:"truncated"`
+	Entries   []DataLogEntry `json:"entries"`
+}
+
+// BucketIndexLogInfo reports the position of the index log of a bucket
+type BucketIndexLogInfo struct {
+	BucketVer   string `json:"bucket_ver"`
+	MasterVer   string `json:"master_ver"`
+	MaxMarker   string `json:"max_marker"`
+	SyncStopped bool   `json:"syncstopped"`
+	OldestGen   uint64 `json:"oldest_gen"`
+	LatestGen   uint64 `json:"latest_gen"`
+}
+
+// BucketIndexLogEntry is an entry of the index log of a bucket
+type BucketIndexLogEntry struct {
+	OpID      string `json:"op_id"`
+	OpTag     string `json:"op_tag"`
+	Op        string `json:"op"`
+	Object    string `json:"object"`
+	Instance  string `json:"instance"`
+	State     string `json:"state"`
+	IndexVer  uint64 `json:"index_ver"`
+	Timestamp string `json:"timestamp"`
+	Versioned bool   `json:"versioned"`
+	Owner     string `json:"owner"`
+}
+
+// getLog sends a GET request for the given log and decodes the response
+func (api *API) getLog(ctx context.Context, path string, args interface{}, fields []string, ref interface{}) error {
+	body, err := api.call(ctx, http.MethodGet, path, valueToURLParams(args, fields))
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(body, ref)
+	if err != nil {
+		return fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return nil
+}
+
+// GetMetadataLogInfo will return the number of shards of the metadata log of
+// a period, the current period is used if period is empty
+func (api *API) GetMetadataLogInfo(ctx context.Context, period string) (MetadataLogInfo, error) {
+	ref := MetadataLogInfo{}
+	err := api.getLog(ctx, "/log?type=metadata", MetadataLogRequest{Period: period}, []string{"period"}, &ref)
+	return ref, err
+}
+
+// GetMetadataLogShardInfo will return the position of a metadata log shard
+func (api *API) GetMetadataLogShardInfo(ctx context.Context, req MetadataLogRequest) (MetadataLogShardInfo, error) {
+	ref := MetadataLogShardInfo{}
+	err := api.getLog(ctx, "/log?type=metadata&info", req, []string{"id", "period"}, &ref)
+	return ref, err
+}
+
+// ListMetadataLog will return the entries of a metadata log shard following
+// the marker
+func (api *API) ListMetadataLog(ctx context.Context, req MetadataLogRequest) (MetadataLogEntries, error) {
+	ref := MetadataLogEntries{}
+	err := api.getLog(ctx, "/log?type=metadata", req, []string{"id", "period", "marker", "max-entries"}, &ref)
+	return ref, err
+}
+
+// TrimMetadataLog will remove the entries of a metadata log shard up to and
+// including the marker
+func (api *API) TrimMetadataLog(ctx context.Context, req MetadataLogRequest) error {
+	if req.Marker == "" {
+		return errMissingLogMarker
+	}
+
+	_, err := api.call(ctx, http.MethodDelete, "/log?type=metadata", valueToURLParams(req, []string{"id", "period", "marker"}))
+	return err
+}
+
+// GetDataLogInfo will return the number of shards of the data log of the
+// zone
+func (api *API) GetDataLogInfo(ctx context.Context) (DataLogInfo, error) {
+	ref := DataLogInfo{}
+	err := api.getLog(ctx, "/log?type=data", DataLogRequest{}, []string{}, &ref)
+	return ref, err
+}
+
+// ListDataLog will return the entries of a data log shard following the
+// marker
+func (api *API) ListDataLog(ctx context.Context, req DataLogRequest) (DataLogEntries, error) {
+	ref := DataLogEntries{}
+	err := api.getLog(ctx, "/log?type=data", req, []string{"id", "marker", "max-entries"}, &ref)
+	return ref, err
+}
+
+// TrimDataLog will remove the entries of a data log shard up to and
+// including the marker
+func (api *API) TrimDataLog(ctx context.Context, req DataLogRequest) error {
+	if req.Marker == "" {
+		return errMissingLogMarker
+	}
+
+	_, err := api.call(ctx, http.MethodDelete, "/log?type=data", valueToURLParams(req, []string{"id", "marker"}))
+	return err
+}
+
+// GetBucketIndexLogInfo will return the position of the index log of a
+// bucket
+func (api *API) GetBucketIndexLogInfo(ctx context.Context, req BucketIndexLogRequest) (BucketIndexLogInfo, error) {
+	if req.Bucket == "" && req.BucketInstance == "" {
+		return BucketIndexLogInfo{}, errMissingBucket
+	}
+
+	ref := BucketIndexLogInfo{}
+	err := api.getLog(ctx, "/log?type=bucket-index&info", req, []string{"bucket", "bucket-instance"}, &ref)
+	return ref, err
+}
+
+// ListBucketIndexLog will return the entries of the index log of a bucket
+// following the marker
+func (api *API) ListBucketIndexLog(ctx context.Context, req BucketIndexLogRequest) ([]BucketIndexLogEntry, error) {
+	if req.Bucket == "" && req.BucketInstance == "" {
+		return nil, errMissingBucket
+	}
+
+	ref := []BucketIndexLogEntry{}
+	err := api.getLog(ctx, "/log?type=bucket-index", req, []string{"bucket", "bucket-instance", "marker", "max-entries"}, &ref)
+	return ref, err
+}
+
+// TrimBucketIndexLog will remove the entries of the index log of a bucket up
+// to and including the end marker
+func (api *API) TrimBucketIndexLog(ctx context.Context, req BucketIndexLogRequest) error {
+	if req.Bucket == "" && req.BucketInstance == "" {
+		return errMissingBucket
+	}
+	if req.EndMarker == "" {
+		return errMissingLogMarker
+	}
+
+	_, err := api.call(ctx, http.MethodDelete, "/log?type=bucket-index", valueToURLParams(req, []string{"bucket", "bucket-instance", "end-marker"}))
+	return err
+}
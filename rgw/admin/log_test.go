@@ -0,0 +1,169 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeMetadataLogInfoResponse = []byte(`{"num_objects": 64, "period": "ba5ebb1c", "realm_epoch": 2}`)
+	fakeMetadataLogResponse     = []byte(`{
+  "marker": "1_1700000000.000001_2.1",
+  "truncated": true,
+  "entries": [
+    {
+      "id": "1_1700000000.000001_2.1",
+      "section": "user",
+      "name": "leseb",
+      "timestamp": "2023-11-14T22:13:20.000001Z",
+      "data": {"status": {"status": "complete"}}
+    }
+  ]
+}`)
+	fakeDataLogResponse = []byte(`{
+  "marker": "00000000000000000000:00000000000000000002",
+  "truncated": false,
+  "entries": [
+    {
+      "log_id": "00000000000000000000:00000000000000000002",
+      "log_timestamp": "2023-11-14T22:13:20.000001Z",
+      "entry": {"key": "mybucket:8d5b4a6b.4177.1:3", "timestamp": "2023-11-14T22:13:20.000001Z"}
+    }
+  ]
+}`)
+	fakeBucketIndexLogInfoResponse = []byte(`{
+  "bucket_ver": "0#3",
+  "master_ver": "0#0",
+  "max_marker": "0#00000000002.2.3",
+  "syncstopped": false,
+  "oldest_gen": 0,
+  "latest_gen": 0
+}`)
+	fakeBucketIndexLogResponse = []byte(`[
+  {
+    "op_id": "0#00000000002.2.3",
+    "op_tag": "8d5b4a6b.4177.1",
+    "op": "write",
+    "object": "myobject",
+    "instance": "",
+    "state": "complete",
+    "index_ver": 2,
+    "timestamp": "2023-11-14T22:13:20.000001Z",
+    "ver": {"pool": 7, "epoch": 3},
+    "bilog_flags": 0,
+    "versioned": false,
+    "owner": "",
+    "owner_display_name": ""
+  }
+]`)
+)
+
+func returnLogMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.URL.Path != "127.0.0.1/admin/log" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			var body []byte
+			switch {
+			case req.Method == http.MethodDelete:
+				if q.Get("marker") == "" && q.Get("end-marker") == "" {
+					return nil, fmt.Errorf("missing marker: %q", req.URL.RawQuery)
+				}
+			case q.Get("type") == "metadata" && q.Has("info"):
+				body = []byte(`{"marker": "1_1700000000.000001_2.1", "last_update": "2023-11-14T22:13:20.000001Z"}`)
+			case q.Get("type") == "metadata" && q.Has("id"):
+				body = fakeMetadataLogResponse
+			case q.Get("type") == "metadata":
+				body = fakeMetadataLogInfoResponse
+			case q.Get("type") == "data" && q.Has("id"):
+				body = fakeDataLogResponse
+			case q.Get("type") == "data":
+				body = []byte(`{"num_objects": 128}`)
+			case q.Get("type") == "bucket-index" && q.Has("info"):
+				body = fakeBucketIndexLogInfoResponse
+			case q.Get("type") == "bucket-index":
+				body = fakeBucketIndexLogResponse
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestLogs(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnLogMockClient())
+	require.NoError(t, err)
+	ctx := context.TODO()
+	maxEntries := 10
+
+	t.Run("metadata", func(t *testing.T) {
+		info, err := api.GetMetadataLogInfo(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, MetadataLogInfo{NumShards: 64, Period: "ba5ebb1c", RealmEpoch: 2}, info)
+
+		shard, err := api.GetMetadataLogShardInfo(ctx, MetadataLogRequest{ShardID: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "1_1700000000.000001_2.1", shard.Marker)
+
+		entries, err := api.ListMetadataLog(ctx, MetadataLogRequest{ShardID: 1, MaxEntries: &maxEntries})
+		require.NoError(t, err)
+		assert.True(t, entries.Truncated)
+		require.Len(t, entries.Entries, 1)
+		assert.Equal(t, "leseb", entries.Entries[0].Name)
+		assert.JSONEq(t, `{"status": {"status": "complete"}}`, string(entries.Entries[0].Data))
+
+		err = api.TrimMetadataLog(ctx, MetadataLogRequest{ShardID: 1})
+		assert.ErrorIs(t, err, errMissingLogMarker)
+		err = api.TrimMetadataLog(ctx, MetadataLogRequest{ShardID: 1, Marker: entries.Marker})
+		assert.NoError(t, err)
+	})
+	t.Run("data", func(t *testing.T) {
+		info, err := api.GetDataLogInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 128, info.NumShards)
+
+		entries, err := api.ListDataLog(ctx, DataLogRequest{ShardID: 0})
+		require.NoError(t, err)
+		assert.False(t, entries.Truncated)
+		require.Len(t, entries.Entries, 1)
+		assert.Equal(t, "mybucket:8d5b4a6b.4177.1:3", entries.Entries[0].Entry.Key)
+
+		err = api.TrimDataLog(ctx, DataLogRequest{})
+		assert.ErrorIs(t, err, errMissingLogMarker)
+		err = api.TrimDataLog(ctx, DataLogRequest{Marker: entries.Marker})
+		assert.NoError(t, err)
+	})
+	t.Run("bucketIndex", func(t *testing.T) {
+		_, err := api.GetBucketIndexLogInfo(ctx, BucketIndexLogRequest{})
+		assert.ErrorIs(t, err, errMissingBucket)
+		info, err := api.GetBucketIndexLogInfo(ctx, BucketIndexLogRequest{Bucket: "mybucket"})
+		require.NoError(t, err)
+		assert.Equal(t, "0#00000000002.2.3", info.MaxMarker)
+
+		entries, err := api.ListBucketIndexLog(ctx, BucketIndexLogRequest{Bucket: "mybucket"})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "write", entries[0].Op)
+		assert.Equal(t, uint64(2), entries[0].IndexVer)
+
+		err = api.TrimBucketIndexLog(ctx, BucketIndexLogRequest{Bucket: "mybucket"})
+		assert.ErrorIs(t, err, errMissingLogMarker)
+		err = api.TrimBucketIndexLog(ctx, BucketIndexLogRequest{Bucket: "mybucket", EndMarker: info.MaxMarker})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,461 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// The Admin Ops API has no MFA endpoints. The TOTP devices of a user are
+// stored in the "otp" metadata section and referenced by the mfa_ids of the
+// user metadata, so they are administered using the metadata API, like
+// radosgw-admin mfa does. The metadata objects are updated by version, so an
+// update is retried rather than undoing a concurrent change of the object.
+
+// MFASeedType is the encoding of the seed of an MFA device
+type MFASeedType string
+
+// The possible values of MFASeedType
+const (
+	MFASeedHex    MFASeedType = "hex"
+	MFASeedBase32 MFASeedType = "base32"
+)
+
+const (
+	// ErrNoSuchMFADevice - User has no MFA device with the given ID
+	ErrNoSuchMFADevice errorReason = "NoSuchMFADevice"
+
+	// ErrMFADeviceExists - User already has an MFA device with the given ID
+	ErrMFADeviceExists errorReason = "MFADeviceExists"
+)
+
+const (
+	// otpTypeTOTP is the type of TOTP devices in the otp metadata
+	otpTypeTOTP = 2
+
+	defaultMFAStepSize = 30
+	defaultMFAWindow   = 2
+	// maxMFASkew is the largest clock skew looked for when resyncing,
+	// as done by radosgw-admin
+	maxMFASkew = 7 * 24 * time.Hour
+
+	// maxMetadataUpdateTries is the number of times an update of a
+	// metadata object is tried when the object keeps changing
+	maxMetadataUpdateTries = 5
+)
+
+var (
+	errMissingMFADeviceID = errors.New("missing MFA device ID")
+	errMissingMFASeed     = errors.New("missing MFA seed")
+	errMissingMFAPin      = errors.New("missing MFA pin")
+	errMFAPinMismatch     = errors.New("MFA pins do not match the device")
+	errMetadataChanged    = errors.New("metadata object changed during the update")
+)
+
+// MFADevice is a TOTP device of a user
+type MFADevice struct {
+	// ID is the serial of the device
+	ID       string      `json:"id"`
+	Seed     string      `json:"seed"`
+	SeedType MFASeedType `json:"seed_type"`
+	// TimeOffset is the number of seconds the clock of the device is
+	// behind, negative if it is ahead
+	TimeOffset int64 `json:"time_ofs"`
+	// StepSize is the number of seconds a pin is valid, 30 if unset
+	StepSize int `json:"step_size"`
+	// Window is the number of steps before and after the current one
+	// that are accepted, 2 if unset
+	Window int `json:"window"`
+}
+
+// otpDevice is an MFADevice as stored in the otp metadata
+type otpDevice struct {
+	Type int `json:"type"`
+	MFADevice
+}
+
+// objVersion is the version of a metadata object
+type objVersion struct {
+	Tag string `json:"tag"`
+	Ver uint64 `json:"ver"`
+}
+
+// otpMetadata is the metadata of the otp section
+type otpMetadata struct {
+	Key   string     `json:"key"`
+	Ver   objVersion `json:"ver"`
+	Mtime string     `json:"mtime,omitempty"`
+	Data  struct {
+		Devices []otpDevice `json:"devices"`
+	} `json:"data"`
+}
+
+func (d MFADevice) seed() ([]byte, error) {
+	switch d.SeedType {
+	case MFASeedHex, "":
+		return hex.DecodeString(d.Seed)
+	case MFASeedBase32:
+		s := strings.ToUpper(strings.TrimRight(d.Seed, "="))
+		return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+	}
+	return nil, fmt.Errorf("%w: seed type %q", ErrInvalidArgument, d.SeedType)
+}
+
+// totp returns the 6 digit pin for the given step of the key, see RFC 6238
+func totp(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	o := sum[len(sum)-1] & 0xf
+	v := binary.BigEndian.Uint32(sum[o:o+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", v%1000000)
+}
+
+// resyncOffset returns the time offset at which pin2 is the current pin of
+// the device and pin1 the one before it
+func resyncOffset(d MFADevice, pin1, pin2 string, now time.Time) (int64, error) {
+	key, err := d.seed()
+	if err != nil {
+		return 0, err
+	}
+	step := int64(d.StepSize)
+	if step <= 0 {
+		step = defaultMFAStepSize
+	}
+	t := now.Unix()
+	for ofs := int64(0); ofs < int64(maxMFASkew/time.Second); ofs += step {
+		for _, o := range []int64{ofs, -ofs} {
+			s := (t - o) / step
+			if totp(key, s-1) == pin1 && totp(key, s) == pin2 {
+				return o, nil
+			}
+			if ofs == 0 {
+				break
+			}
+		}
+	}
+	return 0, errMFAPinMismatch
+}
+
+func (api *API) getOTPMetadata(ctx context.Context, uid string) (otpMetadata, error) {
+	args := valueToURLParams(metadataRequest{Key: uid}, []string{"key"})
+	body, err := api.call(ctx, http.MethodGet, "/metadata/otp", args)
+	if errors.Is(err, ErrNoSuchKey) {
+		return otpMetadata{Key: uid}, nil
+	}
+	if err != nil {
+		return otpMetadata{}, err
+	}
+
+	ref := otpMetadata{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return otpMetadata{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref, nil
+}
+
+// putMetadata stores the given metadata object in a metadata section if the
+// version of the stored object is the one preceding the version of the given
+// object. It returns false if the object was not stored as it has changed.
+func (api *API) putMetadata(ctx context.Context, section, key string, v interface{}) (bool, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return false, err
+	}
+	args := valueToURLParams(metadataRequest{Key: key}, []string{"key"})
+	args.Set("update-type", "update-by-version")
+	requestURL := buildQueryPath(api.Endpoint, "/metadata/"+section, args.Encode())
+	resp, _, err := api.doResponse(ctx, http.MethodPut, requestURL, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	return resp.Header.Get("RGWX_UPDATE_STATUS") != "skipped", nil
+}
+
+// updateOTPMetadata applies the given change to the otp metadata of the user
+// and stores it, retrying if the metadata changes in the meantime
+func (api *API) updateOTPMetadata(ctx context.Context, uid string, change func(*otpMetadata) error) error {
+	for i := 0; i < maxMetadataUpdateTries; i++ {
+		m, err := api.getOTPMetadata(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if err := change(&m); err != nil {
+			return err
+		}
+		m.Ver.Ver++
+		applied, err := api.putMetadata(ctx, "otp", uid, m)
+		if err != nil {
+			return err
+		}
+		if applied {
+			return nil
+		}
+	}
+	return errMetadataChanged
+}
+
+// updateUserMFAIDs applies the given change to the mfa_ids of the user
+// metadata, leaving the rest of the metadata as it is and retrying if the
+// metadata changes in the meantime
+func (api *API) updateUserMFAIDs(ctx context.Context, uid string, change func([]string) []string) error {
+	args := valueToURLParams(metadataRequest{Key: uid}, []string{"key"})
+	for i := 0; i < maxMetadataUpdateTries; i++ {
+		body, err := api.call(ctx, http.MethodGet, "/metadata/user", args)
+		if err != nil {
+			return err
+		}
+
+		ref := map[string]json.RawMessage{}
+		data := map[string]json.RawMessage{}
+		ver := objVersion{}
+		ids := []string{}
+		err = json.Unmarshal(body, &ref)
+		if err == nil {
+			err = json.Unmarshal(ref["data"], &data)
+		}
+		if err == nil {
+			err = json.Unmarshal(ref["ver"], &ver)
+		}
+		if err == nil && data["mfa_ids"] != nil {
+			err = json.Unmarshal(data["mfa_ids"], &ids)
+		}
+		if err != nil {
+			return fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+		}
+
+		ver.Ver++
+		if data["mfa_ids"], err = json.Marshal(change(ids)); err != nil {
+			return err
+		}
+		if ref["data"], err = json.Marshal(data); err != nil {
+			return err
+		}
+		if ref["ver"], err = json.Marshal(ver); err != nil {
+			return err
+		}
+		applied, err := api.putMetadata(ctx, "user", uid, ref)
+		if err != nil {
+			return err
+		}
+		if applied {
+			return nil
+		}
+	}
+	return errMetadataChanged
+}
+
+// addID returns a change adding id to a list of IDs
+func addID(id string) func([]string) []string {
+	return func(ids []string) []string {
+		for _, i := range ids {
+			if i == id {
+				return ids
+			}
+		}
+		return append(ids, id)
+	}
+}
+
+// removeID returns a change removing id from a list of IDs
+func removeID(id string) func([]string) []string {
+	return func(ids []string) []string {
+		l := []string{}
+		for _, i := range ids {
+			if i != id {
+				l = append(l, i)
+			}
+		}
+		return l
+	}
+}
+
+func (m *otpMetadata) find(id string) int {
+	for i, d := range m.Data.Devices {
+		if d.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// ListMFADevices will return the TOTP devices of a user
+func (api *API) ListMFADevices(ctx context.Context, uid string) ([]MFADevice, error) {
+	if uid == "" {
+		return nil, errMissingUserID
+	}
+
+	m, err := api.getOTPMetadata(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]MFADevice, 0, len(m.Data.Devices))
+	for _, d := range m.Data.Devices {
+		devices = append(devices, d.MFADevice)
+	}
+	return devices, nil
+}
+
+// GetMFADevice will return a TOTP device of a user
+func (api *API) GetMFADevice(ctx context.Context, uid, id string) (MFADevice, error) {
+	if uid == "" {
+		return MFADevice{}, errMissingUserID
+	}
+	if id == "" {
+		return MFADevice{}, errMissingMFADeviceID
+	}
+
+	m, err := api.getOTPMetadata(ctx, uid)
+	if err != nil {
+		return MFADevice{}, err
+	}
+	i := m.find(id)
+	if i < 0 {
+		return MFADevice{}, ErrNoSuchMFADevice
+	}
+	return m.Data.Devices[i].MFADevice, nil
+}
+
+// CreateMFADevice will add a TOTP device to a user. The seed defaults to the
+// hex encoding, the step size and window to those of radosgw-admin.
+//
+// The device is stored in the otp metadata before the user metadata is made
+// to reference it. These updates are not atomic: if referencing the device
+// fails, the device is removed again, but it is left unreferenced if that
+// fails too.
+func (api *API) CreateMFADevice(ctx context.Context, uid string, device MFADevice) error {
+	if uid == "" {
+		return errMissingUserID
+	}
+	if device.ID == "" {
+		return errMissingMFADeviceID
+	}
+	if device.Seed == "" {
+		return errMissingMFASeed
+	}
+	if device.SeedType == "" {
+		device.SeedType = MFASeedHex
+	}
+	if _, err := device.seed(); err != nil {
+		return fmt.Errorf("%w: invalid seed: %v", ErrInvalidArgument, err)
+	}
+	if device.StepSize == 0 {
+		device.StepSize = defaultMFAStepSize
+	}
+	if device.Window == 0 {
+		device.Window = defaultMFAWindow
+	}
+
+	err := api.updateOTPMetadata(ctx, uid, func(m *otpMetadata) error {
+		if m.find(device.ID) >= 0 {
+			return ErrMFADeviceExists
+		}
+		m.Data.Devices = append(m.Data.Devices, otpDevice{Type: otpTypeTOTP, MFADevice: device})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = api.updateUserMFAIDs(ctx, uid, addID(device.ID))
+	if err != nil {
+		uerr := api.updateOTPMetadata(ctx, uid, removeOTPDevice(device.ID))
+		return errors.Join(err, uerr)
+	}
+	return nil
+}
+
+// removeOTPDevice returns a change removing a device from the otp metadata
+func removeOTPDevice(id string) func(*otpMetadata) error {
+	return func(m *otpMetadata) error {
+		i := m.find(id)
+		if i < 0 {
+			return ErrNoSuchMFADevice
+		}
+		m.Data.Devices = append(m.Data.Devices[:i], m.Data.Devices[i+1:]...)
+		return nil
+	}
+}
+
+// ResyncMFADevice will adjust the time offset of a TOTP device of a user to
+// the clock of the device. pin1 and pin2 are two consecutive pins shown by
+// the device, pin2 being the current one.
+func (api *API) ResyncMFADevice(ctx context.Context, uid, id, pin1, pin2 string) error {
+	if uid == "" {
+		return errMissingUserID
+	}
+	if id == "" {
+		return errMissingMFADeviceID
+	}
+	if pin1 == "" || pin2 == "" {
+		return errMissingMFAPin
+	}
+
+	err := api.updateOTPMetadata(ctx, uid, func(m *otpMetadata) error {
+		i := m.find(id)
+		if i < 0 {
+			return ErrNoSuchMFADevice
+		}
+		ofs, err := resyncOffset(m.Data.Devices[i].MFADevice, pin1, pin2, time.Now())
+		if err != nil {
+			return err
+		}
+		m.Data.Devices[i].TimeOffset = ofs
+		return nil
+	})
+	return err
+}
+
+// RemoveMFADevice will remove a TOTP device from a user
+//
+// The user metadata stops referencing the device before the device is
+// removed from the otp metadata. These updates are not atomic: if removing
+// the device fails, the user is made to reference it again, but it is left
+// unreferenced if that fails too.
+func (api *API) RemoveMFADevice(ctx context.Context, uid, id string) error {
+	if uid == "" {
+		return errMissingUserID
+	}
+	if id == "" {
+		return errMissingMFADeviceID
+	}
+
+	m, err := api.getOTPMetadata(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if m.find(id) < 0 {
+		return ErrNoSuchMFADevice
+	}
+
+	err = api.updateUserMFAIDs(ctx, uid, removeID(id))
+	if err != nil {
+		return err
+	}
+	err = api.updateOTPMetadata(ctx, uid, removeOTPDevice(id))
+	if errors.Is(err, ErrNoSuchMFADevice) {
+		// the device was removed concurrently
+		return nil
+	}
+	if err != nil {
+		return errors.Join(err, api.updateUserMFAIDs(ctx, uid, addID(id)))
+	}
+	return nil
+}
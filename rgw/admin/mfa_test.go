@@ -0,0 +1,253 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fakeUserMetadataResponse = []byte(`{
+  "key": "leseb",
+  "ver": {"tag": "_abc", "ver": 3},
+  "mtime": "2024-01-02T10:00:00.000000Z",
+  "data": {
+    "user_id": "leseb",
+    "display_name": "This is my name",
+    "mfa_ids": [],
+    "attrs": []
+  }
+}`)
+
+// metadataVersion returns the version of a metadata object
+func metadataVersion(obj []byte) objVersion {
+	ref := struct {
+		Ver objVersion `json:"ver"`
+	}{}
+	_ = json.Unmarshal(obj, &ref)
+	return ref.Ver
+}
+
+// returnMFAMockClient serves the otp and user metadata of the user leseb
+// from memory. The hooks are called before a metadata object is stored, the
+// object is not stored if they return false.
+func returnMFAMockClient() (*mockClient, map[string][]byte, map[string]func() bool) {
+	store := map[string][]byte{"user": fakeUserMetadataResponse}
+	hooks := map[string]func() bool{}
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			var section string
+			switch req.URL.Path {
+			case "127.0.0.1/admin/metadata/otp":
+				section = "otp"
+			case "127.0.0.1/admin/metadata/user":
+				section = "user"
+			}
+			if section == "" || req.URL.Query().Get("key") != "leseb" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			resp := &http.Response{StatusCode: 200}
+			var body []byte
+			switch req.Method {
+			case http.MethodGet:
+				var ok bool
+				if body, ok = store[section]; !ok {
+					resp.StatusCode = http.StatusNotFound
+					body = []byte(`{"Code":"NoSuchKey","RequestId":"tx0","HostId":"h"}`)
+				}
+			case http.MethodPut:
+				if req.URL.Query().Get("update-type") != "update-by-version" {
+					return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+				}
+				payload, err := io.ReadAll(req.Body)
+				if err != nil {
+					return nil, err
+				}
+				if hook := hooks[section]; hook != nil && !hook() {
+					resp.StatusCode = http.StatusInternalServerError
+					body = []byte(`{"Code":"Unknown","RequestId":"tx0","HostId":"h"}`)
+					break
+				}
+				onDisk, ver := metadataVersion(store[section]), metadataVersion(payload)
+				resp.Header = http.Header{}
+				if onDisk.Tag != ver.Tag || onDisk.Ver >= ver.Ver {
+					resp.Header.Set("RGWX_UPDATE_STATUS", "skipped")
+					break
+				}
+				store[section] = payload
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			return resp, nil
+		},
+	}, store, hooks
+}
+
+func userMFAIDs(t *testing.T, store map[string][]byte) []string {
+	ref := struct {
+		Key  string `json:"key"`
+		Data struct {
+			UserID string   `json:"user_id"`
+			MfaIDs []string `json:"mfa_ids"`
+		} `json:"data"`
+	}{}
+	require.NoError(t, json.Unmarshal(store["user"], &ref))
+	assert.Equal(t, "leseb", ref.Key)
+	assert.Equal(t, "leseb", ref.Data.UserID)
+	return ref.Data.MfaIDs
+}
+
+func TestMFADevices(t *testing.T) {
+	c, store, _ := returnMFAMockClient()
+	api, err := New("127.0.0.1", "accessKey", "secretKey", c)
+	require.NoError(t, err)
+	ctx := context.TODO()
+
+	devices, err := api.ListMFADevices(ctx, "leseb")
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+
+	err = api.CreateMFADevice(ctx, "leseb", MFADevice{ID: "dev1", Seed: "3132333435"})
+	require.NoError(t, err)
+	err = api.CreateMFADevice(ctx, "leseb", MFADevice{
+		ID: "dev2", Seed: "GEZDGNBV", SeedType: MFASeedBase32, StepSize: 60, Window: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev1", "dev2"}, userMFAIDs(t, store))
+	assert.Contains(t, string(store["otp"]), `"type":2`)
+
+	err = api.CreateMFADevice(ctx, "leseb", MFADevice{ID: "dev1", Seed: "3132"})
+	assert.ErrorIs(t, err, ErrMFADeviceExists)
+	err = api.CreateMFADevice(ctx, "leseb", MFADevice{ID: "dev3", Seed: "xyz"})
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	err = api.CreateMFADevice(ctx, "leseb", MFADevice{ID: "dev3"})
+	assert.ErrorIs(t, err, errMissingMFASeed)
+
+	devices, err = api.ListMFADevices(ctx, "leseb")
+	require.NoError(t, err)
+	assert.Equal(t, []MFADevice{
+		{ID: "dev1", Seed: "3132333435", SeedType: MFASeedHex, StepSize: 30, Window: 2},
+		{ID: "dev2", Seed: "GEZDGNBV", SeedType: MFASeedBase32, StepSize: 60, Window: 1},
+	}, devices)
+
+	d, err := api.GetMFADevice(ctx, "leseb", "dev2")
+	require.NoError(t, err)
+	assert.Equal(t, 60, d.StepSize)
+	_, err = api.GetMFADevice(ctx, "leseb", "nope")
+	assert.ErrorIs(t, err, ErrNoSuchMFADevice)
+
+	err = api.ResyncMFADevice(ctx, "leseb", "dev1", "abc", "def")
+	assert.ErrorIs(t, err, errMFAPinMismatch)
+	err = api.ResyncMFADevice(ctx, "leseb", "dev1", "", "def")
+	assert.ErrorIs(t, err, errMissingMFAPin)
+
+	err = api.RemoveMFADevice(ctx, "leseb", "dev1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev2"}, userMFAIDs(t, store))
+	err = api.RemoveMFADevice(ctx, "leseb", "dev1")
+	assert.ErrorIs(t, err, ErrNoSuchMFADevice)
+
+	devices, err = api.ListMFADevices(ctx, "leseb")
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, "dev2", devices[0].ID)
+
+	_, err = api.ListMFADevices(ctx, "")
+	assert.ErrorIs(t, err, errMissingUserID)
+	err = api.RemoveMFADevice(ctx, "leseb", "")
+	assert.ErrorIs(t, err, errMissingMFADeviceID)
+}
+
+func TestMFADevicesConcurrentUpdate(t *testing.T) {
+	c, store, hooks := returnMFAMockClient()
+	api, err := New("127.0.0.1", "accessKey", "secretKey", c)
+	require.NoError(t, err)
+	ctx := context.TODO()
+
+	// the user is modified between the GET and the PUT of the update
+	concurrent := bytes.Replace(fakeUserMetadataResponse, []byte(`"ver": 3`), []byte(`"ver": 4`), 1)
+	concurrent = bytes.Replace(concurrent, []byte("This is my name"), []byte("New name"), 1)
+	hooks["user"] = func() bool {
+		delete(hooks, "user")
+		store["user"] = concurrent
+		return true
+	}
+	err = api.CreateMFADevice(ctx, "leseb", MFADevice{ID: "dev1", Seed: "3132333435"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev1"}, userMFAIDs(t, store))
+	assert.Contains(t, string(store["user"]), "New name")
+	assert.Equal(t, objVersion{Tag: "_abc", Ver: 5}, metadataVersion(store["user"]))
+
+	// the user keeps changing
+	hooks["user"] = func() bool {
+		v := metadataVersion(store["user"]).Ver
+		store["user"] = bytes.Replace(store["user"],
+			[]byte(fmt.Sprintf(`"ver":%d}`, v)), []byte(fmt.Sprintf(`"ver":%d}`, v+1)), 1)
+		return true
+	}
+	err = api.RemoveMFADevice(ctx, "leseb", "dev1")
+	assert.ErrorIs(t, err, errMetadataChanged)
+	delete(hooks, "user")
+	assert.Equal(t, []string{"dev1"}, userMFAIDs(t, store))
+}
+
+func TestMFADevicesUndo(t *testing.T) {
+	c, store, hooks := returnMFAMockClient()
+	api, err := New("127.0.0.1", "accessKey", "secretKey", c)
+	require.NoError(t, err)
+	ctx := context.TODO()
+
+	hooks["user"] = func() bool { return false }
+	err = api.CreateMFADevice(ctx, "leseb", MFADevice{ID: "dev1", Seed: "3132333435"})
+	assert.ErrorIs(t, err, ErrUnknown)
+	devices, err := api.ListMFADevices(ctx, "leseb")
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+	assert.Empty(t, userMFAIDs(t, store))
+
+	delete(hooks, "user")
+	err = api.CreateMFADevice(ctx, "leseb", MFADevice{ID: "dev1", Seed: "3132333435"})
+	require.NoError(t, err)
+	hooks["otp"] = func() bool { return false }
+	err = api.RemoveMFADevice(ctx, "leseb", "dev1")
+	assert.ErrorIs(t, err, ErrUnknown)
+	assert.Equal(t, []string{"dev1"}, userMFAIDs(t, store))
+}
+
+func TestTOTP(t *testing.T) {
+	// test vector of RFC 6238, truncated to 6 digits
+	key := []byte("12345678901234567890")
+	assert.Equal(t, "287082", totp(key, 59/30))
+	assert.Equal(t, "081804", totp(key, 1111111109/30))
+}
+
+func TestResyncOffset(t *testing.T) {
+	d := MFADevice{Seed: "3132333435363738393031323334353637383930", SeedType: MFASeedHex}
+	key, err := d.seed()
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+
+	for _, skew := range []int64{0, 600, -3600} {
+		// the clock of the device is skew seconds ahead
+		s := (now.Unix() + skew) / 30
+		ofs, err := resyncOffset(d, totp(key, s-1), totp(key, s), now)
+		require.NoError(t, err)
+		assert.Equal(t, s, (now.Unix()-ofs)/30)
+	}
+
+	d.SeedType = MFASeedBase32
+	d.Seed = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	s := now.Unix() / 30
+	ofs, err := resyncOffset(d, totp(key, s-1), totp(key, s), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), ofs)
+}
@@ -0,0 +1,246 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HTTPClientFunc is an HTTPClient implemented by a function
+type HTTPClientFunc func(req *http.Request) (*http.Response, error)
+
+// Do implements the HTTPClient interface.
+func (f HTTPClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps an HTTPClient to act on the requests sent to the RGW and
+// their responses
+type Middleware func(next HTTPClient) HTTPClient
+
+// ChainHTTPClient wraps the client with the middleware. The first middleware
+// is the outermost one, that is it sees the requests first. If client is nil
+// the default client of New is used.
+func ChainHTTPClient(client HTTPClient, middleware ...Middleware) HTTPClient {
+	if client == nil {
+		client = &http.Client{Timeout: connectionTimeout}
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		client = middleware[i](client)
+	}
+	return client
+}
+
+// NewWithMiddleware returns a client for Ceph RGW like New, sending the
+// requests through the given middleware, see ChainHTTPClient.
+func NewWithMiddleware(endpoint, accessKey, secretKey string, httpClient HTTPClient, middleware ...Middleware) (*API, error) {
+	return New(endpoint, accessKey, secretKey, ChainHTTPClient(httpClient, middleware...))
+}
+
+// Hooks are functions called for every request, for example for logging or
+// metrics. Unset hooks are not called.
+type Hooks struct {
+	// BeforeRequest is called before the request is sent
+	BeforeRequest func(req *http.Request)
+	// AfterResponse is called with the response, or the error, of the
+	// request and the time it took
+	AfterResponse func(req *http.Request, resp *http.Response, err error, elapsed time.Duration)
+}
+
+// WithHooks returns a Middleware calling the hooks for every request
+func WithHooks(hooks Hooks) Middleware {
+	return func(next HTTPClient) HTTPClient {
+		return HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			if hooks.BeforeRequest != nil {
+				hooks.BeforeRequest(req)
+			}
+			start := time.Now()
+			resp, err := next.Do(req)
+			if hooks.AfterResponse != nil {
+				hooks.AfterResponse(req, resp, err, time.Since(start))
+			}
+			return resp, err
+		})
+	}
+}
+
+// RetryPolicy determines how failed requests are retried
+type RetryPolicy struct {
+	// MaxRetries is the number of times a request is retried at most
+	MaxRetries int
+	// MinBackoff is the time waited before the first retry, it doubles for
+	// every further retry up to MaxBackoff. It defaults to 100ms.
+	MinBackoff time.Duration
+	// MaxBackoff is the longest time waited before a retry. It defaults to
+	// 10s.
+	MaxBackoff time.Duration
+	// ShouldRetry decides whether the request is retried given its response
+	// or error. If unset DefaultShouldRetry is used.
+	ShouldRetry func(req *http.Request, resp *http.Response, err error) bool
+}
+
+const (
+	defaultMinBackoff = 100 * time.Millisecond
+	defaultMaxBackoff = 10 * time.Second
+)
+
+// DefaultShouldRetry retries the GET and HEAD requests that failed with a
+// connection error, with status 429 or with a 5xx status other than 501.
+// The RGW uses PUT and DELETE for operations that are not idempotent, like
+// creating users or generating keys, which may have succeeded even though
+// the request failed. Use RetryableError in a custom ShouldRetry to retry
+// those.
+func DefaultShouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+	default:
+		return false
+	}
+	return RetryableError(resp, err)
+}
+
+// RetryableError returns true if the request failed with a connection error,
+// with status 429 or with a 5xx status other than 501, regardless of its
+// method.
+func RetryableError(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented)
+}
+
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	minBackoff, maxBackoff := p.MinBackoff, p.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultMinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	d := minBackoff
+	for i := 0; i < retry && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// WithRetry returns a Middleware retrying failed requests according to the
+// policy. The response of the last attempt is returned.
+func WithRetry(policy RetryPolicy) Middleware {
+	shouldRetry := policy.ShouldRetry
+	if shouldRetry == nil {
+		shouldRetry = DefaultShouldRetry
+	}
+	return func(next HTTPClient) HTTPClient {
+		return HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			for retry := 0; ; retry++ {
+				resp, err := next.Do(req)
+				if retry >= policy.MaxRetries || !shouldRetry(req, resp, err) {
+					return resp, err
+				}
+				// a request with a body can only be sent again if the
+				// body can be recreated
+				if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+					return resp, err
+				}
+				if resp != nil {
+					resp.Body.Close()
+				}
+				if err := sleep(req.Context(), policy.backoff(retry)); err != nil {
+					return nil, err
+				}
+				if req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					req.Body = body
+				}
+			}
+		})
+	}
+}
+
+// rateLimiter is a token bucket limiting the rate of requests
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// wait takes a token, waiting until one is available or ctx is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	var d time.Duration
+	if l.tokens < 0 {
+		d = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if d == 0 {
+		return nil
+	}
+	if err := sleep(ctx, d); err != nil {
+		// give back the token that was not used
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// WithRateLimit returns a Middleware limiting the requests to the given
+// number per second, allowing bursts of up to burst requests. Requests wait
+// until they are allowed or their context is done. A burst smaller than one
+// is treated as one.
+func WithRateLimit(requestsPerSecond float64, burst int) Middleware {
+	if burst < 1 {
+		burst = 1
+	}
+	l := &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+	return func(next HTTPClient) HTTPClient {
+		return HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			if l.rate > 0 {
+				if err := l.wait(req.Context()); err != nil {
+					return nil, err
+				}
+			}
+			return next.Do(req)
+		})
+	}
+}
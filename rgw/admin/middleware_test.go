@@ -0,0 +1,193 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingClient fails the first failures requests with the given status and
+// records the bodies of all requests
+type failingClient struct {
+	failures int
+	status   int
+	bodies   []string
+}
+
+func (c *failingClient) Do(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+	c.bodies = append(c.bodies, body)
+	status := 200
+	if len(c.bodies) <= c.failures {
+		status = c.status
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader("[]")),
+	}, nil
+}
+
+func TestChainHTTPClient(t *testing.T) {
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next HTTPClient) HTTPClient {
+			return HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+	c := &failingClient{}
+	api, err := NewWithMiddleware("127.0.0.1", "accessKey", "secretKey", c, mw("a"), mw("b"))
+	require.NoError(t, err)
+	_, err = api.ListBuckets(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, order)
+	assert.Len(t, c.bodies, 1)
+}
+
+func TestWithHooks(t *testing.T) {
+	var before, after int
+	hooks := Hooks{
+		BeforeRequest: func(req *http.Request) {
+			before++
+		},
+		AfterResponse: func(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+			after++
+			assert.NoError(t, err)
+			assert.Equal(t, 200, resp.StatusCode)
+		},
+	}
+	api, err := NewWithMiddleware("127.0.0.1", "accessKey", "secretKey", &failingClient{}, WithHooks(hooks))
+	require.NoError(t, err)
+	_, err = api.ListBuckets(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, 1, before)
+	assert.Equal(t, 1, after)
+}
+
+func TestWithRetry(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond}
+
+	t.Run("success", func(t *testing.T) {
+		c := &failingClient{failures: 2, status: http.StatusServiceUnavailable}
+		client := ChainHTTPClient(c, WithRetry(policy))
+		req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1/admin/bucket", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Len(t, c.bodies, 3)
+	})
+	t.Run("successWithBody", func(t *testing.T) {
+		c := &failingClient{failures: 2, status: http.StatusServiceUnavailable}
+		p := policy
+		p.ShouldRetry = func(req *http.Request, resp *http.Response, err error) bool {
+			return RetryableError(resp, err)
+		}
+		client := ChainHTTPClient(c, WithRetry(p))
+		req, err := http.NewRequest(http.MethodPut, "http://127.0.0.1/admin/metadata/user", bytes.NewReader([]byte("payload")))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, []string{"payload", "payload", "payload"}, c.bodies)
+	})
+	t.Run("exhausted", func(t *testing.T) {
+		c := &failingClient{failures: 10, status: http.StatusInternalServerError}
+		client := ChainHTTPClient(c, WithRetry(policy))
+		req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1/admin/bucket", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Len(t, c.bodies, 4)
+	})
+	t.Run("notRetried", func(t *testing.T) {
+		c := &failingClient{failures: 1, status: http.StatusNotFound}
+		client := ChainHTTPClient(c, WithRetry(policy))
+		req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1/admin/bucket", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		c = &failingClient{failures: 1, status: http.StatusServiceUnavailable}
+		client = ChainHTTPClient(c, WithRetry(policy))
+		req, err = http.NewRequest(http.MethodPost, "http://127.0.0.1/", nil)
+		require.NoError(t, err)
+		resp, err = client.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+		// PUT is not idempotent for all RGW operations
+		c = &failingClient{failures: 1, status: http.StatusServiceUnavailable}
+		client = ChainHTTPClient(c, WithRetry(policy))
+		req, err = http.NewRequest(http.MethodPut, "http://127.0.0.1/admin/user?key", nil)
+		require.NoError(t, err)
+		resp, err = client.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Len(t, c.bodies, 1)
+	})
+	t.Run("canceled", func(t *testing.T) {
+		c := &failingClient{failures: 10, status: http.StatusServiceUnavailable}
+		client := ChainHTTPClient(c, WithRetry(RetryPolicy{MaxRetries: 3, MinBackoff: time.Hour}))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1/admin/bucket", nil)
+		require.NoError(t, err)
+		_, err = client.Do(req)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Len(t, c.bodies, 1)
+	})
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Second, p.backoff(0))
+	assert.Equal(t, 4*time.Second, p.backoff(2))
+	assert.Equal(t, 5*time.Second, p.backoff(3))
+	assert.Equal(t, defaultMinBackoff, RetryPolicy{}.backoff(0))
+}
+
+func TestWithRateLimit(t *testing.T) {
+	c := &failingClient{}
+	client := ChainHTTPClient(c, WithRateLimit(100, 2))
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1/admin/bucket", nil)
+		require.NoError(t, err)
+		_, err = client.Do(req)
+		require.NoError(t, err)
+	}
+	// the burst is allowed at once, the other two requests wait 10ms each
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client = ChainHTTPClient(c, WithRateLimit(0.001, 1))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1/admin/bucket", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,252 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// The Admin Ops API only allows reading the multisite configuration. Realms,
+// zonegroups and zones are created and modified, and periods are committed,
+// using radosgw-admin.
+
+// Realm describes a multisite realm
+type Realm struct {
+	ID            string `json:"id" url:"id"`
+	Name          string `json:"name" url:"name"`
+	CurrentPeriod string `json:"current_period" url:"-"`
+	Epoch         int    `json:"epoch" url:"-"`
+}
+
+// RealmList contains the names of the realms and the ID of the default realm
+type RealmList struct {
+	DefaultID string   `json:"default_info"`
+	Realms    []string `json:"realms"`
+}
+
+// ZoneGroupZone describes a zone as a member of a zonegroup
+type ZoneGroupZone struct {
+	ID                   string   `json:"id"`
+	Name                 string   `json:"name"`
+	Endpoints            []string `json:"endpoints"`
+	LogMeta              bool     `json:"log_meta"`
+	LogData              bool     `json:"log_data"`
+	BucketIndexMaxShards int      `json:"bucket_index_max_shards"`
+	ReadOnly             bool     `json:"read_only"`
+	TierType             string   `json:"tier_type"`
+	SyncFromAll          bool     `json:"sync_from_all"`
+	SyncFrom             []string `json:"sync_from"`
+	RedirectZone         string   `json:"redirect_zone"`
+	SupportedFeatures    []string `json:"supported_features"`
+}
+
+// PlacementTarget describes a placement target of a zonegroup
+type PlacementTarget struct {
+	Name           string   `json:"name"`
+	Tags           []string `json:"tags"`
+	StorageClasses []string `json:"storage_classes"`
+}
+
+// ZoneGroup describes a multisite zonegroup
+type ZoneGroup struct {
+	ID                 string            `json:"id"`
+	Name               string            `json:"name"`
+	APIName            string            `json:"api_name"`
+	IsMaster           bool              `json:"is_master"`
+	Endpoints          []string          `json:"endpoints"`
+	Hostnames          []string          `json:"hostnames"`
+	HostnamesS3Website []string          `json:"hostnames_s3website"`
+	MasterZone         string            `json:"master_zone"`
+	Zones              []ZoneGroupZone   `json:"zones"`
+	PlacementTargets   []PlacementTarget `json:"placement_targets"`
+	DefaultPlacement   string            `json:"default_placement"`
+	RealmID            string            `json:"realm_id"`
+	EnabledFeatures    []string          `json:"enabled_features"` // reef+
+}
+
+// ZoneGroupMap contains the zonegroups known to the RGW and the default
+// quotas
+type ZoneGroupMap struct {
+	ZoneGroups      []ZoneGroup
+	MasterZoneGroup string
+	BucketQuota     QuotaSpec
+	UserQuota       QuotaSpec
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The RGW encodes
+// the zonegroups as a list of key/value pairs.
+func (m *ZoneGroupMap) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ZoneGroups []struct {
+			Val ZoneGroup `json:"val"`
+		} `json:"zonegroups"`
+		MasterZoneGroup string    `json:"master_zonegroup"`
+		BucketQuota     QuotaSpec `json:"bucket_quota"`
+		UserQuota       QuotaSpec `json:"user_quota"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	m.ZoneGroups = make([]ZoneGroup, 0, len(raw.ZoneGroups))
+	for _, zg := range raw.ZoneGroups {
+		m.ZoneGroups = append(m.ZoneGroups, zg.Val)
+	}
+	m.MasterZoneGroup = raw.MasterZoneGroup
+	m.BucketQuota = raw.BucketQuota
+	m.UserQuota = raw.UserQuota
+	return nil
+}
+
+// ZonePlacementPool describes the pools used by a placement target in a zone
+type ZonePlacementPool struct {
+	Key string `json:"key"`
+	Val struct {
+		IndexPool      string `json:"index_pool"`
+		StorageClasses map[string]struct {
+			DataPool string `json:"data_pool"`
+		} `json:"storage_classes"`
+		DataExtraPool string `json:"data_extra_pool"`
+		IndexType     int    `json:"index_type"`
+	} `json:"val"`
+}
+
+// Zone describes the parameters of the zone served by the RGW
+type Zone struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	DomainRoot    string `json:"domain_root"`
+	ControlPool   string `json:"control_pool"`
+	GCPool        string `json:"gc_pool"`
+	LCPool        string `json:"lc_pool"`
+	LogPool       string `json:"log_pool"`
+	IntentLogPool string `json:"intent_log_pool"`
+	UsageLogPool  string `json:"usage_log_pool"`
+	RolesPool     string `json:"roles_pool"`
+	ReshardPool   string `json:"reshard_pool"`
+	UserKeysPool  string `json:"user_keys_pool"`
+	UserEmailPool string `json:"user_email_pool"`
+	UserSwiftPool string `json:"user_swift_pool"`
+	UserUIDPool   string `json:"user_uid_pool"`
+	OTPPool       string `json:"otp_pool"`
+	NotifPool     string `json:"notif_pool"`
+	SystemKey     struct {
+		AccessKey string `json:"access_key"`
+		SecretKey string `json:"secret_key"`
+	} `json:"system_key"`
+	PlacementPools []ZonePlacementPool `json:"placement_pools"`
+	RealmID        string              `json:"realm_id"`
+}
+
+// PeriodRequest selects the period returned by GetPeriod. If ID is empty the
+// current period of the realm is returned, if Epoch is zero the latest epoch
+// of the period.
+type PeriodRequest struct {
+	ID        string `url:"period_id"`
+	Epoch     int    `url:"epoch"`
+	RealmID   string `url:"realm_id"`
+	RealmName string `url:"realm_name"`
+}
+
+// Period describes a multisite period
+type Period struct {
+	ID              string   `json:"id"`
+	Epoch           int      `json:"epoch"`
+	PredecessorUUID string   `json:"predecessor_uuid"`
+	SyncStatus      []string `json:"sync_status"`
+	PeriodMap       struct {
+		ID         string      `json:"id"`
+		ZoneGroups []ZoneGroup `json:"zonegroups"`
+	} `json:"period_map"`
+	MasterZoneGroup string `json:"master_zonegroup"`
+	MasterZone      string `json:"master_zone"`
+	PeriodConfig    struct {
+		BucketQuota QuotaSpec `json:"bucket_quota"`
+		UserQuota   QuotaSpec `json:"user_quota"`
+	} `json:"period_config"`
+	RealmID    string `json:"realm_id"`
+	RealmEpoch int    `json:"realm_epoch"`
+}
+
+// GetRealm will return the realm with the given ID or name. If neither is set
+// the default realm is returned.
+func (api *API) GetRealm(ctx context.Context, realm Realm) (Realm, error) {
+	body, err := api.call(ctx, http.MethodGet, "/realm", valueToURLParams(realm, []string{"id", "name"}))
+	if err != nil {
+		return Realm{}, err
+	}
+
+	ref := Realm{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return Realm{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref, nil
+}
+
+// ListRealms will return the names of the realms
+func (api *API) ListRealms(ctx context.Context) (RealmList, error) {
+	body, err := api.call(ctx, http.MethodGet, "/realm?list", nil)
+	if err != nil {
+		return RealmList{}, err
+	}
+
+	ref := RealmList{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return RealmList{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref, nil
+}
+
+// GetPeriod will return a period of a realm
+func (api *API) GetPeriod(ctx context.Context, period PeriodRequest) (Period, error) {
+	body, err := api.call(ctx, http.MethodGet, "/realm/period", valueToURLParams(period, []string{"period_id", "epoch", "realm_id", "realm_name"}))
+	if err != nil {
+		return Period{}, err
+	}
+
+	ref := Period{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return Period{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref, nil
+}
+
+// GetZoneGroupMap will return the zonegroups known to the RGW
+func (api *API) GetZoneGroupMap(ctx context.Context) (ZoneGroupMap, error) {
+	body, err := api.call(ctx, http.MethodGet, "/config?type=zonegroup-map", nil)
+	if err != nil {
+		return ZoneGroupMap{}, err
+	}
+
+	ref := ZoneGroupMap{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return ZoneGroupMap{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref, nil
+}
+
+// GetZone will return the parameters of the zone served by the RGW
+func (api *API) GetZone(ctx context.Context) (Zone, error) {
+	body, err := api.call(ctx, http.MethodGet, "/config?type=zone", nil)
+	if err != nil {
+		return Zone{}, err
+	}
+
+	ref := Zone{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return Zone{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref, nil
+}
@@ -0,0 +1,199 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeRealmResponse = []byte(`{
+  "id": "0f13bb55-68f5-4b33-9bc0-2db7fd8c1b55",
+  "name": "gold",
+  "current_period": "1e3e8a7a-4e4c-4a67-a0a4-5f4e0b7b9d11",
+  "epoch": 2
+}`)
+	fakeRealmListResponse = []byte(`{
+  "default_info": "0f13bb55-68f5-4b33-9bc0-2db7fd8c1b55",
+  "realms": ["gold", "silver"]
+}`)
+	fakeZoneGroup = `{
+  "id": "a3c3ab45-fd4b-4b4a-8b6c-3a4d2f8a21f0",
+  "name": "us",
+  "api_name": "us",
+  "is_master": true,
+  "endpoints": ["http://rgw1:80"],
+  "hostnames": [],
+  "hostnames_s3website": [],
+  "master_zone": "9b5e3b7c-1c63-4b39-a8a5-6a5a3a2c11f2",
+  "zones": [
+    {
+      "id": "9b5e3b7c-1c63-4b39-a8a5-6a5a3a2c11f2",
+      "name": "us-east",
+      "endpoints": ["http://rgw1:80"],
+      "log_meta": false,
+      "log_data": true,
+      "bucket_index_max_shards": 11,
+      "read_only": false,
+      "tier_type": "",
+      "sync_from_all": true,
+      "sync_from": [],
+      "redirect_zone": "",
+      "supported_features": ["compress-encrypted", "resharding"]
+    }
+  ],
+  "placement_targets": [
+    {"name": "default-placement", "tags": [], "storage_classes": ["STANDARD"]}
+  ],
+  "default_placement": "default-placement",
+  "realm_id": "0f13bb55-68f5-4b33-9bc0-2db7fd8c1b55",
+  "sync_policy": {"groups": []},
+  "enabled_features": ["resharding"]
+}`
+	fakePeriodResponse = []byte(`{
+  "id": "1e3e8a7a-4e4c-4a67-a0a4-5f4e0b7b9d11",
+  "epoch": 3,
+  "predecessor_uuid": "8d9a1b2c-0000-4000-8000-000000000000",
+  "sync_status": [],
+  "period_map": {
+    "id": "1e3e8a7a-4e4c-4a67-a0a4-5f4e0b7b9d11",
+    "zonegroups": [` + fakeZoneGroup + `],
+    "short_zone_ids": [{"key": "9b5e3b7c-1c63-4b39-a8a5-6a5a3a2c11f2", "val": 1234}]
+  },
+  "master_zonegroup": "a3c3ab45-fd4b-4b4a-8b6c-3a4d2f8a21f0",
+  "master_zone": "9b5e3b7c-1c63-4b39-a8a5-6a5a3a2c11f2",
+  "period_config": {
+    "bucket_quota": {"enabled": false, "check_on_raw": false, "max_size": -1, "max_size_kb": 0, "max_objects": -1},
+    "user_quota": {"enabled": true, "check_on_raw": false, "max_size": 1024, "max_size_kb": 1, "max_objects": -1}
+  },
+  "realm_id": "0f13bb55-68f5-4b33-9bc0-2db7fd8c1b55",
+  "realm_epoch": 2
+}`)
+	fakeZoneGroupMapResponse = []byte(`{
+  "zonegroups": [{"key": "a3c3ab45-fd4b-4b4a-8b6c-3a4d2f8a21f0", "val": ` + fakeZoneGroup + `}],
+  "master_zonegroup": "a3c3ab45-fd4b-4b4a-8b6c-3a4d2f8a21f0",
+  "bucket_quota": {"enabled": false, "check_on_raw": false, "max_size": -1, "max_size_kb": 0, "max_objects": -1},
+  "user_quota": {"enabled": false, "check_on_raw": false, "max_size": -1, "max_size_kb": 0, "max_objects": -1}
+}`)
+	fakeZoneResponse = []byte(`{
+  "id": "9b5e3b7c-1c63-4b39-a8a5-6a5a3a2c11f2",
+  "name": "us-east",
+  "domain_root": "us-east.rgw.meta:root",
+  "control_pool": "us-east.rgw.control",
+  "gc_pool": "us-east.rgw.log:gc",
+  "lc_pool": "us-east.rgw.log:lc",
+  "log_pool": "us-east.rgw.log",
+  "intent_log_pool": "us-east.rgw.log:intent",
+  "usage_log_pool": "us-east.rgw.log:usage",
+  "roles_pool": "us-east.rgw.meta:roles",
+  "reshard_pool": "us-east.rgw.log:reshard",
+  "user_keys_pool": "us-east.rgw.meta:users.keys",
+  "user_email_pool": "us-east.rgw.meta:users.email",
+  "user_swift_pool": "us-east.rgw.meta:users.swift",
+  "user_uid_pool": "us-east.rgw.meta:users.uid",
+  "otp_pool": "us-east.rgw.otp",
+  "notif_pool": "us-east.rgw.log:notif",
+  "system_key": {"access_key": "SYSKEY", "secret_key": "SYSSECRET"},
+  "placement_pools": [
+    {
+      "key": "default-placement",
+      "val": {
+        "index_pool": "us-east.rgw.buckets.index",
+        "storage_classes": {"STANDARD": {"data_pool": "us-east.rgw.buckets.data"}},
+        "data_extra_pool": "us-east.rgw.buckets.non-ec",
+        "index_type": 0
+      }
+    }
+  ],
+  "realm_id": "0f13bb55-68f5-4b33-9bc0-2db7fd8c1b55"
+}`)
+)
+
+func returnMultisiteMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodGet {
+				return nil, fmt.Errorf("unexpected method: %q", req.Method)
+			}
+			q := req.URL.Query()
+			var body []byte
+			switch {
+			case req.URL.Path == "127.0.0.1/admin/realm" && q.Has("list"):
+				body = fakeRealmListResponse
+			case req.URL.Path == "127.0.0.1/admin/realm" && q.Get("name") == "gold":
+				body = fakeRealmResponse
+			case req.URL.Path == "127.0.0.1/admin/realm/period" && q.Get("realm_name") == "gold":
+				body = fakePeriodResponse
+			case req.URL.Path == "127.0.0.1/admin/config" && q.Get("type") == "zonegroup-map":
+				body = fakeZoneGroupMapResponse
+			case req.URL.Path == "127.0.0.1/admin/config" && q.Get("type") == "zone":
+				body = fakeZoneResponse
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestMultisite(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnMultisiteMockClient())
+	require.NoError(t, err)
+
+	t.Run("getRealm", func(t *testing.T) {
+		realm, err := api.GetRealm(context.TODO(), Realm{Name: "gold"})
+		assert.NoError(t, err)
+		assert.Equal(t, "0f13bb55-68f5-4b33-9bc0-2db7fd8c1b55", realm.ID)
+		assert.Equal(t, "1e3e8a7a-4e4c-4a67-a0a4-5f4e0b7b9d11", realm.CurrentPeriod)
+		assert.Equal(t, 2, realm.Epoch)
+	})
+	t.Run("listRealms", func(t *testing.T) {
+		realms, err := api.ListRealms(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, "0f13bb55-68f5-4b33-9bc0-2db7fd8c1b55", realms.DefaultID)
+		assert.Equal(t, []string{"gold", "silver"}, realms.Realms)
+	})
+	t.Run("getPeriod", func(t *testing.T) {
+		period, err := api.GetPeriod(context.TODO(), PeriodRequest{RealmName: "gold"})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, period.Epoch)
+		assert.Equal(t, "a3c3ab45-fd4b-4b4a-8b6c-3a4d2f8a21f0", period.MasterZoneGroup)
+		require.Len(t, period.PeriodMap.ZoneGroups, 1)
+		assert.Equal(t, "us", period.PeriodMap.ZoneGroups[0].Name)
+		require.NotNil(t, period.PeriodConfig.UserQuota.Enabled)
+		assert.True(t, *period.PeriodConfig.UserQuota.Enabled)
+	})
+	t.Run("getZoneGroupMap", func(t *testing.T) {
+		m, err := api.GetZoneGroupMap(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, "a3c3ab45-fd4b-4b4a-8b6c-3a4d2f8a21f0", m.MasterZoneGroup)
+		require.Len(t, m.ZoneGroups, 1)
+		zg := m.ZoneGroups[0]
+		assert.True(t, zg.IsMaster)
+		assert.Equal(t, []string{"http://rgw1:80"}, zg.Endpoints)
+		require.Len(t, zg.Zones, 1)
+		assert.Equal(t, "us-east", zg.Zones[0].Name)
+		assert.Equal(t, 11, zg.Zones[0].BucketIndexMaxShards)
+		assert.Equal(t, []string{"STANDARD"}, zg.PlacementTargets[0].StorageClasses)
+	})
+	t.Run("getZone", func(t *testing.T) {
+		zone, err := api.GetZone(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, "us-east", zone.Name)
+		assert.Equal(t, "SYSKEY", zone.SystemKey.AccessKey)
+		require.Len(t, zone.PlacementPools, 1)
+		assert.Equal(t, "us-east.rgw.buckets.data",
+			zone.PlacementPools[0].Val.StorageClasses["STANDARD"].DataPool)
+	})
+}
@@ -0,0 +1,344 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// The Admin Ops API does not provide access to bucket notifications, so the
+// topics are managed with the SNS API of the RGW and the notifications of a
+// bucket with the S3 API.
+
+var (
+	errMissingTopicARN       = errors.New("missing topic ARN")
+	errMissingNotificationID = errors.New("missing notification ID")
+)
+
+// TopicDestination describes the endpoint notifications are pushed to.
+type TopicDestination struct {
+	PushEndpoint       string
+	PushEndpointArgs   string
+	PushEndpointTopic  string
+	StoredSecret       bool
+	Persistent         bool
+	PersistentQueue    string
+	TimeToLive         string
+	MaxRetries         string
+	RetrySleepDuration string
+}
+
+// UnmarshalJSON decodes the endpoint of a topic. The RGW returns it as an
+// object, or as a string containing the JSON document for the attributes of
+// a topic, with the flags as booleans or as strings depending on the
+// version.
+func (d *TopicDestination) UnmarshalJSON(data []byte) error {
+	var doc string
+	if json.Unmarshal(data, &doc) == nil {
+		if doc == "" {
+			*d = TopicDestination{}
+			return nil
+		}
+		data = []byte(doc)
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	str := func(key string) string {
+		if v, ok := m[key]; ok && v != nil {
+			return fmt.Sprint(v)
+		}
+		return ""
+	}
+	flag := func(key string) bool {
+		v, ok := m[key].(bool)
+		return (ok && v) || str(key) == "true"
+	}
+	*d = TopicDestination{
+		PushEndpoint:       str("EndpointAddress"),
+		PushEndpointArgs:   str("EndpointArgs"),
+		PushEndpointTopic:  str("EndpointTopic"),
+		StoredSecret:       flag("HasStoredSecret"),
+		Persistent:         flag("Persistent"),
+		PersistentQueue:    str("PersistentQueue"),
+		TimeToLive:         str("TimeToLive"),
+		MaxRetries:         str("MaxRetries"),
+		RetrySleepDuration: str("RetrySleepDuration"),
+	}
+	return nil
+}
+
+// Topic describes a bucket notification topic
+type Topic struct {
+	Name       string           `json:"Name"`
+	User       string           `json:"User"`  // reef
+	Owner      string           `json:"Owner"` // squid+
+	ARN        string           `json:"TopicArn"`
+	OpaqueData string           `json:"OpaqueData"`
+	Policy     string           `json:"Policy"`
+	Dest       TopicDestination `json:"EndPoint"`
+}
+
+// topicList decodes the topics of a ListTopics response. The XML member
+// elements of the list are rendered in JSON as either an array or an object
+// with repeated member keys.
+type topicList []Topic
+
+func (l *topicList) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		// an empty list may be rendered as an empty string or null
+		*l = nil
+		return nil
+	}
+	topics := topicList{}
+	for dec.More() {
+		if delim == '{' {
+			if _, err := dec.Token(); err != nil {
+				return err
+			}
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		m := struct {
+			Member *Topic `json:"member"`
+		}{}
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		if m.Member == nil {
+			m.Member = &Topic{}
+			if err := json.Unmarshal(raw, m.Member); err != nil {
+				return err
+			}
+		}
+		topics = append(topics, *m.Member)
+	}
+	*l = topics
+	return nil
+}
+
+// callSNS sends a request for the given action to the SNS API of the RGW.
+// Like the IAM API, it is served at the root of the endpoint.
+func (api *API) callSNS(ctx context.Context, action string, args url.Values) ([]byte, error) {
+	return api.callIAM(ctx, action, args)
+}
+
+// ListTopics will return the notification topics visible to the user of the
+// API.
+// https://docs.ceph.com/en/latest/radosgw/notifications/
+func (api *API) ListTopics(ctx context.Context) ([]Topic, error) {
+	var topics []Topic
+	token := ""
+	for {
+		args := url.Values{}
+		if token != "" {
+			args.Set("NextToken", token)
+		}
+		body, err := api.callSNS(ctx, "ListTopics", args)
+		if err != nil {
+			return nil, err
+		}
+
+		ref := struct {
+			Response struct {
+				Result struct {
+					Topics    topicList `json:"Topics"`
+					NextToken string    `json:"NextToken"`
+				} `json:"ListTopicsResult"`
+			} `json:"ListTopicsResponse"`
+		}{}
+		err = json.Unmarshal(body, &ref)
+		if err != nil {
+			return nil, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+		}
+		topics = append(topics, ref.Response.Result.Topics...)
+
+		token = ref.Response.Result.NextToken
+		if token == "" {
+			return topics, nil
+		}
+	}
+}
+
+// GetTopic will return the details of the notification topic with the ARN
+// of the given topic
+// https://docs.ceph.com/en/latest/radosgw/notifications/
+func (api *API) GetTopic(ctx context.Context, topic Topic) (Topic, error) {
+	if topic.ARN == "" {
+		return Topic{}, errMissingTopicARN
+	}
+
+	body, err := api.callSNS(ctx, "GetTopicAttributes", url.Values{"TopicArn": []string{topic.ARN}})
+	if err != nil {
+		return Topic{}, err
+	}
+
+	ref := struct {
+		Response struct {
+			Result struct {
+				Attributes []struct {
+					Key   string `json:"key"`
+					Value string `json:"value"`
+				} `json:"Attributes"`
+			} `json:"GetTopicAttributesResult"`
+		} `json:"GetTopicAttributesResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return Topic{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	attrs := map[string]string{}
+	for _, a := range ref.Response.Result.Attributes {
+		attrs[a.Key] = a.Value
+	}
+	t := Topic{
+		Name:       attrs["Name"],
+		User:       attrs["User"],
+		Owner:      attrs["Owner"],
+		ARN:        attrs["TopicArn"],
+		OpaqueData: attrs["OpaqueData"],
+		Policy:     attrs["Policy"],
+	}
+	endpoint, _ := json.Marshal(attrs["EndPoint"])
+	err = json.Unmarshal(endpoint, &t.Dest)
+	if err != nil {
+		return Topic{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return t, nil
+}
+
+// DeleteTopic will delete the notification topic with the ARN of the given
+// topic
+// https://docs.ceph.com/en/latest/radosgw/notifications/
+func (api *API) DeleteTopic(ctx context.Context, topic Topic) error {
+	if topic.ARN == "" {
+		return errMissingTopicARN
+	}
+
+	_, err := api.callSNS(ctx, "DeleteTopic", url.Values{"TopicArn": []string{topic.ARN}})
+	return err
+}
+
+// FilterRule is a rule of a bucket notification filter
+type FilterRule struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+// NotificationFilter restricts the objects a bucket notification applies to
+type NotificationFilter struct {
+	Key struct {
+		FilterRules []FilterRule `xml:"FilterRule"`
+	} `xml:"S3Key"`
+	Metadata struct {
+		FilterRules []FilterRule `xml:"FilterRule"`
+	} `xml:"S3Metadata"`
+	Tags struct {
+		FilterRules []FilterRule `xml:"FilterRule"`
+	} `xml:"S3Tags"`
+}
+
+// BucketNotification describes a notification configured on a bucket
+type BucketNotification struct {
+	Bucket   string             `xml:"-"`
+	ID       string             `xml:"Id"`
+	TopicARN string             `xml:"Topic"`
+	Events   []string           `xml:"Event"`
+	Filter   NotificationFilter `xml:"Filter"`
+}
+
+// getBucketNotifications returns the notifications of a bucket, or the one
+// with the given ID if not empty
+func (api *API) getBucketNotifications(ctx context.Context, bucket, id string) ([]BucketNotification, error) {
+	query := "notification"
+	if id != "" {
+		query += "=" + url.QueryEscape(id)
+	}
+	_, body, err := api.callS3(ctx, http.MethodGet, "/"+url.PathEscape(bucket), query)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := struct {
+		Notifications []BucketNotification `xml:"TopicConfiguration"`
+	}{}
+	err = xml.Unmarshal(body, &ref)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+	for i := range ref.Notifications {
+		ref.Notifications[i].Bucket = bucket
+	}
+
+	return ref.Notifications, nil
+}
+
+// ListBucketNotifications will return the notifications configured on a
+// bucket. The credentials of the API must grant access to the bucket, as is
+// the case for system users.
+// https://docs.ceph.com/en/latest/radosgw/s3/bucketops/
+func (api *API) ListBucketNotifications(ctx context.Context, bucket string) ([]BucketNotification, error) {
+	if bucket == "" {
+		return nil, errMissingBucket
+	}
+
+	return api.getBucketNotifications(ctx, bucket, "")
+}
+
+// GetBucketNotification will return a notification configured on a bucket.
+// The credentials of the API must grant access to the bucket, as is the
+// case for system users.
+// https://docs.ceph.com/en/latest/radosgw/s3/bucketops/
+func (api *API) GetBucketNotification(ctx context.Context, notification BucketNotification) (BucketNotification, error) {
+	if notification.Bucket == "" {
+		return BucketNotification{}, errMissingBucket
+	}
+	if notification.ID == "" {
+		return BucketNotification{}, errMissingNotificationID
+	}
+
+	notifications, err := api.getBucketNotifications(ctx, notification.Bucket, notification.ID)
+	if err != nil {
+		return BucketNotification{}, err
+	}
+	if len(notifications) == 0 {
+		return BucketNotification{}, statusError{Code: string(ErrNoSuchKey), statusCode: http.StatusNotFound}
+	}
+
+	return notifications[0], nil
+}
+
+// DeleteBucketNotification will delete a notification configured on a
+// bucket. The credentials of the API must grant access to the bucket, as is
+// the case for system users.
+// https://docs.ceph.com/en/latest/radosgw/s3/bucketops/
+func (api *API) DeleteBucketNotification(ctx context.Context, notification BucketNotification) error {
+	if notification.Bucket == "" {
+		return errMissingBucket
+	}
+	if notification.ID == "" {
+		return errMissingNotificationID
+	}
+
+	query := "notification=" + url.QueryEscape(notification.ID)
+	_, _, err := api.callS3(ctx, http.MethodDelete, "/"+url.PathEscape(notification.Bucket), query)
+	return err
+}
@@ -0,0 +1,177 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeTopicARN = "arn:aws:sns:default::mytopic"
+
+	fakeTopic = `{
+  "User": "",
+  "Name": "mytopic",
+  "EndPoint": {
+    "EndpointAddress": "http://localhost:10900",
+    "EndpointArgs": "push-endpoint=http://localhost:10900",
+    "EndpointTopic": "mytopic",
+    "HasStoredSecret": "false",
+    "Persistent": "true",
+    "TimeToLive": "None",
+    "MaxRetries": "None",
+    "RetrySleepDuration": "None"
+  },
+  "TopicArn": "arn:aws:sns:default::mytopic",
+  "OpaqueData": "",
+  "Policy": ""
+}`
+
+	fakeListTopicsResponse = []byte(fmt.Sprintf(`{"ListTopicsResponse": {"ListTopicsResult": {"Topics": [%s]}}}`, fakeTopic))
+
+	fakeGetTopicAttributesResponse = []byte(`{"GetTopicAttributesResponse": {"GetTopicAttributesResult": {"Attributes": [
+  {"key": "User", "value": ""},
+  {"key": "Name", "value": "mytopic"},
+  {"key": "EndPoint", "value": "{\"EndpointAddress\":\"http://localhost:10900\",\"EndpointArgs\":\"push-endpoint=http://localhost:10900\",\"EndpointTopic\":\"mytopic\",\"HasStoredSecret\":false,\"Persistent\":true}"},
+  {"key": "TopicArn", "value": "arn:aws:sns:default::mytopic"},
+  {"key": "OpaqueData", "value": ""},
+  {"key": "Policy", "value": ""}
+]}}}`)
+
+	fakeNotificationConfiguration = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<NotificationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <TopicConfiguration>
+    <Id>mynotif</Id>
+    <Topic>arn:aws:sns:default::mytopic</Topic>
+    <Event>s3:ObjectCreated:*</Event>
+    <Event>s3:ObjectRemoved:*</Event>
+    <Filter>
+      <S3Key>
+        <FilterRule><Name>prefix</Name><Value>images/</Value></FilterRule>
+      </S3Key>
+    </Filter>
+  </TopicConfiguration>
+</NotificationConfiguration>`)
+)
+
+func returnNotificationMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			var body []byte
+			q := req.URL.Query()
+			switch {
+			case req.Method == http.MethodPost && req.URL.Path == "127.0.0.1/" && q.Get("Action") == "ListTopics":
+				body = fakeListTopicsResponse
+			case req.Method == http.MethodPost && req.URL.Path == "127.0.0.1/" && q.Get("Action") == "GetTopicAttributes" && q.Get("TopicArn") == fakeTopicARN:
+				body = fakeGetTopicAttributesResponse
+			case req.Method == http.MethodPost && req.URL.Path == "127.0.0.1/" && q.Get("Action") == "DeleteTopic" && q.Get("TopicArn") == fakeTopicARN:
+			case req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/mybucket" && q.Has("notification") && q.Get("notification") == "":
+				body = fakeNotificationConfiguration
+			case req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/mybucket" && q.Get("notification") == "mynotif":
+				body = fakeNotificationConfiguration
+			case req.Method == http.MethodDelete && req.URL.Path == "127.0.0.1/mybucket" && q.Get("notification") == "mynotif":
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestTopicList(t *testing.T) {
+	for name, doc := range map[string]string{
+		"array":   fmt.Sprintf(`[%s, %s]`, fakeTopic, fakeTopic),
+		"members": fmt.Sprintf(`[{"member": %s}, {"member": %s}]`, fakeTopic, fakeTopic),
+		"object":  fmt.Sprintf(`{"member": %s, "member": %s}`, fakeTopic, fakeTopic),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var l topicList
+			require.NoError(t, json.Unmarshal([]byte(doc), &l))
+			require.Len(t, l, 2)
+			assert.Equal(t, "mytopic", l[1].Name)
+			assert.Equal(t, fakeTopicARN, l[1].ARN)
+			assert.True(t, l[1].Dest.Persistent)
+			assert.False(t, l[1].Dest.StoredSecret)
+		})
+	}
+	t.Run("empty", func(t *testing.T) {
+		var l topicList
+		require.NoError(t, json.Unmarshal([]byte(`""`), &l))
+		assert.Len(t, l, 0)
+	})
+}
+
+func TestTopics(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnNotificationMockClient())
+	require.NoError(t, err)
+
+	t.Run("list", func(t *testing.T) {
+		topics, err := api.ListTopics(context.TODO())
+		assert.NoError(t, err)
+		require.Len(t, topics, 1)
+		assert.Equal(t, "mytopic", topics[0].Name)
+		assert.Equal(t, "http://localhost:10900", topics[0].Dest.PushEndpoint)
+	})
+	t.Run("get", func(t *testing.T) {
+		topic, err := api.GetTopic(context.TODO(), Topic{ARN: fakeTopicARN})
+		assert.NoError(t, err)
+		assert.Equal(t, "mytopic", topic.Name)
+		assert.Equal(t, fakeTopicARN, topic.ARN)
+		assert.Equal(t, "http://localhost:10900", topic.Dest.PushEndpoint)
+		assert.Equal(t, "mytopic", topic.Dest.PushEndpointTopic)
+		assert.True(t, topic.Dest.Persistent)
+	})
+	t.Run("getMissingARN", func(t *testing.T) {
+		_, err := api.GetTopic(context.TODO(), Topic{Name: "mytopic"})
+		assert.ErrorIs(t, err, errMissingTopicARN)
+	})
+	t.Run("delete", func(t *testing.T) {
+		err := api.DeleteTopic(context.TODO(), Topic{ARN: fakeTopicARN})
+		assert.NoError(t, err)
+		err = api.DeleteTopic(context.TODO(), Topic{Name: "mytopic"})
+		assert.ErrorIs(t, err, errMissingTopicARN)
+	})
+}
+
+func TestBucketNotifications(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnNotificationMockClient())
+	require.NoError(t, err)
+
+	t.Run("list", func(t *testing.T) {
+		notifs, err := api.ListBucketNotifications(context.TODO(), "mybucket")
+		assert.NoError(t, err)
+		require.Len(t, notifs, 1)
+		assert.Equal(t, "mybucket", notifs[0].Bucket)
+		assert.Equal(t, "mynotif", notifs[0].ID)
+		_, err = api.ListBucketNotifications(context.TODO(), "")
+		assert.ErrorIs(t, err, errMissingBucket)
+	})
+	t.Run("get", func(t *testing.T) {
+		n, err := api.GetBucketNotification(context.TODO(), BucketNotification{Bucket: "mybucket", ID: "mynotif"})
+		assert.NoError(t, err)
+		assert.Equal(t, "mybucket", n.Bucket)
+		assert.Equal(t, fakeTopicARN, n.TopicARN)
+		assert.Equal(t, []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"}, n.Events)
+		assert.Equal(t, []FilterRule{{Name: "prefix", Value: "images/"}}, n.Filter.Key.FilterRules)
+		_, err = api.GetBucketNotification(context.TODO(), BucketNotification{Bucket: "mybucket"})
+		assert.ErrorIs(t, err, errMissingNotificationID)
+	})
+	t.Run("delete", func(t *testing.T) {
+		err := api.DeleteBucketNotification(context.TODO(), BucketNotification{Bucket: "mybucket", ID: "mynotif"})
+		assert.NoError(t, err)
+		err = api.DeleteBucketNotification(context.TODO(), BucketNotification{ID: "mynotif"})
+		assert.ErrorIs(t, err, errMissingBucket)
+	})
+}
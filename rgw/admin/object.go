@@ -0,0 +1,94 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errMissingObject = errors.New("missing object")
+
+// objectRequest contains the parameters of the object requests
+type objectRequest struct {
+	Bucket string `url:"bucket"`
+	Object string `url:"object"`
+}
+
+// ObjectStat contains the attributes of an object
+type ObjectStat struct {
+	Size         int64
+	ETag         string
+	LastModified time.Time
+	ContentType  string
+	VersionID    string
+	StorageClass string
+	// Metadata contains the user metadata of the object, without the
+	// x-amz-meta- prefix
+	Metadata map[string]string
+}
+
+// StatObject will return the attributes of an object. The Admin Ops API
+// provides no such call, so the S3 API is used and the credentials of the API
+// must grant read access to the object, as is the case for system users.
+func (api *API) StatObject(ctx context.Context, bucket, object string) (ObjectStat, error) {
+	if bucket == "" {
+		return ObjectStat{}, errMissingBucket
+	}
+	if object == "" {
+		return ObjectStat{}, errMissingObject
+	}
+
+	resp, _, err := api.callS3(ctx, http.MethodHead, escapeObjectPath(bucket, object), "")
+	if err != nil {
+		return ObjectStat{}, err
+	}
+
+	stat := ObjectStat{
+		ETag:         strings.Trim(resp.Header.Get("ETag"), `"`),
+		ContentType:  resp.Header.Get("Content-Type"),
+		VersionID:    resp.Header.Get("X-Amz-Version-Id"),
+		StorageClass: resp.Header.Get("X-Amz-Storage-Class"),
+		Metadata:     map[string]string{},
+	}
+	if v := resp.Header.Get("Content-Length"); v != "" {
+		stat.Size, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return ObjectStat{}, fmt.Errorf("invalid Content-Length %q: %w", v, err)
+		}
+	}
+	if v := resp.Header.Get("Last-Modified"); v != "" {
+		stat.LastModified, err = http.ParseTime(v)
+		if err != nil {
+			return ObjectStat{}, fmt.Errorf("invalid Last-Modified %q: %w", v, err)
+		}
+	}
+	const metaPrefix = "X-Amz-Meta-"
+	for k, v := range resp.Header {
+		if strings.HasPrefix(k, metaPrefix) && len(v) > 0 {
+			stat.Metadata[strings.ToLower(strings.TrimPrefix(k, metaPrefix))] = v[0]
+		}
+	}
+
+	return stat, nil
+}
+
+// RemoveObject will remove an object from a bucket
+// https://docs.ceph.com/en/latest/radosgw/adminops/#remove-object
+func (api *API) RemoveObject(ctx context.Context, bucket, object string) error {
+	if bucket == "" {
+		return errMissingBucket
+	}
+	if object == "" {
+		return errMissingObject
+	}
+
+	req := objectRequest{Bucket: bucket, Object: object}
+	_, err := api.call(ctx, http.MethodDelete, "/bucket?object", valueToURLParams(req, []string{"bucket", "object"}))
+	return err
+}
@@ -0,0 +1,94 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func returnObjectMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			resp := &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+			}
+			switch {
+			case req.Method == http.MethodHead && req.URL.EscapedPath() == "127.0.0.1/mybucket/dir/my%20object":
+				resp.Header.Set("Content-Length", "1234")
+				resp.Header.Set("ETag", `"d41d8cd98f00b204e9800998ecf8427e"`)
+				resp.Header.Set("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+				resp.Header.Set("Content-Type", "text/plain")
+				resp.Header.Set("X-Amz-Storage-Class", "STANDARD")
+				resp.Header.Set("X-Amz-Meta-Owner", "alice")
+			case req.Method == http.MethodHead && req.URL.Path == "127.0.0.1/mybucket/missing":
+				resp.StatusCode = http.StatusNotFound
+			case req.Method == http.MethodHead && req.URL.Path == "127.0.0.1/private/obj":
+				resp.StatusCode = http.StatusForbidden
+			case req.Method == http.MethodDelete && req.URL.Path == "127.0.0.1/admin/bucket" &&
+				req.URL.Query().Get("bucket") == "mybucket" && hasQueryValue(req, "object", "dir/my object"):
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return resp, nil
+		},
+	}
+}
+
+func hasQueryValue(req *http.Request, key, value string) bool {
+	for _, v := range req.URL.Query()[key] {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestStatObject(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnObjectMockClient())
+	require.NoError(t, err)
+
+	stat, err := api.StatObject(context.TODO(), "mybucket", "dir/my object")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1234, stat.Size)
+	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", stat.ETag)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), stat.LastModified)
+	assert.Equal(t, "text/plain", stat.ContentType)
+	assert.Equal(t, "STANDARD", stat.StorageClass)
+	assert.Equal(t, map[string]string{"owner": "alice"}, stat.Metadata)
+
+	_, err = api.StatObject(context.TODO(), "mybucket", "missing")
+	assert.ErrorIs(t, err, ErrNoSuchObject)
+	_, err = api.StatObject(context.TODO(), "private", "obj")
+	assert.ErrorIs(t, err, ErrAccessDenied)
+	_, err = api.StatObject(context.TODO(), "", "obj")
+	assert.ErrorIs(t, err, errMissingBucket)
+	_, err = api.StatObject(context.TODO(), "mybucket", "")
+	assert.ErrorIs(t, err, errMissingObject)
+}
+
+func TestRemoveObject(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnObjectMockClient())
+	require.NoError(t, err)
+
+	err = api.RemoveObject(context.TODO(), "mybucket", "dir/my object")
+	assert.NoError(t, err)
+	err = api.RemoveObject(context.TODO(), "", "obj")
+	assert.ErrorIs(t, err, errMissingBucket)
+	err = api.RemoveObject(context.TODO(), "mybucket", "")
+	assert.ErrorIs(t, err, errMissingObject)
+}
+
+func TestEscapeObjectPath(t *testing.T) {
+	assert.Equal(t, "/b/a/b%20c/d%3Fe", escapeObjectPath("b", "a/b c/d?e"))
+}
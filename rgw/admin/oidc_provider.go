@@ -0,0 +1,145 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+var (
+	errMissingOIDCURL        = errors.New("missing OpenID Connect provider URL")
+	errMissingOIDCThumbprint = errors.New("missing OpenID Connect provider thumbprint")
+	errMissingOIDCARN        = errors.New("missing OpenID Connect provider ARN")
+)
+
+// OIDCProvider describes an OpenID Connect identity provider whose users can
+// assume roles using STS AssumeRoleWithWebIdentity
+type OIDCProvider struct {
+	// ARN is set by the RGW when the provider is created
+	ARN         string   `json:"-"`
+	URL         string   `json:"Url"`
+	ClientIDs   []string `json:"ClientIDList"`
+	Thumbprints []string `json:"ThumbprintList"`
+	CreateDate  string   `json:"CreateDate"`
+}
+
+// addMemberList adds the values to args as a list parameter of the IAM API
+// in the form name.member.N.
+func addMemberList(args url.Values, name string, values []string) {
+	for i, v := range values {
+		args.Add(name+".member."+strconv.Itoa(i+1), v)
+	}
+}
+
+// CreateOIDCProvider will register an OpenID Connect provider and return its
+// ARN. At least one thumbprint of the certificate of the provider is required.
+// https://docs.ceph.com/en/latest/radosgw/oidc/#create-openid-connect-provider
+func (api *API) CreateOIDCProvider(ctx context.Context, provider OIDCProvider) (string, error) {
+	if provider.URL == "" {
+		return "", errMissingOIDCURL
+	}
+	if len(provider.Thumbprints) == 0 {
+		return "", errMissingOIDCThumbprint
+	}
+
+	args := url.Values{}
+	args.Add("Url", provider.URL)
+	addMemberList(args, "ClientIDList", provider.ClientIDs)
+	addMemberList(args, "ThumbprintList", provider.Thumbprints)
+	body, err := api.callIAM(ctx, "CreateOpenIDConnectProvider", args)
+	if err != nil {
+		return "", err
+	}
+
+	ref := struct {
+		Response struct {
+			Result struct {
+				ARN string `json:"OpenIDConnectProviderArn"`
+			} `json:"CreateOpenIDConnectProviderResult"`
+		} `json:"CreateOpenIDConnectProviderResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return "", fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref.Response.Result.ARN, nil
+}
+
+// GetOIDCProvider will return the details of the OpenID Connect provider with
+// the given ARN
+// https://docs.ceph.com/en/latest/radosgw/oidc/#get-openid-connect-provider
+func (api *API) GetOIDCProvider(ctx context.Context, arn string) (OIDCProvider, error) {
+	if arn == "" {
+		return OIDCProvider{}, errMissingOIDCARN
+	}
+
+	args := url.Values{}
+	args.Add("OpenIDConnectProviderArn", arn)
+	body, err := api.callIAM(ctx, "GetOpenIDConnectProvider", args)
+	if err != nil {
+		return OIDCProvider{}, err
+	}
+
+	ref := struct {
+		Response struct {
+			Result OIDCProvider `json:"GetOpenIDConnectProviderResult"`
+		} `json:"GetOpenIDConnectProviderResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return OIDCProvider{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+	ref.Response.Result.ARN = arn
+
+	return ref.Response.Result, nil
+}
+
+// ListOIDCProviders will return the ARNs of the registered OpenID Connect
+// providers
+// https://docs.ceph.com/en/latest/radosgw/oidc/#list-openid-connect-providers
+func (api *API) ListOIDCProviders(ctx context.Context) ([]string, error) {
+	body, err := api.callIAM(ctx, "ListOpenIDConnectProviders", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	ref := struct {
+		Response struct {
+			Result struct {
+				Providers []struct {
+					ARN string `json:"Arn"`
+				} `json:"OpenIDConnectProviderList"`
+			} `json:"ListOpenIDConnectProvidersResult"`
+		} `json:"ListOpenIDConnectProvidersResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	arns := make([]string, 0, len(ref.Response.Result.Providers))
+	for _, p := range ref.Response.Result.Providers {
+		arns = append(arns, p.ARN)
+	}
+	return arns, nil
+}
+
+// DeleteOIDCProvider will remove the OpenID Connect provider with the given
+// ARN
+// https://docs.ceph.com/en/latest/radosgw/oidc/#delete-openid-connect-provider
+func (api *API) DeleteOIDCProvider(ctx context.Context, arn string) error {
+	if arn == "" {
+		return errMissingOIDCARN
+	}
+
+	args := url.Values{}
+	args.Add("OpenIDConnectProviderArn", arn)
+	_, err := api.callIAM(ctx, "DeleteOpenIDConnectProvider", args)
+	return err
+}
@@ -0,0 +1,119 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeOIDCProviderARN = "arn:aws:iam:::oidc-provider/localhost:8080/auth/realms/quickstart"
+
+var (
+	fakeCreateOIDCProviderResponse = []byte(`{
+  "CreateOpenIDConnectProviderResponse": {
+    "CreateOpenIDConnectProviderResult": {
+      "OpenIDConnectProviderArn": "` + fakeOIDCProviderARN + `"
+    }
+  }
+}`)
+	fakeGetOIDCProviderResponse = []byte(`{
+  "GetOpenIDConnectProviderResponse": {
+    "GetOpenIDConnectProviderResult": {
+      "ClientIDList": ["app-profile-jsp"],
+      "CreateDate": "2024-01-01T00:00:00.000Z",
+      "ThumbprintList": ["F7D7B3515DD0D319DD219A43A9EA727AD6065287"],
+      "Url": "localhost:8080/auth/realms/quickstart"
+    }
+  }
+}`)
+	fakeListOIDCProvidersResponse = []byte(`{
+  "ListOpenIDConnectProvidersResponse": {
+    "ListOpenIDConnectProvidersResult": {
+      "OpenIDConnectProviderList": [{"Arn": "` + fakeOIDCProviderARN + `"}]
+    }
+  }
+}`)
+)
+
+func returnOIDCProviderMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.Method != http.MethodPost || q.Get("format") != "json" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			var body []byte
+			switch q.Get("Action") {
+			case "CreateOpenIDConnectProvider":
+				if q.Get("Url") == "" || q.Get("ClientIDList.member.1") != "app-profile-jsp" ||
+					q.Get("ThumbprintList.member.1") == "" || q.Get("ThumbprintList.member.2") == "" {
+					return nil, fmt.Errorf("bad create request: %q", req.URL.RawQuery)
+				}
+				body = fakeCreateOIDCProviderResponse
+			case "GetOpenIDConnectProvider":
+				body = fakeGetOIDCProviderResponse
+			case "ListOpenIDConnectProviders":
+				body = fakeListOIDCProvidersResponse
+			case "DeleteOpenIDConnectProvider":
+				if q.Get("OpenIDConnectProviderArn") != fakeOIDCProviderARN {
+					return nil, fmt.Errorf("bad delete request: %q", req.URL.RawQuery)
+				}
+			default:
+				return nil, fmt.Errorf("unexpected action: %q", q.Get("Action"))
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestOIDCProviders(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnOIDCProviderMockClient())
+	require.NoError(t, err)
+
+	t.Run("create", func(t *testing.T) {
+		_, err := api.CreateOIDCProvider(context.TODO(), OIDCProvider{Thumbprints: []string{"abc"}})
+		assert.ErrorIs(t, err, errMissingOIDCURL)
+		_, err = api.CreateOIDCProvider(context.TODO(), OIDCProvider{URL: "http://localhost:8080"})
+		assert.ErrorIs(t, err, errMissingOIDCThumbprint)
+
+		arn, err := api.CreateOIDCProvider(context.TODO(), OIDCProvider{
+			URL:         "http://localhost:8080/auth/realms/quickstart",
+			ClientIDs:   []string{"app-profile-jsp"},
+			Thumbprints: []string{"F7D7B3515DD0D319DD219A43A9EA727AD6065287", "0123456789ABCDEF"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, fakeOIDCProviderARN, arn)
+	})
+	t.Run("get", func(t *testing.T) {
+		p, err := api.GetOIDCProvider(context.TODO(), fakeOIDCProviderARN)
+		assert.NoError(t, err)
+		assert.Equal(t, fakeOIDCProviderARN, p.ARN)
+		assert.Equal(t, "localhost:8080/auth/realms/quickstart", p.URL)
+		assert.Equal(t, []string{"app-profile-jsp"}, p.ClientIDs)
+		assert.Equal(t, []string{"F7D7B3515DD0D319DD219A43A9EA727AD6065287"}, p.Thumbprints)
+		_, err = api.GetOIDCProvider(context.TODO(), "")
+		assert.ErrorIs(t, err, errMissingOIDCARN)
+	})
+	t.Run("list", func(t *testing.T) {
+		arns, err := api.ListOIDCProviders(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{fakeOIDCProviderARN}, arns)
+	})
+	t.Run("delete", func(t *testing.T) {
+		err := api.DeleteOIDCProvider(context.TODO(), fakeOIDCProviderARN)
+		assert.NoError(t, err)
+		err = api.DeleteOIDCProvider(context.TODO(), "")
+		assert.ErrorIs(t, err, errMissingOIDCARN)
+	})
+}
@@ -0,0 +1,117 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// defaultPageSize is the number of keys listed per page if no size is given,
+// the default of the RGW
+const defaultPageSize = 1000
+
+// ListOptions select the page of a listing
+type ListOptions struct {
+	// Marker is the marker of the previous page, it is empty for the first
+	// page
+	Marker string `url:"marker"`
+	// MaxEntries is the maximum number of keys of the page, the RGW default
+	// of 1000 is used if unset
+	MaxEntries *int `url:"max-entries"`
+}
+
+// ListPage is a page of a listing
+type ListPage struct {
+	Keys      []string `json:"keys"`
+	Truncated bool     `json:"truncated"`
+	Count     int      `json:"count"`
+	// Marker is the marker to pass to get the next page, it is set if the
+	// listing is truncated
+	Marker string `json:"marker"`
+}
+
+// listMetadataPage lists a page of the keys of a metadata section. The RGW
+// returns a page rather than all the keys when max-entries is passed.
+func (api *API) listMetadataPage(ctx context.Context, section string, opts ListOptions) (ListPage, error) {
+	if opts.MaxEntries == nil {
+		n := defaultPageSize
+		opts.MaxEntries = &n
+	}
+
+	body, err := api.call(ctx, http.MethodGet, "/metadata/"+section, valueToURLParams(opts, []string{"marker", "max-entries"}))
+	if err != nil {
+		return ListPage{}, err
+	}
+
+	ref := ListPage{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return ListPage{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref, nil
+}
+
+// walkMetadata calls fn with the keys of a metadata section, retrieving
+// pageSize keys at a time
+func (api *API) walkMetadata(ctx context.Context, section string, pageSize int, fn func(key string) error) error {
+	opts := ListOptions{}
+	if pageSize > 0 {
+		opts.MaxEntries = &pageSize
+	}
+	for {
+		page, err := api.listMetadataPage(ctx, section, opts)
+		if err != nil {
+			return err
+		}
+		for _, key := range page.Keys {
+			if err := fn(key); err != nil {
+				return err
+			}
+		}
+		if !page.Truncated || page.Marker == "" {
+			return nil
+		}
+		opts.Marker = page.Marker
+	}
+}
+
+// ListUsersPage will return a page of the IDs of the users
+func (api *API) ListUsersPage(ctx context.Context, opts ListOptions) (ListPage, error) {
+	return api.listMetadataPage(ctx, "user", opts)
+}
+
+// ListBucketsPage will return a page of the names of the buckets. The names
+// of buckets of tenants are prefixed with the tenant, as in "tenant/bucket".
+func (api *API) ListBucketsPage(ctx context.Context, opts ListOptions) (ListPage, error) {
+	return api.listMetadataPage(ctx, "bucket", opts)
+}
+
+// ListAccountsPage will return a page of the IDs of the RGW accounts
+func (api *API) ListAccountsPage(ctx context.Context, opts ListOptions) (ListPage, error) {
+	return api.listMetadataPage(ctx, "account", opts)
+}
+
+// WalkUsers will call fn with the ID of every user, listing pageSize users at
+// a time. If pageSize is zero the RGW default is used. Walking stops at the
+// first error returned by fn, which is returned.
+func (api *API) WalkUsers(ctx context.Context, pageSize int, fn func(uid string) error) error {
+	return api.walkMetadata(ctx, "user", pageSize, fn)
+}
+
+// WalkBuckets will call fn with the name of every bucket, listing pageSize
+// buckets at a time. If pageSize is zero the RGW default is used. Walking
+// stops at the first error returned by fn, which is returned.
+func (api *API) WalkBuckets(ctx context.Context, pageSize int, fn func(bucket string) error) error {
+	return api.walkMetadata(ctx, "bucket", pageSize, fn)
+}
+
+// WalkAccounts will call fn with the ID of every RGW account, listing
+// pageSize accounts at a time. If pageSize is zero the RGW default is used.
+// Walking stops at the first error returned by fn, which is returned.
+func (api *API) WalkAccounts(ctx context.Context, pageSize int, fn func(accountID string) error) error {
+	return api.walkMetadata(ctx, "account", pageSize, fn)
+}
@@ -0,0 +1,99 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// returnPagedMetadataMockClient lists the given keys of the user and bucket
+// metadata sections by pages, using the index of the next key as marker
+func returnPagedMetadataMockClient(keys []string) *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.Method != http.MethodGet || (req.URL.Path != "127.0.0.1/admin/metadata/user" && req.URL.Path != "127.0.0.1/admin/metadata/bucket") {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			maxEntries, err := strconv.Atoi(q.Get("max-entries"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid max-entries: %q", req.URL.RawQuery)
+			}
+			start := 0
+			if m := q.Get("marker"); m != "" {
+				if start, err = strconv.Atoi(m); err != nil {
+					return nil, fmt.Errorf("invalid marker: %q", req.URL.RawQuery)
+				}
+			}
+			end := start + maxEntries
+			page := ListPage{Keys: keys[start:], Count: len(keys) - start}
+			if end < len(keys) {
+				page = ListPage{Keys: keys[start:end], Count: maxEntries, Truncated: true, Marker: strconv.Itoa(end)}
+			}
+			body, err := json.Marshal(page)
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestPagination(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e"}
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnPagedMetadataMockClient(keys))
+	require.NoError(t, err)
+	ctx := context.TODO()
+
+	t.Run("page", func(t *testing.T) {
+		two := 2
+		page, err := api.ListUsersPage(ctx, ListOptions{MaxEntries: &two})
+		require.NoError(t, err)
+		assert.Equal(t, ListPage{Keys: []string{"a", "b"}, Count: 2, Truncated: true, Marker: "2"}, page)
+
+		page, err = api.ListUsersPage(ctx, ListOptions{MaxEntries: &two, Marker: "4"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"e"}, page.Keys)
+		assert.False(t, page.Truncated)
+
+		page, err = api.ListBucketsPage(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, keys, page.Keys)
+	})
+	t.Run("walk", func(t *testing.T) {
+		var walked []string
+		err := api.WalkBuckets(ctx, 2, func(bucket string) error {
+			walked = append(walked, bucket)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, keys, walked)
+	})
+	t.Run("walkStop", func(t *testing.T) {
+		errStop := errors.New("stop")
+		var walked []string
+		err := api.WalkUsers(ctx, 2, func(uid string) error {
+			if uid == "c" {
+				return errStop
+			}
+			walked = append(walked, uid)
+			return nil
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, []string{"a", "b"}, walked)
+	})
+}
@@ -0,0 +1,128 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// The placement targets and storage classes are part of the zonegroup and
+// zone configuration, which the Admin Ops API only allows reading. They are
+// added and modified using radosgw-admin zonegroup placement and zone
+// placement.
+
+// ErrNoSuchPlacementTarget - Placement target does not exist in the zone
+const ErrNoSuchPlacementTarget errorReason = "NoSuchPlacementTarget"
+
+var errZoneGroupNotFound = errors.New("zonegroup of the zone not found")
+
+// StorageClassPlacement describes where a storage class of a placement target
+// stores its data
+type StorageClassPlacement struct {
+	Name     string
+	DataPool string
+}
+
+// Placement describes a placement target as configured in both the
+// zonegroup and the zone served by the RGW
+type Placement struct {
+	Name string
+	// Tags restrict the users allowed to use the placement target
+	Tags []string
+	// Default is set for the default placement target of the zonegroup
+	Default       bool
+	IndexPool     string
+	DataExtraPool string
+	// StorageClasses are sorted by name. The data pool is empty for the
+	// storage classes the zone has no pool for.
+	StorageClasses []StorageClassPlacement
+}
+
+// zoneGroupOf returns the zonegroup the zone is a member of
+func zoneGroupOf(m ZoneGroupMap, zoneID string) (ZoneGroup, bool) {
+	for _, zg := range m.ZoneGroups {
+		for _, z := range zg.Zones {
+			if z.ID == zoneID {
+				return zg, true
+			}
+		}
+	}
+	return ZoneGroup{}, false
+}
+
+// mergePlacement combines the placement targets of the zonegroup with the
+// placement pools of the zone
+func mergePlacement(zg ZoneGroup, zone Zone) []Placement {
+	pools := make(map[string]ZonePlacementPool, len(zone.PlacementPools))
+	for _, p := range zone.PlacementPools {
+		pools[p.Key] = p
+	}
+
+	placements := make([]Placement, 0, len(zg.PlacementTargets))
+	for _, t := range zg.PlacementTargets {
+		pool := pools[t.Name]
+		p := Placement{
+			Name:          t.Name,
+			Tags:          t.Tags,
+			Default:       t.Name == zg.DefaultPlacement,
+			IndexPool:     pool.Val.IndexPool,
+			DataExtraPool: pool.Val.DataExtraPool,
+		}
+		classes := map[string]bool{}
+		for _, c := range t.StorageClasses {
+			classes[c] = true
+		}
+		for c := range pool.Val.StorageClasses {
+			classes[c] = true
+		}
+		for c := range classes {
+			p.StorageClasses = append(p.StorageClasses, StorageClassPlacement{
+				Name:     c,
+				DataPool: pool.Val.StorageClasses[c].DataPool,
+			})
+		}
+		sort.Slice(p.StorageClasses, func(i, j int) bool {
+			return p.StorageClasses[i].Name < p.StorageClasses[j].Name
+		})
+		placements = append(placements, p)
+	}
+	sort.Slice(placements, func(i, j int) bool {
+		return placements[i].Name < placements[j].Name
+	})
+	return placements
+}
+
+// ListPlacementTargets will return the placement targets of the zone served
+// by the RGW, sorted by name, along with their storage classes and pools
+func (api *API) ListPlacementTargets(ctx context.Context) ([]Placement, error) {
+	zone, err := api.GetZone(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m, err := api.GetZoneGroupMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zg, ok := zoneGroupOf(m, zone.ID)
+	if !ok {
+		return nil, errZoneGroupNotFound
+	}
+	return mergePlacement(zg, zone), nil
+}
+
+// GetPlacementTarget will return a placement target of the zone served by
+// the RGW
+func (api *API) GetPlacementTarget(ctx context.Context, name string) (Placement, error) {
+	placements, err := api.ListPlacementTargets(ctx)
+	if err != nil {
+		return Placement{}, err
+	}
+	for _, p := range placements {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Placement{}, ErrNoSuchPlacementTarget
+}
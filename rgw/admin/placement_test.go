@@ -0,0 +1,70 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPlacementTargets(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnMultisiteMockClient())
+	require.NoError(t, err)
+
+	placements, err := api.ListPlacementTargets(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, []Placement{{
+		Name:          "default-placement",
+		Tags:          []string{},
+		Default:       true,
+		IndexPool:     "us-east.rgw.buckets.index",
+		DataExtraPool: "us-east.rgw.buckets.non-ec",
+		StorageClasses: []StorageClassPlacement{
+			{Name: "STANDARD", DataPool: "us-east.rgw.buckets.data"},
+		},
+	}}, placements)
+
+	p, err := api.GetPlacementTarget(context.TODO(), "default-placement")
+	require.NoError(t, err)
+	assert.True(t, p.Default)
+	_, err = api.GetPlacementTarget(context.TODO(), "cold-placement")
+	assert.ErrorIs(t, err, ErrNoSuchPlacementTarget)
+}
+
+func TestMergePlacement(t *testing.T) {
+	zg := ZoneGroup{
+		DefaultPlacement: "default-placement",
+		PlacementTargets: []PlacementTarget{
+			{Name: "default-placement", StorageClasses: []string{"STANDARD", "COLD", "GLACIER"}},
+			{Name: "archive", Tags: []string{"archive"}, StorageClasses: []string{"STANDARD"}},
+		},
+	}
+	zone := Zone{}
+	err := json.Unmarshal([]byte(`{"placement_pools": [{
+  "key": "default-placement",
+  "val": {
+    "index_pool": "idx",
+    "storage_classes": {"STANDARD": {"data_pool": "data"}, "COLD": {"data_pool": "data.ec"}},
+    "data_extra_pool": "extra"
+  }
+}]}`), &zone)
+	require.NoError(t, err)
+
+	placements := mergePlacement(zg, zone)
+	require.Len(t, placements, 2)
+	assert.Equal(t, "archive", placements[0].Name)
+	assert.False(t, placements[0].Default)
+	assert.Equal(t, "", placements[0].IndexPool)
+	assert.Equal(t, []StorageClassPlacement{{Name: "STANDARD"}}, placements[0].StorageClasses)
+	assert.Equal(t, "default-placement", placements[1].Name)
+	assert.True(t, placements[1].Default)
+	assert.Equal(t, []StorageClassPlacement{
+		{Name: "COLD", DataPool: "data.ec"},
+		{Name: "GLACIER"},
+		{Name: "STANDARD", DataPool: "data"},
+	}, placements[1].StorageClasses)
+}
@@ -0,0 +1,151 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// Grantee types of an ACL grant
+const (
+	GranteeTypeUser    = "CanonicalUser"
+	GranteeTypeEmail   = "AmazonCustomerByEmail"
+	GranteeTypeGroup   = "Group"
+	GranteeTypeReferer = "Referer"
+	GranteeTypeUnknown = "Unknown"
+)
+
+// Permissions of an ACL grant
+const (
+	PermissionRead        = "READ"
+	PermissionWrite       = "WRITE"
+	PermissionReadACP     = "READ_ACP"
+	PermissionWriteACP    = "WRITE_ACP"
+	PermissionFullControl = "FULL_CONTROL"
+)
+
+// Groups that can be granted permissions
+const (
+	GroupAllUsers           = "AllUsers"
+	GroupAuthenticatedUsers = "AuthenticatedUsers"
+)
+
+// permission flags as encoded by the RGW
+const (
+	permRead        = 0x01
+	permWrite       = 0x02
+	permReadACP     = 0x04
+	permWriteACP    = 0x08
+	permFullControl = permRead | permWrite | permReadACP | permWriteACP
+)
+
+// Grant summarizes a grant of an ACL
+type Grant struct {
+	// GranteeType is one of the GranteeType constants
+	GranteeType string
+	// Grantee is the user ID, email address, group or referer the grant
+	// applies to
+	Grantee string
+	// Permissions are the permissions granted, PermissionFullControl or
+	// one or more of the other Permission constants
+	Permissions []string
+}
+
+func permissionNames(flags int) []string {
+	if flags&permFullControl == permFullControl {
+		return []string{PermissionFullControl}
+	}
+	perms := []string{}
+	for _, p := range []struct {
+		flag int
+		name string
+	}{
+		{permRead, PermissionRead},
+		{permWrite, PermissionWrite},
+		{permReadACP, PermissionReadACP},
+		{permWriteACP, PermissionWriteACP},
+	} {
+		if flags&p.flag != 0 {
+			perms = append(perms, p.name)
+		}
+	}
+	return perms
+}
+
+// Grants returns a summary of the grants of the ACL of the policy, sorted by
+// grantee type and grantee
+func (p Policy) Grants() []Grant {
+	grants := []Grant{}
+	for _, g := range p.ACL.GrantMap {
+		grant := Grant{Permissions: permissionNames(g.Grant.Permission.Flags)}
+		switch g.Grant.Type.Type {
+		case 0:
+			grant.GranteeType = GranteeTypeUser
+			grant.Grantee = g.Grant.ID
+		case 1:
+			grant.GranteeType = GranteeTypeEmail
+			grant.Grantee = g.Grant.Email
+		case 2:
+			grant.GranteeType = GranteeTypeGroup
+			if g.Grant.Group != nil {
+				switch *g.Grant.Group {
+				case 1:
+					grant.Grantee = GroupAllUsers
+				case 2:
+					grant.Grantee = GroupAuthenticatedUsers
+				}
+			}
+		case 4:
+			grant.GranteeType = GranteeTypeReferer
+			grant.Grantee = g.Grant.URLSpec
+		default:
+			grant.GranteeType = GranteeTypeUnknown
+			grant.Grantee = g.ID
+		}
+		grants = append(grants, grant)
+	}
+	sort.SliceStable(grants, func(i, j int) bool {
+		if grants[i].GranteeType != grants[j].GranteeType {
+			return grants[i].GranteeType < grants[j].GranteeType
+		}
+		return grants[i].Grantee < grants[j].Grantee
+	})
+	return grants
+}
+
+// GetRawPolicy will return the policy of a bucket, or of an object of the
+// bucket if object is not empty, as the JSON document returned by the RGW
+// https://docs.ceph.com/en/latest/radosgw/adminops/#get-bucket-or-object-policy
+func (api *API) GetRawPolicy(ctx context.Context, bucket, object string) ([]byte, error) {
+	if bucket == "" {
+		return nil, errMissingBucket
+	}
+
+	req := objectRequest{Bucket: bucket, Object: object}
+	return api.call(ctx, http.MethodGet, "/bucket?policy", valueToURLParams(req, []string{"bucket", "object"}))
+}
+
+// GetObjectPolicy will return the policy of an object
+// https://docs.ceph.com/en/latest/radosgw/adminops/#get-bucket-or-object-policy
+func (api *API) GetObjectPolicy(ctx context.Context, bucket, object string) (Policy, error) {
+	if object == "" {
+		return Policy{}, errMissingObject
+	}
+
+	body, err := api.GetRawPolicy(ctx, bucket, object)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	ref := Policy{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return Policy{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref, nil
+}
@@ -0,0 +1,107 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fakePolicyResponse = []byte(`{
+  "acl": {
+    "acl_user_map": [{"user": "alice", "acl": 15}],
+    "acl_group_map": [{"group": 1, "acl": 1}],
+    "grant_map": [
+      {
+        "id": "alice",
+        "grant": {
+          "type": {"type": 0},
+          "id": "alice",
+          "email": "",
+          "permission": {"flags": 15},
+          "name": "Alice",
+          "group": 0,
+          "url_spec": ""
+        }
+      },
+      {
+        "id": "",
+        "grant": {
+          "type": {"type": 2},
+          "id": "",
+          "email": "",
+          "permission": {"flags": 1},
+          "name": "",
+          "group": 1,
+          "url_spec": ""
+        }
+      },
+      {
+        "id": "bob@example.com",
+        "grant": {
+          "type": {"type": 1},
+          "id": "",
+          "email": "bob@example.com",
+          "permission": {"flags": 6},
+          "name": "",
+          "group": 0,
+          "url_spec": ""
+        }
+      }
+    ]
+  },
+  "owner": {"id": "alice", "display_name": "Alice"}
+}`)
+
+func returnPolicyMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/admin/bucket" &&
+				q.Get("bucket") == "mybucket" && hasQueryValue(req, "policy", "") {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewReader(fakePolicyResponse)),
+				}, nil
+			}
+			return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+		},
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnPolicyMockClient())
+	require.NoError(t, err)
+
+	t.Run("raw", func(t *testing.T) {
+		raw, err := api.GetRawPolicy(context.TODO(), "mybucket", "")
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(fakePolicyResponse), string(raw))
+		_, err = api.GetRawPolicy(context.TODO(), "", "obj")
+		assert.ErrorIs(t, err, errMissingBucket)
+	})
+	t.Run("object", func(t *testing.T) {
+		p, err := api.GetObjectPolicy(context.TODO(), "mybucket", "obj")
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", p.Owner.ID)
+		_, err = api.GetObjectPolicy(context.TODO(), "mybucket", "")
+		assert.ErrorIs(t, err, errMissingObject)
+	})
+	t.Run("grants", func(t *testing.T) {
+		p, err := api.GetObjectPolicy(context.TODO(), "mybucket", "obj")
+		require.NoError(t, err)
+		assert.Equal(t, []Grant{
+			{GranteeType: GranteeTypeEmail, Grantee: "bob@example.com", Permissions: []string{PermissionWrite, PermissionReadACP}},
+			{GranteeType: GranteeTypeUser, Grantee: "alice", Permissions: []string{PermissionFullControl}},
+			{GranteeType: GranteeTypeGroup, Grantee: GroupAllUsers, Permissions: []string{PermissionRead}},
+		}, p.Grants())
+		assert.Empty(t, Policy{}.Grants())
+	})
+}
@@ -0,0 +1,60 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// maxPresignExpiry is the longest validity of a presigned request allowed by
+// SigV4
+const maxPresignExpiry = 7 * 24 * time.Hour
+
+var errInvalidPresignExpiry = errors.New("presign expiry must be positive and at most 7 days")
+
+// SetSigningRegion sets the region the requests are signed for, for RGWs
+// configured with a region other than "default". It must not be called
+// while requests are sent.
+func (api *API) SetSigningRegion(region string) {
+	api.region = region
+}
+
+// SetSigningService sets the service name the requests are signed for,
+// "s3" by default, as may be required by proxies in front of the RGW. It
+// must not be called while requests are sent.
+func (api *API) SetSigningService(service string) {
+	api.service = service
+}
+
+// PresignRequest returns the URL of a request to the Admin Ops API, signed
+// with the credentials of the API, that is valid for the given time. The URL
+// can be used by clients without the credentials. The path and args are
+// those of the Admin Ops API, for example "/user" and the uid of a user. The
+// headers that must be sent along with the URL are returned.
+func (api *API) PresignRequest(ctx context.Context, httpMethod, path string, args url.Values, expires time.Duration) (string, http.Header, error) {
+	if expires <= 0 || expires > maxPresignExpiry {
+		return "", nil, errInvalidPresignExpiry
+	}
+
+	query := url.Values{}
+	for k, v := range args {
+		query[k] = append([]string(nil), v...)
+	}
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires/time.Second)))
+
+	request, err := http.NewRequestWithContext(ctx, httpMethod, buildQueryPath(api.Endpoint, path, query.Encode()), nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	signer, creds, err := api.signer(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	return signer.PresignHTTP(ctx, creds, request, unsignedPayload, api.signingService(), api.signingRegion(), time.Now())
+}
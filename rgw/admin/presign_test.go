@@ -0,0 +1,69 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningRegionAndService(t *testing.T) {
+	var auth string
+	c := &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			auth = req.Header.Get("Authorization")
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader([]byte("[]"))),
+			}, nil
+		},
+	}
+	api, err := New("127.0.0.1", "accessKey", "secretKey", c)
+	require.NoError(t, err)
+
+	_, err = api.ListBuckets(context.TODO())
+	require.NoError(t, err)
+	assert.Contains(t, auth, "/default/s3/aws4_request")
+
+	api.SetSigningRegion("eu-west")
+	api.SetSigningService("rgw")
+	_, err = api.ListBuckets(context.TODO())
+	require.NoError(t, err)
+	assert.Contains(t, auth, "/eu-west/rgw/aws4_request")
+}
+
+func TestPresignRequest(t *testing.T) {
+	api, err := New("http://127.0.0.1", "accessKey", "secretKey", nil)
+	require.NoError(t, err)
+	api.SetSigningRegion("eu-west")
+
+	args := url.Values{"uid": []string{"leseb"}}
+	signed, _, err := api.PresignRequest(context.TODO(), http.MethodGet, "/user", args, 10*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signed, "http://127.0.0.1/admin/user?"))
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	q := u.Query()
+	assert.Equal(t, "leseb", q.Get("uid"))
+	assert.Equal(t, "600", q.Get("X-Amz-Expires"))
+	assert.Equal(t, "AWS4-HMAC-SHA256", q.Get("X-Amz-Algorithm"))
+	assert.Contains(t, q.Get("X-Amz-Credential"), "accessKey/")
+	assert.Contains(t, q.Get("X-Amz-Credential"), "/eu-west/s3/aws4_request")
+	assert.NotEmpty(t, q.Get("X-Amz-Signature"))
+	assert.Equal(t, []string{"leseb"}, args["uid"])
+	assert.NotContains(t, args, "X-Amz-Expires")
+
+	_, _, err = api.PresignRequest(context.TODO(), http.MethodGet, "/user", args, 0)
+	assert.ErrorIs(t, err, errInvalidPresignExpiry)
+	_, _, err = api.PresignRequest(context.TODO(), http.MethodGet, "/user", args, 8*24*time.Hour)
+	assert.ErrorIs(t, err, errInvalidPresignExpiry)
+}
@@ -0,0 +1,104 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// ErrMethodNotAllowed - The RGW does not support the request
+	ErrMethodNotAllowed errorReason = "MethodNotAllowed"
+
+	// ErrNotImplemented - The RGW does not implement the request
+	ErrNotImplemented errorReason = "NotImplemented"
+)
+
+// Feature is an optional part of the Admin Ops API
+type Feature string
+
+// The features detected by Probe
+const (
+	// FeatureInfo is the info endpoint, used by GetInfo
+	FeatureInfo Feature = "info"
+	// FeatureAccounts are the RGW accounts
+	FeatureAccounts Feature = "accounts"
+	// FeatureRateLimit are the rate limits
+	FeatureRateLimit Feature = "ratelimit"
+)
+
+// Capabilities are the features of the Admin Ops API supported by an RGW
+type Capabilities struct {
+	Features map[Feature]bool
+}
+
+// Supports returns whether the feature is supported
+func (c Capabilities) Supports(f Feature) bool {
+	return c.Features[f]
+}
+
+// featureProbe is a read only request identifying a feature
+type featureProbe struct {
+	feature Feature
+	path    string
+}
+
+var featureProbes = []featureProbe{
+	{FeatureInfo, "/info"},
+	{FeatureAccounts, "/account"},
+	{FeatureRateLimit, "/ratelimit?global"},
+}
+
+// probeSupported interprets the result of a probe request. Any error other
+// than the RGW rejecting the request as unsupported means the endpoint
+// exists, missing or invalid arguments included.
+func probeSupported(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, ErrMethodNotAllowed) || errors.Is(err, ErrNotImplemented) {
+		return false, nil
+	}
+	var se statusError
+	if errors.As(err, &se) {
+		return true, nil
+	}
+	return false, err
+}
+
+// Probe will find the features supported by the RGW by sending read only
+// requests to their endpoints. The features are recorded on the API and
+// returned by Capabilities. Probe is meant to be called once, before the
+// API is used concurrently.
+func (api *API) Probe(ctx context.Context) (Capabilities, error) {
+	c := Capabilities{Features: make(map[Feature]bool, len(featureProbes))}
+	for _, p := range featureProbes {
+		_, err := api.call(ctx, http.MethodGet, p.path, url.Values{"format": []string{"json"}})
+		supported, err := probeSupported(err)
+		if err != nil {
+			return Capabilities{}, err
+		}
+		c.Features[p.feature] = supported
+	}
+	api.features = make(map[string]bool, len(c.Features))
+	for f, supported := range c.Features {
+		api.features[string(f)] = supported
+	}
+	return c, nil
+}
+
+// Capabilities returns the features found by the last call to Probe. The
+// boolean is false if Probe has not been called successfully.
+func (api *API) Capabilities() (Capabilities, bool) {
+	if api.features == nil {
+		return Capabilities{}, false
+	}
+	c := Capabilities{Features: make(map[Feature]bool, len(api.features))}
+	for f, supported := range api.features {
+		c.Features[Feature(f)] = supported
+	}
+	return c, true
+}
@@ -0,0 +1,64 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbe(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			status, body := 200, "{}"
+			switch req.URL.Path {
+			case "127.0.0.1/admin/account":
+				status, body = 405, `{"Code": "MethodNotAllowed", "RequestId": "tx0", "HostId": "h"}`
+			case "127.0.0.1/admin/ratelimit":
+				status, body = 501, `{"Code": "NotImplemented", "RequestId": "tx0", "HostId": "h"}`
+			case "127.0.0.1/admin/info":
+				status, body = 403, `{"Code": "AccessDenied", "RequestId": "tx0", "HostId": "h"}`
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			}, nil
+		},
+	})
+	require.NoError(t, err)
+
+	_, ok := api.Capabilities()
+	assert.False(t, ok)
+
+	c, err := api.Probe(context.TODO())
+	require.NoError(t, err)
+	assert.True(t, c.Supports(FeatureInfo))
+	assert.False(t, c.Supports(FeatureAccounts))
+	assert.False(t, c.Supports(FeatureRateLimit))
+
+	recorded, ok := api.Capabilities()
+	assert.True(t, ok)
+	assert.Equal(t, c, recorded)
+}
+
+func TestProbeError(t *testing.T) {
+	errConn := errors.New("connection refused")
+	api, err := New("127.0.0.1", "accessKey", "secretKey", &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			return nil, errConn
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = api.Probe(context.TODO())
+	assert.ErrorIs(t, err, errConn)
+	_, ok := api.Capabilities()
+	assert.False(t, ok)
+}
@@ -0,0 +1,93 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// QuotaScope selects the quota read or written by GetQuota and SetQuota
+type QuotaScope string
+
+// The possible values of QuotaScope
+const (
+	// QuotaScopeUser is the quota of a user, identified by its ID
+	QuotaScopeUser QuotaScope = "user"
+	// QuotaScopeUserBucket is the quota of each bucket of a user,
+	// identified by its ID
+	QuotaScopeUserBucket QuotaScope = "user-bucket"
+	// QuotaScopeBucket is the quota of a bucket, identified by its name
+	QuotaScopeBucket QuotaScope = "bucket"
+	// QuotaScopeAccount is the quota of an RGW account, identified by its
+	// ID. Accounts require Ceph Squid or later.
+	QuotaScopeAccount QuotaScope = "account"
+	// QuotaScopeAccountBucket is the quota of each bucket of an RGW
+	// account, identified by its ID. Accounts require Ceph Squid or later.
+	QuotaScopeAccountBucket QuotaScope = "account-bucket"
+	// QuotaScopeGlobalUser is the default quota of the users of the
+	// cluster, the ID is not used
+	QuotaScopeGlobalUser QuotaScope = "global-user"
+	// QuotaScopeGlobalBucket is the default quota of the buckets of the
+	// cluster, the ID is not used
+	QuotaScopeGlobalBucket QuotaScope = "global-bucket"
+)
+
+// errGlobalQuotaReadOnly is returned when setting a global quota. Global
+// quotas are part of the period configuration, which the Admin Ops API
+// cannot modify.
+var errGlobalQuotaReadOnly = errors.New("global quotas can only be set using radosgw-admin global quota set")
+
+func invalidQuotaScope(scope QuotaScope) error {
+	return fmt.Errorf("%w: invalid quota scope %q", ErrInvalidArgument, scope)
+}
+
+// GetQuota will return the quota of the given scope. The id identifies the
+// user, bucket or account the quota applies to, see QuotaScope.
+func (api *API) GetQuota(ctx context.Context, scope QuotaScope, id string) (QuotaSpec, error) {
+	switch scope {
+	case QuotaScopeUser:
+		return api.GetUserQuota(ctx, QuotaSpec{UID: id})
+	case QuotaScopeUserBucket:
+		return api.GetBucketQuota(ctx, QuotaSpec{UID: id})
+	case QuotaScopeBucket:
+		if id == "" {
+			return QuotaSpec{}, errMissingBucket
+		}
+		b, err := api.GetBucketInfo(ctx, Bucket{Bucket: id})
+		return b.BucketQuota, err
+	case QuotaScopeAccount, QuotaScopeAccountBucket:
+		return api.getAccountQuota(ctx, scope, id)
+	case QuotaScopeGlobalUser, QuotaScopeGlobalBucket:
+		p, err := api.GetPeriod(ctx, PeriodRequest{})
+		if scope == QuotaScopeGlobalUser {
+			return p.PeriodConfig.UserQuota, err
+		}
+		return p.PeriodConfig.BucketQuota, err
+	}
+	return QuotaSpec{}, invalidQuotaScope(scope)
+}
+
+// SetQuota will set the quota of the given scope. The id identifies the
+// user, bucket or account the quota applies to, see QuotaScope. The bucket
+// quota scope requires the owner of the bucket in the UID of the quota.
+// Global quotas can not be set.
+func (api *API) SetQuota(ctx context.Context, scope QuotaScope, id string, quota QuotaSpec) error {
+	switch scope {
+	case QuotaScopeUser:
+		quota.UID = id
+		return api.SetUserQuota(ctx, quota)
+	case QuotaScopeUserBucket:
+		quota.UID = id
+		return api.SetBucketQuota(ctx, quota)
+	case QuotaScopeBucket:
+		quota.Bucket = id
+		return api.SetIndividualBucketQuota(ctx, quota)
+	case QuotaScopeAccount, QuotaScopeAccountBucket:
+		return api.setAccountQuota(ctx, scope, id, quota)
+	case QuotaScopeGlobalUser, QuotaScopeGlobalBucket:
+		return errGlobalQuotaReadOnly
+	}
+	return invalidQuotaScope(scope)
+}
@@ -0,0 +1,26 @@
+//go:build !(pacific || quincy || reef) && ceph_preview
+
+package admin
+
+import (
+	"context"
+)
+
+func (api *API) getAccountQuota(ctx context.Context, scope QuotaScope, id string) (QuotaSpec, error) {
+	if id == "" {
+		return QuotaSpec{}, ErrInvalidArgument
+	}
+	a, err := api.GetAccount(ctx, id)
+	if scope == QuotaScopeAccount {
+		return a.Quota, err
+	}
+	return a.BucketQuota, err
+}
+
+func (api *API) setAccountQuota(ctx context.Context, scope QuotaScope, id string, quota QuotaSpec) error {
+	quota.QuotaType = AccountQuotaTypeBucket
+	if scope == QuotaScopeAccount {
+		quota.QuotaType = AccountQuotaTypeAccount
+	}
+	return api.SetAccountQuota(ctx, id, quota)
+}
@@ -0,0 +1,44 @@
+//go:build !(pacific || quincy || reef) && ceph_preview
+
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountQuotaScopes(t *testing.T) {
+	var requests []string
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnQuotaScopeMockClient(&requests))
+	require.NoError(t, err)
+	ctx := context.TODO()
+
+	t.Run("get", func(t *testing.T) {
+		quota, err := api.GetQuota(ctx, QuotaScopeAccount, "RGW1")
+		require.NoError(t, err)
+		require.NotNil(t, quota.MaxObjects)
+		assert.Equal(t, int64(4), *quota.MaxObjects)
+		quota, err = api.GetQuota(ctx, QuotaScopeAccountBucket, "RGW1")
+		require.NoError(t, err)
+		require.NotNil(t, quota.MaxObjects)
+		assert.Equal(t, int64(5), *quota.MaxObjects)
+
+		_, err = api.GetQuota(ctx, QuotaScopeAccount, "")
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+	t.Run("set", func(t *testing.T) {
+		maxObjects := int64(10)
+		quota := QuotaSpec{MaxObjects: &maxObjects}
+		requests = nil
+
+		require.NoError(t, api.SetQuota(ctx, QuotaScopeAccount, "RGW1", quota))
+		require.NoError(t, api.SetQuota(ctx, QuotaScopeAccountBucket, "RGW1", quota))
+		assert.Equal(t, []string{
+			"PUT 127.0.0.1/admin/account",
+			"PUT 127.0.0.1/admin/account",
+		}, requests)
+	})
+}
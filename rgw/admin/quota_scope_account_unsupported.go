@@ -0,0 +1,20 @@
+//go:build (pacific || quincy || reef) && ceph_preview
+
+package admin
+
+import (
+	"context"
+	"errors"
+)
+
+// errAccountsUnsupported is returned for the account quota scopes, RGW
+// accounts were added in Ceph Squid.
+var errAccountsUnsupported = errors.New("RGW accounts are not supported before Ceph Squid")
+
+func (api *API) getAccountQuota(ctx context.Context, scope QuotaScope, id string) (QuotaSpec, error) {
+	return QuotaSpec{}, errAccountsUnsupported
+}
+
+func (api *API) setAccountQuota(ctx context.Context, scope QuotaScope, id string, quota QuotaSpec) error {
+	return errAccountsUnsupported
+}
@@ -0,0 +1,24 @@
+//go:build (pacific || quincy || reef) && ceph_preview
+
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountQuotaScopesUnsupported(t *testing.T) {
+	var requests []string
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnQuotaScopeMockClient(&requests))
+	require.NoError(t, err)
+	ctx := context.TODO()
+
+	_, err = api.GetQuota(ctx, QuotaScopeAccount, "RGW1")
+	assert.ErrorIs(t, err, errAccountsUnsupported)
+	err = api.SetQuota(ctx, QuotaScopeAccountBucket, "RGW1", QuotaSpec{})
+	assert.ErrorIs(t, err, errAccountsUnsupported)
+	assert.Empty(t, requests)
+}
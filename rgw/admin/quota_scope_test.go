@@ -0,0 +1,95 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func returnQuotaScopeMockClient(requests *[]string) *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			*requests = append(*requests, req.Method+" "+req.URL.Path)
+			var body string
+			switch {
+			case req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/admin/user":
+				body = fmt.Sprintf(`{"enabled": true, "max_objects": %d}`, map[string]int{"user": 1, "bucket": 2}[q.Get("quota-type")])
+			case req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/admin/bucket":
+				body = `{"bucket": "mybucket", "bucket_quota": {"enabled": true, "max_objects": 3}}`
+			case req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/admin/account":
+				body = `{"id": "RGW1", "quota": {"max_objects": 4}, "bucket_quota": {"max_objects": 5}}`
+			case req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/admin/realm/period":
+				body = `{"period_config": {"bucket_quota": {"max_objects": 6}, "user_quota": {"max_objects": 7}}}`
+			case req.Method == http.MethodPut:
+				if q.Get("max-objects") != "10" {
+					return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+				}
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			}, nil
+		},
+	}
+}
+
+func TestQuotaScopes(t *testing.T) {
+	var requests []string
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnQuotaScopeMockClient(&requests))
+	require.NoError(t, err)
+	ctx := context.TODO()
+
+	t.Run("get", func(t *testing.T) {
+		for scope, expected := range map[QuotaScope]int64{
+			QuotaScopeUser:         1,
+			QuotaScopeUserBucket:   2,
+			QuotaScopeBucket:       3,
+			QuotaScopeGlobalBucket: 6,
+			QuotaScopeGlobalUser:   7,
+		} {
+			id := "leseb"
+			if scope == QuotaScopeBucket {
+				id = "mybucket"
+			}
+			quota, err := api.GetQuota(ctx, scope, id)
+			require.NoError(t, err, scope)
+			require.NotNil(t, quota.MaxObjects, scope)
+			assert.Equal(t, expected, *quota.MaxObjects, scope)
+		}
+
+		_, err := api.GetQuota(ctx, "nope", "leseb")
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+		_, err = api.GetQuota(ctx, QuotaScopeUser, "")
+		assert.ErrorIs(t, err, errMissingUserID)
+	})
+	t.Run("set", func(t *testing.T) {
+		maxObjects := int64(10)
+		quota := QuotaSpec{MaxObjects: &maxObjects}
+		requests = nil
+
+		require.NoError(t, api.SetQuota(ctx, QuotaScopeUser, "leseb", quota))
+		require.NoError(t, api.SetQuota(ctx, QuotaScopeUserBucket, "leseb", quota))
+		require.NoError(t, api.SetQuota(ctx, QuotaScopeBucket, "mybucket", QuotaSpec{UID: "leseb", MaxObjects: &maxObjects}))
+		assert.Equal(t, []string{
+			"PUT 127.0.0.1/admin/user",
+			"PUT 127.0.0.1/admin/user",
+			"PUT 127.0.0.1/admin/bucket",
+		}, requests)
+
+		err := api.SetQuota(ctx, QuotaScopeGlobalUser, "", quota)
+		assert.ErrorIs(t, err, errGlobalQuotaReadOnly)
+		err = api.SetQuota(ctx, "nope", "leseb", quota)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
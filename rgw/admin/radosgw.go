@@ -19,6 +19,7 @@ const (
 	authRegion        = "default"
 	service           = "s3"
 	connectionTimeout = time.Second * 3
+	unsignedPayload   = "UNSIGNED-PAYLOAD"
 )
 
 var (
@@ -38,6 +39,12 @@ type API struct {
 	SecretKey  string
 	Endpoint   string
 	HTTPClient HTTPClient
+
+	// region and service override authRegion and service when signing
+	region  string
+	service string
+	// features are the features found by Probe, nil before
+	features map[string]bool
 }
 
 // New returns client for Ceph RGW
@@ -72,50 +79,95 @@ func New(endpoint, accessKey, secretKey string, httpClient HTTPClient) (*API, er
 
 // call makes request to the RGW Admin Ops API
 func (api *API) call(ctx context.Context, httpMethod, path string, args url.Values) (body []byte, err error) {
-	// Build request
-	request, err := http.NewRequestWithContext(ctx, httpMethod, buildQueryPath(api.Endpoint, path, args.Encode()), nil)
-	if err != nil {
-		return nil, err
-	}
+	return api.do(ctx, httpMethod, buildQueryPath(api.Endpoint, path, args.Encode()), nil)
+}
 
-	// Build S3 authentication
-	credCache := aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(api.AccessKey, api.SecretKey, ""))
-	creds, err := credCache.Retrieve(ctx)
+// do sends a signed request to the given RGW URL and returns the body of the
+// response. The payload is optional.
+func (api *API) do(ctx context.Context, httpMethod, requestURL string, payload io.Reader) (body []byte, err error) {
+	_, body, err = api.doResponse(ctx, httpMethod, requestURL, payload)
+	return body, err
+}
+
+// doResponse is like do but also returns the response, for the callers that
+// need its headers.
+func (api *API) doResponse(ctx context.Context, httpMethod, requestURL string, payload io.Reader) (*http.Response, []byte, error) {
+	// Build request
+	request, err := http.NewRequestWithContext(ctx, httpMethod, requestURL, payload)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	signer := v4.NewSigner()
-	// This was present in https://github.com/IrekFasikhov/go-rgwadmin/ but it seems that the lib works without it
-	// Let's keep it here just in case something shows up
-	// signer.DisableRequestBodyOverwrite = true
-
-	// Sign in S3
-	const emptyPayloadHash = "UNSIGNED-PAYLOAD"
-	err = signer.SignHTTP(ctx, creds, request, emptyPayloadHash, service, authRegion, time.Now())
+	err = api.sign(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Send HTTP request
 	resp, err := api.HTTPClient.Do(request)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
 	// Decode HTTP response
 	decodedResponse, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	resp.Body = io.NopCloser(bytes.NewBuffer(decodedResponse))
 
 	// Handle error in response
 	if resp.StatusCode >= 300 {
-		return nil, handleStatusError(decodedResponse)
+		return nil, nil, handleHTTPError(resp.StatusCode, decodedResponse)
 	}
 
-	return decodedResponse, nil
+	return resp, decodedResponse, nil
+}
+
+// signingRegion returns the region the requests are signed for
+func (api *API) signingRegion() string {
+	if api.region != "" {
+		return api.region
+	}
+	return authRegion
+}
+
+// signingService returns the service the requests are signed for
+func (api *API) signingService() string {
+	if api.service != "" {
+		return api.service
+	}
+	return service
+}
+
+// signer returns the signer and credentials used to sign requests
+func (api *API) signer(ctx context.Context) (*v4.Signer, aws.Credentials, error) {
+	credCache := aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(api.AccessKey, api.SecretKey, ""))
+	creds, err := credCache.Retrieve(ctx)
+	if err != nil {
+		return nil, aws.Credentials{}, err
+	}
+
+	// S3 expects the path to be escaped only once
+	signer := v4.NewSigner(func(o *v4.SignerOptions) {
+		o.DisableURIPathEscaping = true
+	})
+	return signer, creds, nil
+}
+
+// sign adds the S3 authentication of the API credentials to the request
+func (api *API) sign(ctx context.Context, request *http.Request) error {
+	// Build S3 authentication
+	signer, creds, err := api.signer(ctx)
+	if err != nil {
+		return err
+	}
+	// This was present in https://github.com/IrekFasikhov/go-rgwadmin/ but it seems that the lib works without it
+	// Let's keep it here just in case something shows up
+	// signer.DisableRequestBodyOverwrite = true
+
+	// Sign in S3
+	return signer.SignHTTP(ctx, creds, request, unsignedPayload, api.signingService(), api.signingRegion(), time.Now())
 }
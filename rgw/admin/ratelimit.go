@@ -0,0 +1,154 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Scopes of the rate limits that can be set with SetGlobalRateLimit
+const (
+	RateLimitScopeUser      = "user"
+	RateLimitScopeBucket    = "bucket"
+	RateLimitScopeAnonymous = "anonymous"
+)
+
+// RateLimitSpec describes the rate limit of a user or a bucket. The limits
+// are per RGW instance and per minute, zero means unlimited. Unset fields
+// are left unchanged by the set operations.
+type RateLimitSpec struct {
+	MaxReadOps    *int64 `json:"max_read_ops" url:"max-read-ops"`
+	MaxWriteOps   *int64 `json:"max_write_ops" url:"max-write-ops"`
+	MaxReadBytes  *int64 `json:"max_read_bytes" url:"max-read-bytes"`
+	MaxWriteBytes *int64 `json:"max_write_bytes" url:"max-write-bytes"`
+	Enabled       *bool  `json:"enabled" url:"enabled"`
+}
+
+// GlobalRateLimit contains the rate limits applied to the users and buckets
+// without their own rate limit, and to anonymous access
+type GlobalRateLimit struct {
+	User      RateLimitSpec `json:"user_ratelimit"`
+	Bucket    RateLimitSpec `json:"bucket_ratelimit"`
+	Anonymous RateLimitSpec `json:"anonymous_ratelimit"`
+}
+
+// rateLimitRequest contains the parameters of the rate limit requests
+type rateLimitRequest struct {
+	Scope  string `url:"ratelimit-scope"`
+	UID    string `url:"uid"`
+	Bucket string `url:"bucket"`
+	Global *bool  `url:"global"`
+	Spec   RateLimitSpec
+}
+
+var rateLimitSetFields = []string{"ratelimit-scope", "uid", "bucket", "global", "max-read-ops", "max-write-ops", "max-read-bytes", "max-write-bytes", "enabled"}
+
+// GetUserRateLimit will return the rate limit of a user
+// https://docs.ceph.com/en/latest/radosgw/adminops/#get-user-rate-limit
+func (api *API) GetUserRateLimit(ctx context.Context, uid string) (RateLimitSpec, error) {
+	if uid == "" {
+		return RateLimitSpec{}, errMissingUserID
+	}
+
+	req := rateLimitRequest{Scope: RateLimitScopeUser, UID: uid}
+	body, err := api.call(ctx, http.MethodGet, "/ratelimit", valueToURLParams(req, []string{"ratelimit-scope", "uid"}))
+	if err != nil {
+		return RateLimitSpec{}, err
+	}
+
+	ref := struct {
+		RateLimit RateLimitSpec `json:"user_ratelimit"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return RateLimitSpec{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref.RateLimit, nil
+}
+
+// SetUserRateLimit will set the rate limit of a user
+// https://docs.ceph.com/en/latest/radosgw/adminops/#set-user-rate-limit
+func (api *API) SetUserRateLimit(ctx context.Context, uid string, spec RateLimitSpec) error {
+	if uid == "" {
+		return errMissingUserID
+	}
+
+	req := rateLimitRequest{Scope: RateLimitScopeUser, UID: uid, Spec: spec}
+	_, err := api.call(ctx, http.MethodPost, "/ratelimit", valueToURLParams(req, rateLimitSetFields))
+	return err
+}
+
+// GetBucketRateLimit will return the rate limit of a bucket
+// https://docs.ceph.com/en/latest/radosgw/adminops/#get-bucket-rate-limit
+func (api *API) GetBucketRateLimit(ctx context.Context, bucket string) (RateLimitSpec, error) {
+	if bucket == "" {
+		return RateLimitSpec{}, errMissingBucket
+	}
+
+	req := rateLimitRequest{Scope: RateLimitScopeBucket, Bucket: bucket}
+	body, err := api.call(ctx, http.MethodGet, "/ratelimit", valueToURLParams(req, []string{"ratelimit-scope", "bucket"}))
+	if err != nil {
+		return RateLimitSpec{}, err
+	}
+
+	ref := struct {
+		RateLimit RateLimitSpec `json:"bucket_ratelimit"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return RateLimitSpec{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref.RateLimit, nil
+}
+
+// SetBucketRateLimit will set the rate limit of a bucket
+// https://docs.ceph.com/en/latest/radosgw/adminops/#set-bucket-rate-limit
+func (api *API) SetBucketRateLimit(ctx context.Context, bucket string, spec RateLimitSpec) error {
+	if bucket == "" {
+		return errMissingBucket
+	}
+
+	req := rateLimitRequest{Scope: RateLimitScopeBucket, Bucket: bucket, Spec: spec}
+	_, err := api.call(ctx, http.MethodPost, "/ratelimit", valueToURLParams(req, rateLimitSetFields))
+	return err
+}
+
+// GetGlobalRateLimit will return the global rate limits
+// https://docs.ceph.com/en/latest/radosgw/adminops/#get-global-rate-limit
+func (api *API) GetGlobalRateLimit(ctx context.Context) (GlobalRateLimit, error) {
+	global := true
+	req := rateLimitRequest{Global: &global}
+	body, err := api.call(ctx, http.MethodGet, "/ratelimit", valueToURLParams(req, []string{"global"}))
+	if err != nil {
+		return GlobalRateLimit{}, err
+	}
+
+	ref := GlobalRateLimit{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return GlobalRateLimit{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref, nil
+}
+
+// SetGlobalRateLimit will set the global rate limit of the given scope, one
+// of RateLimitScopeUser, RateLimitScopeBucket or RateLimitScopeAnonymous
+// https://docs.ceph.com/en/latest/radosgw/adminops/#set-global-rate-limit
+func (api *API) SetGlobalRateLimit(ctx context.Context, scope string, spec RateLimitSpec) error {
+	switch scope {
+	case RateLimitScopeUser, RateLimitScopeBucket, RateLimitScopeAnonymous:
+	default:
+		return fmt.Errorf("%w: invalid rate limit scope %q", ErrInvalidArgument, scope)
+	}
+
+	global := true
+	req := rateLimitRequest{Scope: scope, Global: &global, Spec: spec}
+	_, err := api.call(ctx, http.MethodPost, "/ratelimit", valueToURLParams(req, rateLimitSetFields))
+	return err
+}
@@ -0,0 +1,113 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeRateLimit          = `{"max_read_ops": 1024, "max_write_ops": 256, "max_read_bytes": 0, "max_write_bytes": 0, "enabled": true}`
+	fakeUserRateLimit      = []byte(`{"user_ratelimit": ` + fakeRateLimit + `}`)
+	fakeBucketRateLimit    = []byte(`{"bucket_ratelimit": ` + fakeRateLimit + `}`)
+	fakeGlobalRateLimitRes = []byte(`{
+  "bucket_ratelimit": {"max_read_ops": 0, "max_write_ops": 0, "max_read_bytes": 0, "max_write_bytes": 0, "enabled": false},
+  "user_ratelimit": ` + fakeRateLimit + `,
+  "anonymous_ratelimit": {"max_read_ops": 10, "max_write_ops": 0, "max_read_bytes": 0, "max_write_bytes": 0, "enabled": true}
+}`)
+)
+
+func returnRateLimitMockClient(requests *[]string) *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "127.0.0.1/admin/ratelimit" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			q := req.URL.Query()
+			var body []byte
+			switch {
+			case req.Method == http.MethodPost:
+				*requests = append(*requests, req.URL.RawQuery)
+			case q.Get("global") == "true":
+				body = fakeGlobalRateLimitRes
+			case q.Get("ratelimit-scope") == "user" && q.Get("uid") != "":
+				body = fakeUserRateLimit
+			case q.Get("ratelimit-scope") == "bucket" && q.Get("bucket") != "":
+				body = fakeBucketRateLimit
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	var requests []string
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnRateLimitMockClient(&requests))
+	require.NoError(t, err)
+
+	maxReadOps := int64(1024)
+	enabled := true
+	spec := RateLimitSpec{MaxReadOps: &maxReadOps, Enabled: &enabled}
+
+	t.Run("user", func(t *testing.T) {
+		_, err := api.GetUserRateLimit(context.TODO(), "")
+		assert.ErrorIs(t, err, errMissingUserID)
+		rl, err := api.GetUserRateLimit(context.TODO(), "leseb")
+		assert.NoError(t, err)
+		require.NotNil(t, rl.MaxWriteOps)
+		assert.EqualValues(t, 256, *rl.MaxWriteOps)
+		require.NotNil(t, rl.Enabled)
+		assert.True(t, *rl.Enabled)
+
+		requests = nil
+		err = api.SetUserRateLimit(context.TODO(), "leseb", spec)
+		assert.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Equal(t, "enabled=true&format=json&max-read-ops=1024&ratelimit-scope=user&uid=leseb", requests[0])
+		err = api.SetUserRateLimit(context.TODO(), "", spec)
+		assert.ErrorIs(t, err, errMissingUserID)
+	})
+	t.Run("bucket", func(t *testing.T) {
+		_, err := api.GetBucketRateLimit(context.TODO(), "")
+		assert.ErrorIs(t, err, errMissingBucket)
+		rl, err := api.GetBucketRateLimit(context.TODO(), "mybucket")
+		assert.NoError(t, err)
+		require.NotNil(t, rl.MaxReadOps)
+		assert.EqualValues(t, 1024, *rl.MaxReadOps)
+
+		requests = nil
+		err = api.SetBucketRateLimit(context.TODO(), "mybucket", spec)
+		assert.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Equal(t, "bucket=mybucket&enabled=true&format=json&max-read-ops=1024&ratelimit-scope=bucket", requests[0])
+	})
+	t.Run("global", func(t *testing.T) {
+		rl, err := api.GetGlobalRateLimit(context.TODO())
+		assert.NoError(t, err)
+		require.NotNil(t, rl.Bucket.Enabled)
+		assert.False(t, *rl.Bucket.Enabled)
+		require.NotNil(t, rl.Anonymous.MaxReadOps)
+		assert.EqualValues(t, 10, *rl.Anonymous.MaxReadOps)
+
+		requests = nil
+		err = api.SetGlobalRateLimit(context.TODO(), RateLimitScopeAnonymous, spec)
+		assert.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Equal(t, "enabled=true&format=json&global=true&max-read-ops=1024&ratelimit-scope=anonymous", requests[0])
+		err = api.SetGlobalRateLimit(context.TODO(), "nope", spec)
+		assert.ErrorIs(t, err, ErrInvalidArgument)
+	})
+}
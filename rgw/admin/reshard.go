@@ -0,0 +1,129 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// The Admin Ops API does not provide access to the reshard queue. Adding
+// buckets to the queue, listing and canceling its entries and resharding
+// a bucket immediately remain radosgw-admin operations.
+
+// Reshard states of a bucket, as reported by BucketReshardStatus.Status
+const (
+	ReshardStatusNone       = "not-resharding"
+	ReshardStatusInProgress = "in-progress"
+	ReshardStatusDone       = "done"
+)
+
+// reshardStatus decodes the reshard status of a bucket, which is encoded as
+// a number or as a string depending on the Ceph version
+type reshardStatus string
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *reshardStatus) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		switch n {
+		case 0:
+			*s = ReshardStatusNone
+		case 1:
+			*s = ReshardStatusInProgress
+		case 2:
+			*s = ReshardStatusDone
+		default:
+			return fmt.Errorf("unknown reshard status %d", n)
+		}
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	*s = reshardStatus(str)
+	return nil
+}
+
+// BucketReshardStatus reports the reshard state of a bucket
+type BucketReshardStatus struct {
+	Bucket   string
+	BucketID string
+	// NumShards is the current number of index shards of the bucket
+	NumShards int
+	// Status is one of the ReshardStatus constants
+	Status string
+	// NewBucketInstanceID is the ID of the bucket instance the index is
+	// resharded to, used by the reshard of older Ceph versions
+	NewBucketInstanceID string
+}
+
+// metadataRequest contains the parameters of the metadata requests
+type metadataRequest struct {
+	Key string `url:"key"`
+}
+
+// bucketInstanceMetadata is the part of the bucket instance metadata used
+// to report the reshard state
+type bucketInstanceMetadata struct {
+	Data struct {
+		BucketInfo struct {
+			NumShards           int           `json:"num_shards"`
+			ReshardStatus       reshardStatus `json:"reshard_status"`
+			NewBucketInstanceID string        `json:"new_bucket_instance_id"`
+			Layout              *struct {
+				CurrentIndex struct {
+					Layout struct {
+						Normal struct {
+							NumShards int `json:"num_shards"`
+						} `json:"normal"`
+					} `json:"layout"`
+				} `json:"current_index"`
+			} `json:"layout"`
+		} `json:"bucket_info"`
+	} `json:"data"`
+}
+
+// GetBucketReshardStatus will return the reshard state of a bucket
+func (api *API) GetBucketReshardStatus(ctx context.Context, bucket string) (BucketReshardStatus, error) {
+	if bucket == "" {
+		return BucketReshardStatus{}, errMissingBucket
+	}
+
+	info, err := api.GetBucketInfo(ctx, Bucket{Bucket: bucket})
+	if err != nil {
+		return BucketReshardStatus{}, err
+	}
+
+	args := valueToURLParams(metadataRequest{Key: bucket + ":" + info.ID}, []string{"key"})
+	body, err := api.call(ctx, http.MethodGet, "/metadata/bucket.instance", args)
+	if err != nil {
+		return BucketReshardStatus{}, err
+	}
+
+	ref := bucketInstanceMetadata{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return BucketReshardStatus{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	bi := ref.Data.BucketInfo
+	status := BucketReshardStatus{
+		Bucket:              bucket,
+		BucketID:            info.ID,
+		NumShards:           bi.NumShards,
+		Status:              string(bi.ReshardStatus),
+		NewBucketInstanceID: bi.NewBucketInstanceID,
+	}
+	// newer versions report the number of shards as part of the index layout
+	if bi.Layout != nil && bi.Layout.CurrentIndex.Layout.Normal.NumShards != 0 {
+		status.NumShards = bi.Layout.CurrentIndex.Layout.Normal.NumShards
+	}
+	if status.Status == "" {
+		status.Status = ReshardStatusNone
+	}
+	return status, nil
+}
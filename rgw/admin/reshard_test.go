@@ -0,0 +1,98 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeReshardBucketInfo = []byte(`{"bucket": "mybucket", "id": "3b2f4a1e.4137.1", "num_shards": 11}`)
+	// metadata as reported by older versions
+	fakeBucketInstanceOld = []byte(`{
+  "key": "bucket.instance:oldbucket:3b2f4a1e.4137.1",
+  "data": {
+    "bucket_info": {
+      "num_shards": 11,
+      "reshard_status": 1,
+      "new_bucket_instance_id": "3b2f4a1e.4137.2"
+    }
+  }
+}`)
+	// metadata as reported by newer versions
+	fakeBucketInstanceNew = []byte(`{
+  "key": "bucket.instance:mybucket:3b2f4a1e.4137.1",
+  "data": {
+    "bucket_info": {
+      "num_shards": 0,
+      "reshard_status": "not-resharding",
+      "new_bucket_instance_id": "",
+      "layout": {
+        "resharding": "None",
+        "current_index": {
+          "gen": 1,
+          "layout": {"type": "Normal", "normal": {"num_shards": 23, "hash_type": "Mod"}}
+        }
+      }
+    }
+  }
+}`)
+)
+
+func returnReshardMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			var body []byte
+			switch {
+			case req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/admin/bucket":
+				body = fakeReshardBucketInfo
+			case req.Method == http.MethodGet && req.URL.Path == "127.0.0.1/admin/metadata/bucket.instance":
+				switch q.Get("key") {
+				case "mybucket:3b2f4a1e.4137.1":
+					body = fakeBucketInstanceNew
+				case "oldbucket:3b2f4a1e.4137.1":
+					body = fakeBucketInstanceOld
+				}
+			}
+			if body == nil {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestGetBucketReshardStatus(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnReshardMockClient())
+	require.NoError(t, err)
+
+	_, err = api.GetBucketReshardStatus(context.TODO(), "")
+	assert.ErrorIs(t, err, errMissingBucket)
+
+	status, err := api.GetBucketReshardStatus(context.TODO(), "mybucket")
+	assert.NoError(t, err)
+	assert.Equal(t, BucketReshardStatus{
+		Bucket:    "mybucket",
+		BucketID:  "3b2f4a1e.4137.1",
+		NumShards: 23,
+		Status:    ReshardStatusNone,
+	}, status)
+
+	status, err = api.GetBucketReshardStatus(context.TODO(), "oldbucket")
+	assert.NoError(t, err)
+	assert.Equal(t, 11, status.NumShards)
+	assert.Equal(t, ReshardStatusInProgress, status.Status)
+	assert.Equal(t, "3b2f4a1e.4137.2", status.NewBucketInstanceID)
+}
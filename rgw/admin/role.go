@@ -0,0 +1,155 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrNoSuchEntity - Role does not exist
+const ErrNoSuchEntity errorReason = "NoSuchEntity"
+
+var (
+	errMissingRoleName   = errors.New("missing role name")
+	errMissingRolePolicy = errors.New("missing role assume role policy document")
+)
+
+// Role describes an IAM role that can be assumed using STS AssumeRole
+type Role struct {
+	ID                       string `json:"RoleId" url:"-"`
+	Name                     string `json:"RoleName" url:"RoleName"`
+	Path                     string `json:"Path" url:"Path"`
+	ARN                      string `json:"Arn" url:"-"`
+	CreateDate               string `json:"CreateDate" url:"-"`
+	MaxSessionDuration       *int   `json:"MaxSessionDuration" url:"MaxSessionDuration"`
+	AssumeRolePolicyDocument string `json:"AssumeRolePolicyDocument" url:"AssumeRolePolicyDocument"`
+}
+
+// callIAM sends a request for the given action to the IAM API of the RGW.
+// Unlike the Admin Ops API, the IAM API is served at the root of the
+// endpoint and the operation is selected by the Action parameter.
+func (api *API) callIAM(ctx context.Context, action string, args url.Values) ([]byte, error) {
+	args.Set("Action", action)
+	args.Set("format", "json")
+	return api.do(ctx, http.MethodPost, fmt.Sprintf("%s/?%s", api.Endpoint, args.Encode()), nil)
+}
+
+// CreateRole will create a new role
+// https://docs.ceph.com/en/latest/radosgw/role/#create-a-role
+func (api *API) CreateRole(ctx context.Context, role Role) (Role, error) {
+	if role.Name == "" {
+		return Role{}, errMissingRoleName
+	}
+	if role.AssumeRolePolicyDocument == "" {
+		return Role{}, errMissingRolePolicy
+	}
+
+	body, err := api.callIAM(ctx, "CreateRole", valueToURLParams(role, []string{"RoleName", "Path", "MaxSessionDuration", "AssumeRolePolicyDocument"}))
+	if err != nil {
+		return Role{}, err
+	}
+
+	ref := struct {
+		Response struct {
+			Result struct {
+				Role Role `json:"Role"`
+			} `json:"CreateRoleResult"`
+		} `json:"CreateRoleResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return Role{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref.Response.Result.Role, nil
+}
+
+// GetRole will return the details of a role
+// https://docs.ceph.com/en/latest/radosgw/role/#get-a-role
+func (api *API) GetRole(ctx context.Context, roleName string) (Role, error) {
+	if roleName == "" {
+		return Role{}, errMissingRoleName
+	}
+
+	body, err := api.callIAM(ctx, "GetRole", valueToURLParams(Role{Name: roleName}, []string{"RoleName"}))
+	if err != nil {
+		return Role{}, err
+	}
+
+	ref := struct {
+		Response struct {
+			Result struct {
+				Role Role `json:"Role"`
+			} `json:"GetRoleResult"`
+		} `json:"GetRoleResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return Role{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref.Response.Result.Role, nil
+}
+
+// ListRoles will return the roles whose path starts with pathPrefix. If
+// pathPrefix is empty all the roles are returned.
+// https://docs.ceph.com/en/latest/radosgw/role/#list-roles
+func (api *API) ListRoles(ctx context.Context, pathPrefix string) ([]Role, error) {
+	args := url.Values{}
+	if pathPrefix != "" {
+		args.Add("PathPrefix", pathPrefix)
+	}
+
+	body, err := api.callIAM(ctx, "ListRoles", args)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := struct {
+		Response struct {
+			Result struct {
+				Roles []Role `json:"Roles"`
+			} `json:"ListRolesResult"`
+		} `json:"ListRolesResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref.Response.Result.Roles, nil
+}
+
+// DeleteRole will delete a role. The role must not have permission policies
+// attached.
+// https://docs.ceph.com/en/latest/radosgw/role/#delete-a-role
+func (api *API) DeleteRole(ctx context.Context, roleName string) error {
+	if roleName == "" {
+		return errMissingRoleName
+	}
+
+	_, err := api.callIAM(ctx, "DeleteRole", valueToURLParams(Role{Name: roleName}, []string{"RoleName"}))
+	return err
+}
+
+// UpdateAssumeRolePolicy will replace the policy document that determines
+// which principals can assume a role
+// https://docs.ceph.com/en/latest/radosgw/role/#update-assume-role-policy-document-of-a-role
+func (api *API) UpdateAssumeRolePolicy(ctx context.Context, roleName, policyDocument string) error {
+	if roleName == "" {
+		return errMissingRoleName
+	}
+	if policyDocument == "" {
+		return errMissingRolePolicy
+	}
+
+	args := valueToURLParams(Role{Name: roleName}, []string{"RoleName"})
+	args.Add("PolicyDocument", policyDocument)
+	_, err := api.callIAM(ctx, "UpdateAssumeRolePolicy", args)
+	return err
+}
@@ -0,0 +1,119 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// The RGW does not keep track of the sessions of assumed roles, the session
+// tokens are self contained. The permissions of the temporary credentials
+// are audited through the policies of the roles that can be assumed.
+
+var errMissingRolePolicyName = errors.New("missing role policy name")
+
+// RolePolicy is an inline permission policy of a role
+type RolePolicy struct {
+	RoleName       string `json:"RoleName"`
+	PolicyName     string `json:"PolicyName"`
+	PolicyDocument string `json:"PolicyDocument"`
+}
+
+// AttachedRolePolicy is a managed policy attached to a role
+type AttachedRolePolicy struct {
+	PolicyName string `json:"PolicyName"`
+	PolicyARN  string `json:"PolicyArn"`
+}
+
+func roleArgs(roleName string) url.Values {
+	return valueToURLParams(Role{Name: roleName}, []string{"RoleName"})
+}
+
+// ListRolePolicies will return the names of the inline permission policies
+// of a role
+// https://docs.ceph.com/en/latest/radosgw/role/#list-permission-policy-names-attached-to-a-role
+func (api *API) ListRolePolicies(ctx context.Context, roleName string) ([]string, error) {
+	if roleName == "" {
+		return nil, errMissingRoleName
+	}
+
+	body, err := api.callIAM(ctx, "ListRolePolicies", roleArgs(roleName))
+	if err != nil {
+		return nil, err
+	}
+
+	ref := struct {
+		Response struct {
+			Result struct {
+				PolicyNames []string `json:"PolicyNames"`
+			} `json:"ListRolePoliciesResult"`
+		} `json:"ListRolePoliciesResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref.Response.Result.PolicyNames, nil
+}
+
+// GetRolePolicy will return an inline permission policy of a role
+// https://docs.ceph.com/en/latest/radosgw/role/#get-permission-policy-attached-to-a-role
+func (api *API) GetRolePolicy(ctx context.Context, roleName, policyName string) (RolePolicy, error) {
+	if roleName == "" {
+		return RolePolicy{}, errMissingRoleName
+	}
+	if policyName == "" {
+		return RolePolicy{}, errMissingRolePolicyName
+	}
+
+	args := roleArgs(roleName)
+	args.Set("PolicyName", policyName)
+	body, err := api.callIAM(ctx, "GetRolePolicy", args)
+	if err != nil {
+		return RolePolicy{}, err
+	}
+
+	ref := struct {
+		Response struct {
+			Result RolePolicy `json:"GetRolePolicyResult"`
+		} `json:"GetRolePolicyResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return RolePolicy{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref.Response.Result, nil
+}
+
+// ListAttachedRolePolicies will return the managed policies attached to a
+// role. Managed policies are only supported by RGW accounts.
+func (api *API) ListAttachedRolePolicies(ctx context.Context, roleName string) ([]AttachedRolePolicy, error) {
+	if roleName == "" {
+		return nil, errMissingRoleName
+	}
+
+	body, err := api.callIAM(ctx, "ListAttachedRolePolicies", roleArgs(roleName))
+	if err != nil {
+		return nil, err
+	}
+
+	ref := struct {
+		Response struct {
+			Result struct {
+				AttachedPolicies []AttachedRolePolicy `json:"AttachedPolicies"`
+			} `json:"ListAttachedRolePoliciesResult"`
+		} `json:"ListAttachedRolePoliciesResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref.Response.Result.AttachedPolicies, nil
+}
@@ -0,0 +1,85 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeListRolePoliciesResponse = []byte(`{"ListRolePoliciesResponse": {"ListRolePoliciesResult": {"PolicyNames": ["Policy1", "Policy2"]}}}`)
+	fakeGetRolePolicyResponse    = []byte(`{"GetRolePolicyResponse": {"GetRolePolicyResult": {
+  "PolicyName": "Policy1",
+  "RoleName": "S3Access",
+  "PolicyDocument": "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Effect\":\"Allow\",\"Action\":[\"s3:*\"],\"Resource\":\"arn:aws:s3:::example_bucket\"}]}"
+}}}`)
+	fakeListAttachedRolePoliciesResponse = []byte(`{"ListAttachedRolePoliciesResponse": {"ListAttachedRolePoliciesResult": {"AttachedPolicies": [
+  {"PolicyName": "AmazonS3ReadOnlyAccess", "PolicyArn": "arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"}
+]}}}`)
+)
+
+func returnRolePolicyMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.Method != http.MethodPost || req.URL.Path != "127.0.0.1/" || q.Get("RoleName") != "S3Access" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			status := 200
+			var body []byte
+			switch q.Get("Action") {
+			case "ListRolePolicies":
+				body = fakeListRolePoliciesResponse
+			case "GetRolePolicy":
+				if q.Get("PolicyName") != "Policy1" {
+					status, body = 404, fakeNoSuchEntity
+				} else {
+					body = fakeGetRolePolicyResponse
+				}
+			case "ListAttachedRolePolicies":
+				body = fakeListAttachedRolePoliciesResponse
+			default:
+				return nil, fmt.Errorf("unexpected action: %q", q.Get("Action"))
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestRolePolicies(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnRolePolicyMockClient())
+	require.NoError(t, err)
+
+	names, err := api.ListRolePolicies(context.TODO(), "S3Access")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Policy1", "Policy2"}, names)
+	_, err = api.ListRolePolicies(context.TODO(), "")
+	assert.ErrorIs(t, err, errMissingRoleName)
+
+	p, err := api.GetRolePolicy(context.TODO(), "S3Access", "Policy1")
+	require.NoError(t, err)
+	assert.Equal(t, "S3Access", p.RoleName)
+	assert.Contains(t, p.PolicyDocument, "example_bucket")
+	_, err = api.GetRolePolicy(context.TODO(), "S3Access", "Policy3")
+	assert.ErrorIs(t, err, ErrNoSuchEntity)
+	_, err = api.GetRolePolicy(context.TODO(), "S3Access", "")
+	assert.ErrorIs(t, err, errMissingRolePolicyName)
+
+	attached, err := api.ListAttachedRolePolicies(context.TODO(), "S3Access")
+	require.NoError(t, err)
+	assert.Equal(t, []AttachedRolePolicy{{
+		PolicyName: "AmazonS3ReadOnlyAccess",
+		PolicyARN:  "arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess",
+	}}, attached)
+}
@@ -0,0 +1,120 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeRole = `{
+  "RoleId": "9ed1e8d1-7fd8-41c8-a6b9-1a33d1afb53e",
+  "RoleName": "S3Access",
+  "Path": "/application_abc/component_xyz/",
+  "Arn": "arn:aws:iam:::role/application_abc/component_xyz/S3Access",
+  "CreateDate": "2024-01-01T00:00:00.000Z",
+  "MaxSessionDuration": 3600,
+  "AssumeRolePolicyDocument": "{\"Version\":\"2012-10-17\"}"
+}`
+
+	fakeCreateRoleResponse = []byte(fmt.Sprintf(`{"CreateRoleResponse": {"CreateRoleResult": {"Role": %s}}}`, fakeRole))
+	fakeGetRoleResponse    = []byte(fmt.Sprintf(`{"GetRoleResponse": {"GetRoleResult": {"Role": %s}}}`, fakeRole))
+	fakeListRolesResponse  = []byte(fmt.Sprintf(`{"ListRolesResponse": {"ListRolesResult": {"Roles": [%s]}}}`, fakeRole))
+	fakeNoSuchEntity       = []byte(`{"Code": "NoSuchEntity", "RequestId": "tx0", "HostId": "h"}`)
+)
+
+func returnRoleMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.Method != http.MethodPost || req.URL.Path != "127.0.0.1/" || q.Get("format") != "json" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			status := 200
+			var body []byte
+			switch q.Get("Action") {
+			case "CreateRole":
+				body = fakeCreateRoleResponse
+			case "GetRole":
+				if q.Get("RoleName") != "S3Access" {
+					status, body = 404, fakeNoSuchEntity
+				} else {
+					body = fakeGetRoleResponse
+				}
+			case "ListRoles":
+				body = fakeListRolesResponse
+			case "DeleteRole":
+			case "UpdateAssumeRolePolicy":
+				if q.Get("PolicyDocument") == "" {
+					return nil, fmt.Errorf("missing policy document: %q", req.URL.RawQuery)
+				}
+			default:
+				return nil, fmt.Errorf("unexpected action: %q", q.Get("Action"))
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestRoles(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnRoleMockClient())
+	require.NoError(t, err)
+	policy := `{"Version":"2012-10-17"}`
+
+	t.Run("create", func(t *testing.T) {
+		_, err := api.CreateRole(context.TODO(), Role{AssumeRolePolicyDocument: policy})
+		assert.ErrorIs(t, err, errMissingRoleName)
+		_, err = api.CreateRole(context.TODO(), Role{Name: "S3Access"})
+		assert.ErrorIs(t, err, errMissingRolePolicy)
+
+		role, err := api.CreateRole(context.TODO(), Role{
+			Name:                     "S3Access",
+			Path:                     "/application_abc/component_xyz/",
+			AssumeRolePolicyDocument: policy,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "arn:aws:iam:::role/application_abc/component_xyz/S3Access", role.ARN)
+		require.NotNil(t, role.MaxSessionDuration)
+		assert.Equal(t, 3600, *role.MaxSessionDuration)
+	})
+	t.Run("get", func(t *testing.T) {
+		role, err := api.GetRole(context.TODO(), "S3Access")
+		assert.NoError(t, err)
+		assert.Equal(t, "S3Access", role.Name)
+		assert.Equal(t, policy, role.AssumeRolePolicyDocument)
+
+		_, err = api.GetRole(context.TODO(), "missing")
+		assert.ErrorIs(t, err, ErrNoSuchEntity)
+		_, err = api.GetRole(context.TODO(), "")
+		assert.ErrorIs(t, err, errMissingRoleName)
+	})
+	t.Run("list", func(t *testing.T) {
+		roles, err := api.ListRoles(context.TODO(), "/application_abc/")
+		assert.NoError(t, err)
+		require.Len(t, roles, 1)
+		assert.Equal(t, "S3Access", roles[0].Name)
+	})
+	t.Run("updateAssumeRolePolicy", func(t *testing.T) {
+		err := api.UpdateAssumeRolePolicy(context.TODO(), "S3Access", policy)
+		assert.NoError(t, err)
+		err = api.UpdateAssumeRolePolicy(context.TODO(), "S3Access", "")
+		assert.ErrorIs(t, err, errMissingRolePolicy)
+	})
+	t.Run("delete", func(t *testing.T) {
+		err := api.DeleteRole(context.TODO(), "S3Access")
+		assert.NoError(t, err)
+		err = api.DeleteRole(context.TODO(), "")
+		assert.ErrorIs(t, err, errMissingRoleName)
+	})
+}
@@ -0,0 +1,77 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// s3Error is the error document of the S3 API
+type s3Error struct {
+	Code      string `xml:"Code"`
+	RequestID string `xml:"RequestId"`
+	HostID    string `xml:"HostId"`
+}
+
+// escapeObjectPath escapes the bucket and object for use as the path of an
+// S3 request
+func escapeObjectPath(bucket, object string) string {
+	segments := strings.Split(object, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return "/" + url.PathEscape(bucket) + "/" + strings.Join(segments, "/")
+}
+
+// callS3 sends a signed request to the S3 API of the RGW, for the operations
+// that the Admin Ops API does not provide. The credentials of the API must
+// grant access to the bucket, as is the case for system users. The path is
+// expected to be escaped already. The response is returned unless its
+// status indicates an error.
+func (api *API) callS3(ctx context.Context, httpMethod, path, query string) (*http.Response, []byte, error) {
+	requestURL := api.Endpoint + path
+	if query != "" {
+		requestURL += "?" + query
+	}
+	request, err := http.NewRequestWithContext(ctx, httpMethod, requestURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = api.sign(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := api.HTTPClient.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode < 300 {
+		return resp, body, nil
+	}
+	e := s3Error{}
+	if len(body) > 0 && xml.Unmarshal(body, &e) == nil && e.Code != "" {
+		return nil, nil, statusError{Code: e.Code, RequestID: e.RequestID, HostID: e.HostID, statusCode: resp.StatusCode}
+	}
+	// responses to HEAD requests have no body describing the error
+	code := ErrUnknown
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		code = ErrNoSuchObject
+	case http.StatusForbidden:
+		code = ErrAccessDenied
+	}
+	return nil, nil, statusError{Code: string(code), statusCode: resp.StatusCode}
+}
@@ -0,0 +1,115 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	errMissingRoleARN         = errors.New("missing role ARN")
+	errMissingRoleSessionName = errors.New("missing role session name")
+)
+
+// TemporaryCredentials are time limited credentials issued by the STS API
+type TemporaryCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	SessionToken    string `json:"SessionToken"`
+	// Expiration is the time the credentials expire at, in ISO 8601 format
+	Expiration string `json:"Expiration"`
+}
+
+// AssumedRoleUser identifies the session of an assumed role
+type AssumedRoleUser struct {
+	ARN           string `json:"Arn"`
+	AssumedRoleID string `json:"AssumedRoleId"`
+}
+
+// SessionTokenRequest contains the parameters of GetSessionToken
+type SessionTokenRequest struct {
+	// DurationSeconds is the validity of the credentials, the RGW default
+	// of one hour applies if unset
+	DurationSeconds *int `url:"DurationSeconds"`
+	// SerialNumber and TokenCode identify an MFA device and its current
+	// pin, if required
+	SerialNumber string `url:"SerialNumber"`
+	TokenCode    string `url:"TokenCode"`
+}
+
+// AssumeRoleRequest contains the parameters of AssumeRole
+type AssumeRoleRequest struct {
+	RoleARN         string `url:"RoleArn"`
+	RoleSessionName string `url:"RoleSessionName"`
+	DurationSeconds *int   `url:"DurationSeconds"`
+	// Policy is an optional session policy further restricting the
+	// permissions of the role
+	Policy       string `url:"Policy"`
+	ExternalID   string `url:"ExternalId"`
+	SerialNumber string `url:"SerialNumber"`
+	TokenCode    string `url:"TokenCode"`
+}
+
+// AssumeRoleResponse is the result of AssumeRole
+type AssumeRoleResponse struct {
+	Credentials      TemporaryCredentials `json:"Credentials"`
+	AssumedRoleUser  AssumedRoleUser      `json:"AssumedRoleUser"`
+	PackedPolicySize int                  `json:"PackedPolicySize"`
+}
+
+// GetSessionToken will return temporary credentials for the user of the API
+// credentials. The STS API must be enabled in the RGW.
+// https://docs.ceph.com/en/latest/radosgw/STS/#sts-rest-apis
+func (api *API) GetSessionToken(ctx context.Context, req SessionTokenRequest) (TemporaryCredentials, error) {
+	body, err := api.callIAM(ctx, "GetSessionToken", valueToURLParams(req, []string{"DurationSeconds", "SerialNumber", "TokenCode"}))
+	if err != nil {
+		return TemporaryCredentials{}, err
+	}
+
+	ref := struct {
+		Response struct {
+			Result struct {
+				Credentials TemporaryCredentials `json:"Credentials"`
+			} `json:"GetSessionTokenResult"`
+		} `json:"GetSessionTokenResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return TemporaryCredentials{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref.Response.Result.Credentials, nil
+}
+
+// AssumeRole will return temporary credentials for a role. The STS API must
+// be enabled in the RGW and the user of the API credentials must be allowed
+// to assume the role by its assume role policy.
+// https://docs.ceph.com/en/latest/radosgw/STS/#sts-rest-apis
+func (api *API) AssumeRole(ctx context.Context, req AssumeRoleRequest) (AssumeRoleResponse, error) {
+	if req.RoleARN == "" {
+		return AssumeRoleResponse{}, errMissingRoleARN
+	}
+	if req.RoleSessionName == "" {
+		return AssumeRoleResponse{}, errMissingRoleSessionName
+	}
+
+	body, err := api.callIAM(ctx, "AssumeRole", valueToURLParams(req, []string{"RoleArn", "RoleSessionName", "DurationSeconds", "Policy", "ExternalId", "SerialNumber", "TokenCode"}))
+	if err != nil {
+		return AssumeRoleResponse{}, err
+	}
+
+	ref := struct {
+		Response struct {
+			Result AssumeRoleResponse `json:"AssumeRoleResult"`
+		} `json:"AssumeRoleResponse"`
+	}{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return AssumeRoleResponse{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref.Response.Result, nil
+}
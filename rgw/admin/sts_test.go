@@ -0,0 +1,93 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeCredentials = `{
+  "AccessKeyId": "ASIAEXAMPLE",
+  "SecretAccessKey": "secret",
+  "SessionToken": "token",
+  "Expiration": "2024-01-01T01:00:00Z"
+}`
+
+	fakeGetSessionTokenResponse = []byte(fmt.Sprintf(`{"GetSessionTokenResponse": {"GetSessionTokenResult": {"Credentials": %s}}}`, fakeCredentials))
+	fakeAssumeRoleResponse      = []byte(fmt.Sprintf(`{"AssumeRoleResponse": {"AssumeRoleResult": {
+  "Credentials": %s,
+  "AssumedRoleUser": {"Arn": "arn:aws:sts:::assumed-role/S3Access/session", "AssumedRoleId": "id:session"},
+  "PackedPolicySize": 0
+}}}`, fakeCredentials))
+)
+
+func returnSTSMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.Method != http.MethodPost || req.URL.Path != "127.0.0.1/" || q.Get("format") != "json" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			var body []byte
+			switch q.Get("Action") {
+			case "GetSessionToken":
+				if q.Get("DurationSeconds") != "900" {
+					return nil, fmt.Errorf("unexpected duration: %q", req.URL.RawQuery)
+				}
+				body = fakeGetSessionTokenResponse
+			case "AssumeRole":
+				if q.Get("RoleArn") == "" || q.Get("RoleSessionName") != "session" {
+					return nil, fmt.Errorf("unexpected request: %q", req.URL.RawQuery)
+				}
+				body = fakeAssumeRoleResponse
+			default:
+				return nil, fmt.Errorf("unexpected action: %q", q.Get("Action"))
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestSTS(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnSTSMockClient())
+	require.NoError(t, err)
+	expected := TemporaryCredentials{
+		AccessKeyID:     "ASIAEXAMPLE",
+		SecretAccessKey: "secret",
+		SessionToken:    "token",
+		Expiration:      "2024-01-01T01:00:00Z",
+	}
+	duration := 900
+
+	t.Run("getSessionToken", func(t *testing.T) {
+		creds, err := api.GetSessionToken(context.TODO(), SessionTokenRequest{DurationSeconds: &duration})
+		require.NoError(t, err)
+		assert.Equal(t, expected, creds)
+	})
+	t.Run("assumeRole", func(t *testing.T) {
+		_, err := api.AssumeRole(context.TODO(), AssumeRoleRequest{RoleSessionName: "session"})
+		assert.ErrorIs(t, err, errMissingRoleARN)
+		_, err = api.AssumeRole(context.TODO(), AssumeRoleRequest{RoleARN: "arn:aws:iam:::role/S3Access"})
+		assert.ErrorIs(t, err, errMissingRoleSessionName)
+
+		resp, err := api.AssumeRole(context.TODO(), AssumeRoleRequest{
+			RoleARN:         "arn:aws:iam:::role/S3Access",
+			RoleSessionName: "session",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, resp.Credentials)
+		assert.Equal(t, "id:session", resp.AssumedRoleUser.AssumedRoleID)
+	})
+}
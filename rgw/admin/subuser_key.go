@@ -0,0 +1,83 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// subuserFullName returns the name of a subuser as reported in the keys of
+// the user, that is prefixed with the user ID
+func subuserFullName(uid, subuser string) string {
+	if strings.Contains(subuser, ":") {
+		return subuser
+	}
+	return uid + ":" + subuser
+}
+
+func validateSubuserKey(key UserKeySpec) error {
+	if key.UID == "" {
+		return errMissingUserID
+	}
+	if key.SubUser == "" {
+		return errMissingSubuserID
+	}
+	switch key.KeyType {
+	case "s3", "swift":
+	default:
+		return errUnsupportedKeyType
+	}
+	return nil
+}
+
+// CreateSubuserKey will generate a new key or add the specified one to a
+// subuser. KeyType must be "s3" or "swift". The keys of the subuser of the
+// given type are returned, the AccessKey of Swift keys being empty.
+// https://docs.ceph.com/en/latest/radosgw/adminops/#create-key
+func (api *API) CreateSubuserKey(ctx context.Context, key UserKeySpec) ([]UserKeySpec, error) {
+	if err := validateSubuserKey(key); err != nil {
+		return nil, err
+	}
+
+	body, err := api.call(ctx, http.MethodPut, "/user?key", valueToURLParams(key, []string{"uid", "subuser", "access-key", "secret-key", "key-type", "generate-key"}))
+	if err != nil {
+		return nil, err
+	}
+
+	ref := []UserKeySpec{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	// the response contains the keys of the user and all of its subusers
+	name := subuserFullName(key.UID, key.SubUser)
+	keys := []UserKeySpec{}
+	for _, k := range ref {
+		if k.User == name {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys, nil
+}
+
+// RemoveSubuserKey will remove a key of a subuser. KeyType must be "s3" or
+// "swift", the AccessKey is required for S3 keys only as a subuser has a
+// single Swift key.
+// https://docs.ceph.com/en/latest/radosgw/adminops/#remove-key
+func (api *API) RemoveSubuserKey(ctx context.Context, key UserKeySpec) error {
+	if err := validateSubuserKey(key); err != nil {
+		return err
+	}
+	if key.KeyType == "s3" && key.AccessKey == "" {
+		return errMissingUserAccessKey
+	}
+
+	_, err := api.call(ctx, http.MethodDelete, "/user?key", valueToURLParams(key, []string{"uid", "subuser", "access-key", "key-type"}))
+	return err
+}
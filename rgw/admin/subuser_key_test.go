@@ -0,0 +1,84 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeSubuserS3KeysResponse = []byte(`[
+  {"user": "leseb", "access_key": "EOE7FYCNOBZJ5VFV909G", "secret_key": "qmIqpWm8HxCzmynCrD6U6vKWi4hnDBndOnmxXNsV"},
+  {"user": "leseb:swift", "access_key": "AKIAB1", "secret_key": "s3cr3t"}
+]`)
+	fakeSubuserSwiftKeysResponse = []byte(`[
+  {"user": "leseb:swift", "secret_key": "sw1ft"},
+  {"user": "leseb:other", "secret_key": "0ther"}
+]`)
+)
+
+func returnSubuserKeyMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.URL.Path != "127.0.0.1/admin/user" || !q.Has("key") || q.Get("uid") != "leseb" || q.Get("subuser") != "swift" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			var body []byte
+			switch {
+			case req.Method == http.MethodPut && q.Get("key-type") == "s3":
+				body = fakeSubuserS3KeysResponse
+			case req.Method == http.MethodPut && q.Get("key-type") == "swift":
+				body = fakeSubuserSwiftKeysResponse
+			case req.Method == http.MethodDelete:
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestSubuserKeys(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnSubuserKeyMockClient())
+	require.NoError(t, err)
+	generate := true
+
+	keys, err := api.CreateSubuserKey(context.TODO(), UserKeySpec{UID: "leseb", SubUser: "swift", KeyType: "s3", GenerateKey: &generate})
+	require.NoError(t, err)
+	assert.Equal(t, []UserKeySpec{{User: "leseb:swift", AccessKey: "AKIAB1", SecretKey: "s3cr3t"}}, keys)
+
+	keys, err = api.CreateSubuserKey(context.TODO(), UserKeySpec{UID: "leseb", SubUser: "swift", KeyType: "swift"})
+	require.NoError(t, err)
+	assert.Equal(t, []UserKeySpec{{User: "leseb:swift", SecretKey: "sw1ft"}}, keys)
+
+	_, err = api.CreateSubuserKey(context.TODO(), UserKeySpec{UID: "leseb", KeyType: "s3"})
+	assert.ErrorIs(t, err, errMissingSubuserID)
+	_, err = api.CreateSubuserKey(context.TODO(), UserKeySpec{SubUser: "swift", KeyType: "s3"})
+	assert.ErrorIs(t, err, errMissingUserID)
+	_, err = api.CreateSubuserKey(context.TODO(), UserKeySpec{UID: "leseb", SubUser: "swift"})
+	assert.ErrorIs(t, err, errUnsupportedKeyType)
+
+	err = api.RemoveSubuserKey(context.TODO(), UserKeySpec{UID: "leseb", SubUser: "swift", KeyType: "swift"})
+	assert.NoError(t, err)
+	err = api.RemoveSubuserKey(context.TODO(), UserKeySpec{UID: "leseb", SubUser: "swift", KeyType: "s3", AccessKey: "AKIAB1"})
+	assert.NoError(t, err)
+	err = api.RemoveSubuserKey(context.TODO(), UserKeySpec{UID: "leseb", SubUser: "swift", KeyType: "s3"})
+	assert.ErrorIs(t, err, errMissingUserAccessKey)
+}
+
+func TestSubuserFullName(t *testing.T) {
+	assert.Equal(t, "leseb:swift", subuserFullName("leseb", "swift"))
+	assert.Equal(t, "leseb:swift", subuserFullName("leseb", "leseb:swift"))
+}
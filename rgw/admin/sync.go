@@ -0,0 +1,237 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+var errMissingSourceZone = errors.New("missing source zone")
+
+// syncRequest contains the parameters of the sync status requests
+type syncRequest struct {
+	Bucket     string `url:"bucket"`
+	SourceZone string `url:"source-zone"`
+	ShardID    int    `url:"id"`
+}
+
+// States of the data sync of a zone, as reported by DataSyncInfo.State
+const (
+	DataSyncStateInit                 = "init"
+	DataSyncStateBuildingFullSyncMaps = "building-full-sync-maps"
+	DataSyncStateSync                 = "sync"
+)
+
+// States of the sync of a shard, as reported by DataSyncMarker.State and
+// BucketShardSyncStatus.State
+const (
+	SyncStateInit            = "init"
+	SyncStateFullSync        = "full-sync"
+	SyncStateIncrementalSync = "incremental-sync"
+	SyncStateStopped         = "stopped"
+)
+
+// rgwTimeLayouts are the layouts the RGW formats timestamps with
+var rgwTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// parseRGWTime parses a timestamp as formatted by the RGW
+func parseRGWTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range rgwTimeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// DataSyncInfo reports the overall state of the data sync from a source zone
+type DataSyncInfo struct {
+	State      string `json:"status"`
+	NumShards  int    `json:"num_shards"`
+	InstanceID uint64 `json:"instance_id"`
+}
+
+// DataSyncMarker reports the sync position of a data log shard
+type DataSyncMarker struct {
+	State          string `json:"status"`
+	Marker         string `json:"marker"`
+	NextStepMarker string `json:"next_step_marker"`
+	TotalEntries   int64  `json:"total_entries"`
+	Pos            int64  `json:"pos"`
+	Timestamp      string `json:"timestamp"`
+}
+
+// DataSyncStatus reports the state of the data sync from a source zone. The
+// markers are indexed by data log shard.
+type DataSyncStatus struct {
+	Info    DataSyncInfo
+	Markers map[int]DataSyncMarker
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The RGW encodes
+// the markers as a list of key/value pairs.
+func (s *DataSyncStatus) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Info    DataSyncInfo `json:"info"`
+		Markers []struct {
+			Key int            `json:"key"`
+			Val DataSyncMarker `json:"val"`
+		} `json:"markers"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.Info = raw.Info
+	s.Markers = make(map[int]DataSyncMarker, len(raw.Markers))
+	for _, m := range raw.Markers {
+		s.Markers[m.Key] = m.Val
+	}
+	return nil
+}
+
+// DataLogShardInfo reports the position of a data log shard
+type DataLogShardInfo struct {
+	Marker     string `json:"marker"`
+	LastUpdate string `json:"last_update"`
+}
+
+// DataSyncLag summarizes how far the data sync from a source zone is behind
+// the data log of the source zone
+type DataSyncLag struct {
+	// ShardsBehind are the data log shards that have changes not yet synced
+	ShardsBehind []int
+	// OldestChange is the time of the last change synced on the shard that
+	// is furthest behind. Changes of that shard made after that time are
+	// not yet synced. It is zero if no shard is behind or the time is
+	// unknown.
+	OldestChange time.Time
+}
+
+// Lag compares the sync status with the data log shards of the source zone,
+// as returned by GetDataLogShardInfo called on the source zone, and returns
+// the shards that are behind. Shards that are still in full sync are always
+// behind.
+func (s DataSyncStatus) Lag(remote map[int]DataLogShardInfo) DataSyncLag {
+	lag := DataSyncLag{}
+	for shard, r := range remote {
+		m, ok := s.Markers[shard]
+		if ok && m.State == SyncStateIncrementalSync && m.Marker >= r.Marker {
+			continue
+		}
+		lag.ShardsBehind = append(lag.ShardsBehind, shard)
+		if !ok {
+			continue
+		}
+		t, err := parseRGWTime(m.Timestamp)
+		if err != nil || t.IsZero() || t.Unix() == 0 {
+			continue
+		}
+		if lag.OldestChange.IsZero() || t.Before(lag.OldestChange) {
+			lag.OldestChange = t
+		}
+	}
+	sort.Ints(lag.ShardsBehind)
+	return lag
+}
+
+// BucketShardSyncStatus reports the sync state of a bucket index shard
+type BucketShardSyncStatus struct {
+	State      string `json:"status"`
+	FullMarker struct {
+		Position string `json:"position"`
+		Count    int64  `json:"count"`
+	} `json:"full_marker"`
+	IncMarker struct {
+		Position  string `json:"position"`
+		Timestamp string `json:"timestamp"`
+	} `json:"inc_marker"`
+}
+
+// GetDataSyncStatus will return the state of the data sync from the given
+// source zone to the zone of the RGW
+func (api *API) GetDataSyncStatus(ctx context.Context, sourceZone string) (DataSyncStatus, error) {
+	if sourceZone == "" {
+		return DataSyncStatus{}, errMissingSourceZone
+	}
+
+	args := valueToURLParams(syncRequest{SourceZone: sourceZone}, []string{"source-zone"})
+	body, err := api.call(ctx, http.MethodGet, "/log?type=data&status", args)
+	if err != nil {
+		return DataSyncStatus{}, err
+	}
+
+	ref := DataSyncStatus{}
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		return DataSyncStatus{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return ref, nil
+}
+
+// GetDataLogShardInfo will return the position of the data log shards of the
+// zone of the RGW. numShards is the number of data log shards, as reported by
+// DataSyncInfo.NumShards.
+func (api *API) GetDataLogShardInfo(ctx context.Context, numShards int) (map[int]DataLogShardInfo, error) {
+	shards := make(map[int]DataLogShardInfo, numShards)
+	for shard := 0; shard < numShards; shard++ {
+		args := valueToURLParams(syncRequest{ShardID: shard}, []string{"id"})
+		body, err := api.call(ctx, http.MethodGet, "/log?type=data&info", args)
+		if err != nil {
+			return nil, err
+		}
+
+		ref := DataLogShardInfo{}
+		err = json.Unmarshal(body, &ref)
+		if err != nil {
+			return nil, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+		}
+		shards[shard] = ref
+	}
+
+	return shards, nil
+}
+
+// GetBucketSyncStatus will return the sync state of the index shards of a
+// bucket synced from the given source zone
+func (api *API) GetBucketSyncStatus(ctx context.Context, bucket, sourceZone string) ([]BucketShardSyncStatus, error) {
+	if bucket == "" {
+		return nil, errMissingBucket
+	}
+	if sourceZone == "" {
+		return nil, errMissingSourceZone
+	}
+
+	args := valueToURLParams(syncRequest{Bucket: bucket, SourceZone: sourceZone}, []string{"bucket", "source-zone"})
+	body, err := api.call(ctx, http.MethodGet, "/log?type=bucket-index&status", args)
+	if err != nil {
+		return nil, err
+	}
+
+	// depending on the version the shards are wrapped in a status object
+	var ref []BucketShardSyncStatus
+	err = json.Unmarshal(body, &ref)
+	if err != nil {
+		wrapped := struct {
+			Status []BucketShardSyncStatus `json:"status"`
+		}{}
+		if err2 := json.Unmarshal(body, &wrapped); err2 != nil {
+			return nil, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+		}
+		ref = wrapped.Status
+	}
+
+	return ref, nil
+}
@@ -0,0 +1,125 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeDataSyncStatusResponse = []byte(`{
+  "info": {"status": "sync", "num_shards": 3, "instance_id": 123456789},
+  "markers": [
+    {"key": 0, "val": {"status": "incremental-sync", "marker": "1_1700000000.000001_10.1", "next_step_marker": "", "total_entries": 10, "pos": 0, "timestamp": "2024-01-01T00:00:00.000000Z"}},
+    {"key": 1, "val": {"status": "incremental-sync", "marker": "1_1700000000.000001_5.1", "next_step_marker": "", "total_entries": 5, "pos": 0, "timestamp": "2024-01-02T00:00:00.000000Z"}},
+    {"key": 2, "val": {"status": "full-sync", "marker": "", "next_step_marker": "", "total_entries": 0, "pos": 0, "timestamp": "0.000000"}}
+  ]
+}`)
+	fakeDataLogShardInfo = map[string][]byte{
+		"0": []byte(`{"marker": "1_1700000000.000001_10.1", "last_update": "2024-01-01T00:00:00.000000Z"}`),
+		"1": []byte(`{"marker": "1_1700000000.000001_9.1", "last_update": "2024-01-03T00:00:00.000000Z"}`),
+		"2": []byte(`{"marker": "", "last_update": "0.000000"}`),
+	}
+	fakeBucketSyncStatusResponse = []byte(`[
+  {"status": "incremental-sync", "full_marker": {"position": "", "count": 0}, "inc_marker": {"position": "00000000001.1.5", "timestamp": "2024-01-01T00:00:00.000000Z"}},
+  {"status": "full-sync", "full_marker": {"position": "obj1", "count": 1}, "inc_marker": {"position": "", "timestamp": "0.000000"}}
+]`)
+)
+
+func returnSyncMockClient() *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.Method != http.MethodGet || req.URL.Path != "127.0.0.1/admin/log" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			var body []byte
+			switch {
+			case q.Get("type") == "data" && q.Has("status") && q.Get("source-zone") == "us-east":
+				body = fakeDataSyncStatusResponse
+			case q.Get("type") == "data" && q.Has("info"):
+				body = fakeDataLogShardInfo[q.Get("id")]
+			case q.Get("type") == "bucket-index" && q.Has("status") && q.Get("bucket") == "mybucket":
+				body = fakeBucketSyncStatusResponse
+				if q.Get("source-zone") == "wrapped" {
+					body = []byte(fmt.Sprintf(`{"status": %s}`, fakeBucketSyncStatusResponse))
+				}
+			}
+			if body == nil {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestDataSyncStatus(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnSyncMockClient())
+	require.NoError(t, err)
+
+	_, err = api.GetDataSyncStatus(context.TODO(), "")
+	assert.ErrorIs(t, err, errMissingSourceZone)
+
+	status, err := api.GetDataSyncStatus(context.TODO(), "us-east")
+	require.NoError(t, err)
+	assert.Equal(t, DataSyncStateSync, status.Info.State)
+	assert.Equal(t, 3, status.Info.NumShards)
+	require.Len(t, status.Markers, 3)
+	assert.Equal(t, SyncStateFullSync, status.Markers[2].State)
+
+	remote, err := api.GetDataLogShardInfo(context.TODO(), status.Info.NumShards)
+	require.NoError(t, err)
+	require.Len(t, remote, 3)
+	assert.Equal(t, "1_1700000000.000001_9.1", remote[1].Marker)
+
+	lag := status.Lag(remote)
+	assert.Equal(t, []int{1, 2}, lag.ShardsBehind)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), lag.OldestChange)
+
+	lag = DataSyncStatus{}.Lag(nil)
+	assert.Empty(t, lag.ShardsBehind)
+	assert.True(t, lag.OldestChange.IsZero())
+}
+
+func TestBucketSyncStatus(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnSyncMockClient())
+	require.NoError(t, err)
+
+	_, err = api.GetBucketSyncStatus(context.TODO(), "", "us-east")
+	assert.ErrorIs(t, err, errMissingBucket)
+	_, err = api.GetBucketSyncStatus(context.TODO(), "mybucket", "")
+	assert.ErrorIs(t, err, errMissingSourceZone)
+
+	for _, zone := range []string{"us-east", "wrapped"} {
+		shards, err := api.GetBucketSyncStatus(context.TODO(), "mybucket", zone)
+		require.NoError(t, err)
+		require.Len(t, shards, 2)
+		assert.Equal(t, SyncStateIncrementalSync, shards[0].State)
+		assert.Equal(t, "00000000001.1.5", shards[0].IncMarker.Position)
+		assert.Equal(t, SyncStateFullSync, shards[1].State)
+		assert.EqualValues(t, 1, shards[1].FullMarker.Count)
+	}
+}
+
+func TestParseRGWTime(t *testing.T) {
+	ts, err := parseRGWTime("2024-01-02T03:04:05.000006Z")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC), ts)
+	ts, err = parseRGWTime("2024-01-02 03:04:05.000006Z")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC), ts)
+	_, err = parseRGWTime("0.000000")
+	assert.Error(t, err)
+}
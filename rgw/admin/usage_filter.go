@@ -0,0 +1,137 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// usageTimeLayout is the layout of the times of the usage requests
+const usageTimeLayout = "2006-01-02 15:04:05"
+
+var (
+	errMissingUsageStart  = errors.New("missing usage start time")
+	errInvalidUsageWindow = errors.New("invalid usage window")
+)
+
+// UsageFilter selects the usage information returned by GetUsageFiltered and
+// WalkUsage, and removed by TrimUsageFiltered. Unset fields do not restrict
+// the usage information.
+type UsageFilter struct {
+	UserID string
+	Bucket string
+	// Start and End restrict the usage information to the time range
+	// [Start, End). The RGW logs usage per hour.
+	Start time.Time
+	End   time.Time
+	// Categories restricts the usage information to the given categories
+	// of operations, for example "get_obj" and "put_obj". It is not used by
+	// TrimUsageFiltered.
+	Categories  []string
+	ShowEntries *bool
+	ShowSummary *bool
+	// RemoveAll must be set to trim the usage information of all users,
+	// when UserID is empty.
+	RemoveAll *bool
+}
+
+// usageRequest contains the parameters of the filtered usage requests
+type usageRequest struct {
+	UserID      string `url:"uid"`
+	Bucket      string `url:"bucket"`
+	Start       string `url:"start"`
+	End         string `url:"end"`
+	Categories  string `url:"categories"`
+	ShowEntries *bool  `url:"show-entries"`
+	ShowSummary *bool  `url:"show-summary"`
+	RemoveAll   *bool  `url:"remove-all"`
+}
+
+func formatUsageTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(usageTimeLayout)
+}
+
+func (f UsageFilter) request() usageRequest {
+	return usageRequest{
+		UserID:      f.UserID,
+		Bucket:      f.Bucket,
+		Start:       formatUsageTime(f.Start),
+		End:         formatUsageTime(f.End),
+		Categories:  strings.Join(f.Categories, ","),
+		ShowEntries: f.ShowEntries,
+		ShowSummary: f.ShowSummary,
+		RemoveAll:   f.RemoveAll,
+	}
+}
+
+// GetUsageFiltered will return the usage information selected by the filter.
+// The filtering is done by the RGW.
+// https://docs.ceph.com/en/latest/radosgw/adminops/#get-usage
+func (api *API) GetUsageFiltered(ctx context.Context, filter UsageFilter) (Usage, error) {
+	body, err := api.call(ctx, http.MethodGet, "/usage", valueToURLParams(filter.request(), []string{"uid", "bucket", "start", "end", "categories", "show-entries", "show-summary"}))
+	if err != nil {
+		return Usage{}, err
+	}
+
+	u := Usage{}
+	err = json.Unmarshal(body, &u)
+	if err != nil {
+		return Usage{}, fmt.Errorf("%s. %s. %w", unmarshalError, string(body), err)
+	}
+
+	return u, nil
+}
+
+// TrimUsageFiltered will remove the usage information selected by the filter
+// https://docs.ceph.com/en/latest/radosgw/adminops/#trim-usage
+func (api *API) TrimUsageFiltered(ctx context.Context, filter UsageFilter) error {
+	_, err := api.call(ctx, http.MethodDelete, "/usage", valueToURLParams(filter.request(), []string{"uid", "bucket", "start", "end", "remove-all"}))
+	return err
+}
+
+// WalkUsage will retrieve the usage information selected by the filter in
+// consecutive time windows of the given length, and call fn with the usage
+// of each window, in order. This bounds the amount of usage information
+// retrieved at once. The filter must have a start time, if it has no end
+// time the current time is used. Walking stops at the first error returned
+// by fn, which is returned.
+func (api *API) WalkUsage(ctx context.Context, filter UsageFilter, window time.Duration,
+	fn func(start, end time.Time, usage Usage) error) error {
+
+	if filter.Start.IsZero() {
+		return errMissingUsageStart
+	}
+	if window <= 0 {
+		return errInvalidUsageWindow
+	}
+	end := filter.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	for start := filter.Start; start.Before(end); start = start.Add(window) {
+		f := filter
+		f.Start = start
+		f.End = start.Add(window)
+		if f.End.After(end) {
+			f.End = end
+		}
+		u, err := api.GetUsageFiltered(ctx, f)
+		if err != nil {
+			return err
+		}
+		if err := fn(f.Start, f.End, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fakeUsageResponse = []byte(`{
+  "entries": [
+    {
+      "user": "leseb",
+      "buckets": [
+        {
+          "bucket": "mybucket",
+          "time": "2024-01-01T00:00:00.000000Z",
+          "epoch": 1704067200,
+          "owner": "leseb",
+          "categories": [
+            {"category": "put_obj", "bytes_sent": 0, "bytes_received": 1024, "ops": 2, "successful_ops": 2}
+          ]
+        }
+      ]
+    }
+  ],
+  "summary": []
+}`)
+
+func returnUsageMockClient(queries *[]url.Values) *mockClient {
+	return &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "127.0.0.1/admin/usage" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			*queries = append(*queries, req.URL.Query())
+			var body []byte
+			if req.Method == http.MethodGet {
+				body = fakeUsageResponse
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestUsageFiltered(t *testing.T) {
+	var queries []url.Values
+	api, err := New("127.0.0.1", "accessKey", "secretKey", returnUsageMockClient(&queries))
+	require.NoError(t, err)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("get", func(t *testing.T) {
+		queries = nil
+		u, err := api.GetUsageFiltered(context.TODO(), UsageFilter{
+			UserID:     "leseb",
+			Bucket:     "mybucket",
+			Start:      start,
+			End:        start.Add(time.Hour),
+			Categories: []string{"put_obj", "get_obj"},
+		})
+		assert.NoError(t, err)
+		require.Len(t, u.Entries, 1)
+		assert.Equal(t, "mybucket", u.Entries[0].Buckets[0].Bucket)
+		require.Len(t, queries, 1)
+		q := queries[0]
+		assert.Equal(t, "leseb", q.Get("uid"))
+		assert.Equal(t, "mybucket", q.Get("bucket"))
+		assert.Equal(t, "2024-01-01 00:00:00", q.Get("start"))
+		assert.Equal(t, "2024-01-01 01:00:00", q.Get("end"))
+		assert.Equal(t, "put_obj,get_obj", q.Get("categories"))
+	})
+	t.Run("trim", func(t *testing.T) {
+		queries = nil
+		removeAll := true
+		err := api.TrimUsageFiltered(context.TODO(), UsageFilter{
+			Bucket:     "mybucket",
+			End:        start,
+			Categories: []string{"put_obj"},
+			RemoveAll:  &removeAll,
+		})
+		assert.NoError(t, err)
+		require.Len(t, queries, 1)
+		q := queries[0]
+		assert.Equal(t, "mybucket", q.Get("bucket"))
+		assert.Equal(t, "2024-01-01 00:00:00", q.Get("end"))
+		assert.Equal(t, "true", q.Get("remove-all"))
+		assert.False(t, q.Has("start"))
+		assert.False(t, q.Has("categories"))
+	})
+	t.Run("walk", func(t *testing.T) {
+		queries = nil
+		var windows [][2]time.Time
+		err := api.WalkUsage(context.TODO(), UsageFilter{Start: start, End: start.Add(150 * time.Minute)}, time.Hour,
+			func(s, e time.Time, u Usage) error {
+				windows = append(windows, [2]time.Time{s, e})
+				assert.Len(t, u.Entries, 1)
+				return nil
+			})
+		assert.NoError(t, err)
+		assert.Equal(t, [][2]time.Time{
+			{start, start.Add(time.Hour)},
+			{start.Add(time.Hour), start.Add(2 * time.Hour)},
+			{start.Add(2 * time.Hour), start.Add(150 * time.Minute)},
+		}, windows)
+		require.Len(t, queries, 3)
+		assert.Equal(t, "2024-01-01 02:30:00", queries[2].Get("end"))
+	})
+	t.Run("walkStop", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := api.WalkUsage(context.TODO(), UsageFilter{Start: start, End: start.Add(5 * time.Hour)}, time.Hour,
+			func(time.Time, time.Time, Usage) error {
+				calls++
+				return stop
+			})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+	})
+	t.Run("walkInvalid", func(t *testing.T) {
+		fn := func(time.Time, time.Time, Usage) error { return nil }
+		err := api.WalkUsage(context.TODO(), UsageFilter{}, time.Hour, fn)
+		assert.ErrorIs(t, err, errMissingUsageStart)
+		err = api.WalkUsage(context.TODO(), UsageFilter{Start: start}, 0, fn)
+		assert.ErrorIs(t, err, errInvalidUsageWindow)
+	})
+}
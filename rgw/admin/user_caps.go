@@ -0,0 +1,102 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// The permissions of a user capability, as used in UserCapSpec.Perm
+const (
+	UserCapPermRead  = "read"
+	UserCapPermWrite = "write"
+	UserCapPermAll   = "*"
+)
+
+// userCapTypes are the types of capabilities known to the RGW
+var userCapTypes = []string{
+	"users", "buckets", "metadata", "info", "usage", "zone", "bilog",
+	"mdlog", "datalog", "roles", "user-policy", "amz-cache",
+	"oidc-provider", "user-info-without-keys", "ratelimit", "accounts",
+}
+
+func (c UserCapSpec) validate() error {
+	if !contains(userCapTypes, c.Type) {
+		return fmt.Errorf("%w: capability type %q", ErrInvalidCapability, c.Type)
+	}
+	perms := strings.Split(c.Perm, ",")
+	for _, p := range perms {
+		switch strings.TrimSpace(p) {
+		case UserCapPermRead, UserCapPermWrite, UserCapPermAll:
+		default:
+			return fmt.Errorf("%w: capability permission %q", ErrInvalidCapability, c.Perm)
+		}
+	}
+	return nil
+}
+
+// FormatUserCaps validates the capabilities and formats them as expected by
+// the RGW, for example "users=read;buckets=*"
+func FormatUserCaps(caps []UserCapSpec) (string, error) {
+	if len(caps) == 0 {
+		return "", errMissingUserCap
+	}
+	s := make([]string, 0, len(caps))
+	for _, c := range caps {
+		if err := c.validate(); err != nil {
+			return "", err
+		}
+		s = append(s, c.Type+"="+strings.ReplaceAll(c.Perm, " ", ""))
+	}
+	return strings.Join(s, ";"), nil
+}
+
+// ParseUserCaps parses and validates capabilities formatted like
+// "users=read;buckets=*"
+func ParseUserCaps(s string) ([]UserCapSpec, error) {
+	var caps []UserCapSpec
+	for _, c := range strings.Split(s, ";") {
+		if strings.TrimSpace(c) == "" {
+			continue
+		}
+		t, p, found := strings.Cut(c, "=")
+		if !found {
+			return nil, fmt.Errorf("%w: capability %q", ErrInvalidCapability, c)
+		}
+		spec := UserCapSpec{Type: strings.TrimSpace(t), Perm: strings.TrimSpace(p)}
+		if err := spec.validate(); err != nil {
+			return nil, err
+		}
+		caps = append(caps, spec)
+	}
+	if len(caps) == 0 {
+		return nil, errMissingUserCap
+	}
+	return caps, nil
+}
+
+// AddUserCaps adds the capabilities to a user, like AddUserCap, after
+// validating them.
+//
+// On Success, it returns the updated list of UserCaps for the user.
+func (api *API) AddUserCaps(ctx context.Context, uid string, caps ...UserCapSpec) ([]UserCapSpec, error) {
+	userCap, err := FormatUserCaps(caps)
+	if err != nil {
+		return nil, err
+	}
+	return api.AddUserCap(ctx, uid, userCap)
+}
+
+// RemoveUserCaps removes the capabilities from a user, like RemoveUserCap,
+// after validating them.
+//
+// On Success, it returns the updated list of UserCaps for the user.
+func (api *API) RemoveUserCaps(ctx context.Context, uid string, caps ...UserCapSpec) ([]UserCapSpec, error) {
+	userCap, err := FormatUserCaps(caps)
+	if err != nil {
+		return nil, err
+	}
+	return api.RemoveUserCap(ctx, uid, userCap)
+}
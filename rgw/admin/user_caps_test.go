@@ -0,0 +1,83 @@
+//go:build ceph_preview
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatUserCaps(t *testing.T) {
+	s, err := FormatUserCaps([]UserCapSpec{
+		{Type: "users", Perm: UserCapPermRead},
+		{Type: "buckets", Perm: UserCapPermAll},
+		{Type: "usage", Perm: "read, write"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "users=read;buckets=*;usage=read,write", s)
+
+	_, err = FormatUserCaps(nil)
+	assert.ErrorIs(t, err, errMissingUserCap)
+	_, err = FormatUserCaps([]UserCapSpec{{Type: "user", Perm: UserCapPermRead}})
+	assert.ErrorIs(t, err, ErrInvalidCapability)
+	_, err = FormatUserCaps([]UserCapSpec{{Type: "users", Perm: "all"}})
+	assert.ErrorIs(t, err, ErrInvalidCapability)
+}
+
+func TestParseUserCaps(t *testing.T) {
+	caps, err := ParseUserCaps("users=read; buckets=*;usage=read,write;")
+	require.NoError(t, err)
+	assert.Equal(t, []UserCapSpec{
+		{Type: "users", Perm: "read"},
+		{Type: "buckets", Perm: "*"},
+		{Type: "usage", Perm: "read,write"},
+	}, caps)
+
+	_, err = ParseUserCaps("")
+	assert.ErrorIs(t, err, errMissingUserCap)
+	_, err = ParseUserCaps("users")
+	assert.ErrorIs(t, err, ErrInvalidCapability)
+	_, err = ParseUserCaps("users=execute")
+	assert.ErrorIs(t, err, ErrInvalidCapability)
+}
+
+func TestUserCapsMockAPI(t *testing.T) {
+	api, err := New("127.0.0.1", "accessKey", "secretKey", &mockClient{
+		mockDo: func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.URL.Path != "127.0.0.1/admin/user" || !q.Has("caps") || q.Get("user-caps") != "users=read;buckets=*" {
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			body := `[{"type": "buckets", "perm": "*"}, {"type": "users", "perm": "read"}]`
+			if req.Method == http.MethodDelete {
+				body = `[]`
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			}, nil
+		},
+	})
+	require.NoError(t, err)
+	caps := []UserCapSpec{{Type: "users", Perm: UserCapPermRead}, {Type: "buckets", Perm: UserCapPermAll}}
+
+	added, err := api.AddUserCaps(context.TODO(), "leseb", caps...)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, caps, added)
+
+	removed, err := api.RemoveUserCaps(context.TODO(), "leseb", caps...)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+
+	_, err = api.AddUserCaps(context.TODO(), "leseb", UserCapSpec{Type: "nope", Perm: UserCapPermRead})
+	assert.ErrorIs(t, err, ErrInvalidCapability)
+	_, err = api.AddUserCaps(context.TODO(), "", caps...)
+	assert.ErrorIs(t, err, errMissingUserID)
+}